@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/session"
+)
+
+// SessionMiddleware is middleware.SessionMiddleware for any SessionManager,
+// it sets the same context keys
+func SessionMiddleware(next http.Handler, sm SessionManager) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		c, err := r.Cookie("session_id")
+		if err == nil {
+			uid, err := sm.Get(c.Value)
+			switch err {
+			case nil:
+				ctx = context.WithValue(ctx, middleware.KeyIsAuthenticated, true)
+				ctx = context.WithValue(ctx, middleware.KeySessionID, c.Value)
+				ctx = context.WithValue(ctx, middleware.KeyUserID, uid)
+				// the list of the sessions shows when they were used last
+				sm.TouchSession(c.Value)
+			case session.ErrKeyNotFound:
+				// delete invalid cookie
+				c.Expires = time.Now().AddDate(0, 0, -1)
+				http.SetCookie(w, c)
+				ctx = context.WithValue(ctx, middleware.KeyIsAuthenticated, false)
+			default:
+				logger.Error(err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		} else { // ErrNoCookie
+			ctx = context.WithValue(ctx, middleware.KeyIsAuthenticated, false)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
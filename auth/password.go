@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	HashBcrypt   = "bcrypt"
+	HashArgon2id = "argon2id"
+
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+
+	// the password of the hash compared when there is no user
+	dummyPassword = "dummy password"
+)
+
+var (
+	ErrUnknownHash   = fmt.Errorf("unknown password hash")
+	ErrMalformedHash = fmt.Errorf("malformed password hash")
+)
+
+// PasswordHasher hashes the passwords by the policy, the hashes are encoded in PHC
+// string format: $argon2id$v=19$m=65536,t=3,p=4$salt$hash, bcrypt keeps its own $2a$cost$...
+type PasswordHasher struct {
+	Algorithm  string
+	BcryptCost int
+	Argon2Time uint32
+	// in KiB
+	Argon2Memory  uint32
+	Argon2Threads uint8
+
+	// a hash takes Argon2Memory, the hashes over the limit wait for the free slot
+	slots chan struct{}
+
+	dummyOnce sync.Once
+	dummy     string
+	dummyErr  error
+}
+
+// NewPasswordHasher runs up to concurrency hashes and compares at the same time, 0 is unlimited
+func NewPasswordHasher(algorithm string, bcryptCost int, argon2Time, argon2Memory uint32,
+	argon2Threads uint8, concurrency int) (*PasswordHasher, error) {
+	switch algorithm {
+	case HashBcrypt:
+		if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be from %v to %v", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case HashArgon2id:
+		if argon2Time == 0 || argon2Memory < 8*uint32(argon2Threads) || argon2Threads == 0 {
+			return nil, fmt.Errorf("argon2id needs time and threads above 0 and at least 8 KiB of memory per thread")
+		}
+	default:
+		return nil, fmt.Errorf("unknown hashing algorithm: %v", algorithm)
+	}
+	if concurrency < 0 {
+		return nil, fmt.Errorf("hashing concurrency can't be negative")
+	}
+
+	h := &PasswordHasher{
+		Algorithm:     algorithm,
+		BcryptCost:    bcryptCost,
+		Argon2Time:    argon2Time,
+		Argon2Memory:  argon2Memory,
+		Argon2Threads: argon2Threads,
+	}
+	if concurrency > 0 {
+		h.slots = make(chan struct{}, concurrency)
+	}
+
+	return h, nil
+}
+
+// acquire waits for the free slot, the returned func frees it
+func (h *PasswordHasher) acquire() func() {
+	if h.slots == nil {
+		return func() {}
+	}
+	h.slots <- struct{}{}
+
+	return func() { <-h.slots }
+}
+
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	defer h.acquire()()
+
+	if h.Algorithm == HashBcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+
+		return string(hash), nil
+	}
+
+	salt := make([]byte, argon2SaltLen)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+	p := argon2Params{
+		time:    h.Argon2Time,
+		memory:  h.Argon2Memory,
+		threads: h.Argon2Threads,
+		salt:    salt,
+	}
+	p.key = argon2.IDKey([]byte(password), salt, p.time, p.memory, p.threads, argon2KeyLen)
+
+	return p.encode(), nil
+}
+
+// Compare checks the password against the hash of any supported algorithm, outdated is true
+// if the password matches but the hash is weaker than the policy or made by another algorithm
+func (h *PasswordHasher) Compare(hashed, clean string) (match, outdated bool, err error) {
+	defer h.acquire()()
+
+	switch {
+	case strings.HasPrefix(hashed, "$2"):
+		err = bcrypt.CompareHashAndPassword([]byte(hashed), []byte(clean))
+		switch err {
+		case nil:
+		case bcrypt.ErrMismatchedHashAndPassword:
+			return false, false, nil
+		default:
+			return false, false, err
+		}
+		cost, err := bcrypt.Cost([]byte(hashed))
+		if err != nil {
+			return false, false, err
+		}
+
+		return true, h.Algorithm != HashBcrypt || cost < h.BcryptCost, nil
+	case strings.HasPrefix(hashed, "$"+HashArgon2id+"$"):
+		p, err := decodeArgon2(hashed)
+		if err != nil {
+			return false, false, err
+		}
+		key := argon2.IDKey([]byte(clean), p.salt, p.time, p.memory, p.threads, uint32(len(p.key)))
+		if subtle.ConstantTimeCompare(key, p.key) != 1 {
+			return false, false, nil
+		}
+
+		return true, h.Algorithm != HashArgon2id || p.time < h.Argon2Time || p.memory < h.Argon2Memory ||
+			p.threads < h.Argon2Threads, nil
+	default:
+		return false, false, ErrUnknownHash
+	}
+}
+
+// CompareDummy takes as long as Compare of the hash made by the policy, so the login
+// of the unknown email can't be told from the wrong password by the time of the response
+func (h *PasswordHasher) CompareDummy(clean string) {
+	h.dummyOnce.Do(func() {
+		h.dummy, h.dummyErr = h.Hash(dummyPassword)
+	})
+	if h.dummyErr != nil {
+		return
+	}
+	_, _, _ = h.Compare(h.dummy, clean)
+}
+
+type argon2Params struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+func (p *argon2Params) encode() string {
+	return fmt.Sprintf("$%v$v=%v$m=%v,t=%v,p=%v$%v$%v", HashArgon2id, argon2.Version,
+		p.memory, p.time, p.threads,
+		base64.RawStdEncoding.EncodeToString(p.salt), base64.RawStdEncoding.EncodeToString(p.key))
+}
+
+func decodeArgon2(hashed string) (*argon2Params, error) {
+	parts := strings.Split(hashed, "$")
+	// "", algorithm, version, params, salt, hash
+	if len(parts) != 6 {
+		return nil, ErrMalformedHash
+	}
+	version := 0
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return nil, ErrMalformedHash
+	}
+	p := &argon2Params{}
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads)
+	if err != nil {
+		return nil, ErrMalformedHash
+	}
+	p.salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, ErrMalformedHash
+	}
+	p.key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(p.key) == 0 {
+		return nil, ErrMalformedHash
+	}
+
+	return p, nil
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPasswordHasher(t *testing.T) {
+	bcryptWeak, _ := NewPasswordHasher(HashBcrypt, 4, 0, 0, 0, 0)
+	bcryptStrong, _ := NewPasswordHasher(HashBcrypt, 5, 0, 0, 0, 0)
+	argonWeak, _ := NewPasswordHasher(HashArgon2id, 0, 1, 64, 1, 0)
+	argonStrong, _ := NewPasswordHasher(HashArgon2id, 0, 2, 64, 1, 0)
+
+	tests := []struct {
+		name     string
+		hashedBy *PasswordHasher
+		policy   *PasswordHasher
+		outdated bool
+	}{
+		{"same bcrypt", bcryptWeak, bcryptWeak, false},
+		{"stronger bcrypt", bcryptStrong, bcryptWeak, false},
+		{"weaker bcrypt", bcryptWeak, bcryptStrong, true},
+		{"bcrypt to argon2id", bcryptWeak, argonWeak, true},
+		{"same argon2id", argonWeak, argonWeak, false},
+		{"weaker argon2id", argonWeak, argonStrong, true},
+		{"argon2id to bcrypt", argonStrong, bcryptStrong, true},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := tc.hashedBy.Hash("password")
+			if err != nil {
+				t.Fatal(err)
+			}
+			match, outdated, err := tc.policy.Compare(hash, "password")
+			if err != nil || !match || outdated != tc.outdated {
+				t.Fatalf("expected match and outdated %v, got %v %v %v", tc.outdated, match, outdated, err)
+			}
+			match, outdated, err = tc.policy.Compare(hash, "wrong")
+			if err != nil || match || outdated {
+				t.Fatalf("wrong password: got %v %v %v", match, outdated, err)
+			}
+		})
+	}
+}
+
+func TestCompareDummy(t *testing.T) {
+	bcryptHasher, _ := NewPasswordHasher(HashBcrypt, 4, 0, 0, 0, 0)
+	argonHasher, _ := NewPasswordHasher(HashArgon2id, 0, 1, 64, 1, 0)
+	for _, h := range []*PasswordHasher{bcryptHasher, argonHasher} {
+		h.CompareDummy("password")
+		h.CompareDummy("password")
+		// the dummy hash costs as much as the hashes of the policy
+		match, outdated, err := h.Compare(h.dummy, dummyPassword)
+		if err != nil || !match || outdated {
+			t.Fatalf("%v: unexpected dummy hash %q: %v %v %v", h.Algorithm, h.dummy, match, outdated, err)
+		}
+	}
+}
+
+func TestPasswordHasherEncoding(t *testing.T) {
+	h, _ := NewPasswordHasher(HashArgon2id, 0, 1, 64, 1, 0)
+	hash, err := h.Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") || len(hash) > 255 {
+		t.Fatalf("unexpected hash: %v", hash)
+	}
+	other, _ := h.Hash("password")
+	if other == hash {
+		t.Fatal("hashes are not salted")
+	}
+
+	for _, malformed := range []string{
+		"$argon2id$v=19$m=64,t=1,p=1$salt",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=64$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=64,t=1,p=1$!!$aGFzaA",
+	} {
+		if _, _, err := h.Compare(malformed, "password"); err != ErrMalformedHash {
+			t.Fatalf("%v: expected %v, got %v", malformed, ErrMalformedHash, err)
+		}
+	}
+	if _, _, err := h.Compare("plain", "password"); err != ErrUnknownHash {
+		t.Fatalf("expected %v, got %v", ErrUnknownHash, err)
+	}
+
+	for _, policy := range [][]uint32{{0, 64, 1}, {1, 64, 0}, {1, 4, 1}} {
+		if _, err := NewPasswordHasher(HashArgon2id, 0, policy[0], policy[1], uint8(policy[2]), 0); err == nil {
+			t.Fatalf("invalid argon2id policy %v is accepted", policy)
+		}
+	}
+	if _, err := NewPasswordHasher(HashBcrypt, 3, 0, 0, 0, 0); err == nil {
+		t.Fatal("invalid bcrypt cost is accepted")
+	}
+	if _, err := NewPasswordHasher("md5", 0, 0, 0, 0, 0); err == nil {
+		t.Fatal("unknown algorithm is accepted")
+	}
+	if _, err := NewPasswordHasher(HashBcrypt, 4, 0, 0, 0, -1); err == nil {
+		t.Fatal("negative concurrency is accepted")
+	}
+}
+
+func TestPasswordHasherConcurrency(t *testing.T) {
+	h, _ := NewPasswordHasher(HashArgon2id, 0, 1, 64, 1, 1)
+	release := h.acquire()
+	done := make(chan struct{})
+	go func() {
+		_, _ = h.Hash("password")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("hash doesn't wait for the busy slot")
+	case <-time.After(50 * time.Millisecond):
+	}
+	release()
+	<-done
+}
@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/session"
+
+	"api/models"
+)
+
+// SessionClient creates and checks the sessions of the players, it is the client of auth-service
+type SessionClient interface {
+	Create(uID uint) (string, error)
+	Get(sID string) (uint, error)
+	Delete(sID string) error
+	Close() error
+}
+
+// SessionManager also finds the sessions of a player, it is implemented
+// by TrackedSessionManager and by MemorySessionManager
+type SessionManager interface {
+	SessionClient
+	// CreateSession creates the session of info.UserID and remembers the device
+	CreateSession(info *models.SessionInfo) (string, error)
+	// GetUserSessions returns the sessions of the user, the last seen first
+	GetUserSessions(uID uint) ([]models.SessionInfo, error)
+	// DeleteUserSessions logs the user out everywhere except the session
+	DeleteUserSessions(uID uint, except string) error
+	// TouchSession marks the session as seen now, it is called on every request
+	// so it doesn't wait for the database
+	TouchSession(sID string)
+}
+
+var (
+	_ SessionClient  = (*session.SessionManager)(nil)
+	_ SessionManager = (*MemorySessionManager)(nil)
+)
+
+// MemorySessionManager keeps the sessions in the process memory
+// so the service can run without auth-service
+type MemorySessionManager struct {
+	mu       sync.RWMutex
+	sessions map[string]models.SessionInfo
+}
+
+func NewMemorySessionManager() *MemorySessionManager {
+	return &MemorySessionManager{
+		sessions: make(map[string]models.SessionInfo),
+	}
+}
+
+// newSessionID returns a random UUID like the ones of auth-service
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+func (sm *MemorySessionManager) Create(uID uint) (string, error) {
+	return sm.CreateSession(&models.SessionInfo{UserID: uID})
+}
+
+func (sm *MemorySessionManager) CreateSession(info *models.SessionInfo) (string, error) {
+	sID, err := newSessionID()
+	if err != nil {
+		return "", err
+	}
+	s := *info
+	s.SessionID = sID
+	s.CreatedAt = time.Now()
+	s.LastSeenAt = s.CreatedAt
+
+	sm.mu.Lock()
+	sm.sessions[sID] = s
+	sm.mu.Unlock()
+
+	return sID, nil
+}
+
+func (sm *MemorySessionManager) Get(sID string) (uint, error) {
+	sm.mu.RLock()
+	s, ok := sm.sessions[sID]
+	sm.mu.RUnlock()
+	if !ok {
+		return 0, session.ErrKeyNotFound
+	}
+
+	return s.UserID, nil
+}
+
+func (sm *MemorySessionManager) Delete(sID string) error {
+	sm.mu.Lock()
+	delete(sm.sessions, sID)
+	sm.mu.Unlock()
+
+	return nil
+}
+
+func (sm *MemorySessionManager) GetUserSessions(uID uint) ([]models.SessionInfo, error) {
+	sessions := []models.SessionInfo{}
+	sm.mu.RLock()
+	for _, s := range sm.sessions {
+		if s.UserID == uID {
+			sessions = append(sessions, s)
+		}
+	}
+	sm.mu.RUnlock()
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].LastSeenAt.Equal(sessions[j].LastSeenAt) {
+			return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+		}
+		return sessions[i].SessionID < sessions[j].SessionID
+	})
+
+	return sessions, nil
+}
+
+func (sm *MemorySessionManager) DeleteUserSessions(uID uint, except string) error {
+	sm.mu.Lock()
+	for sID, s := range sm.sessions {
+		if s.UserID == uID && sID != except {
+			delete(sm.sessions, sID)
+		}
+	}
+	sm.mu.Unlock()
+
+	return nil
+}
+
+func (sm *MemorySessionManager) TouchSession(sID string) {
+	sm.mu.Lock()
+	if s, ok := sm.sessions[sID]; ok {
+		s.LastSeenAt = time.Now()
+		sm.sessions[sID] = s
+	}
+	sm.mu.Unlock()
+}
+
+func (sm *MemorySessionManager) Close() error {
+	return nil
+}
+
+// PingSessionManager makes the cheapest call to the session manager,
+// it looks up the session which never exists
+func PingSessionManager(sm SessionClient) error {
+	_, err := sm.Get("")
+	if err == session.ErrKeyNotFound {
+		return nil
+	}
+
+	return err
+}
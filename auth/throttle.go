@@ -0,0 +1,260 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"api/metrics"
+	"api/models"
+)
+
+const (
+	ScopeAccount = "account"
+	ScopeAddress = "address"
+
+	// the delay stops doubling long before the cap overflows
+	maxBackoffShift = 30
+
+	// the keys of the password resets don't clash with the ones of the logins in the same store
+	passwordResetPrefix = "reset:"
+)
+
+// LoginAttemptStore counts the failed logins in a row for every key
+type LoginAttemptStore interface {
+	// ReserveLoginAttempt counts the attempt as a failure before the password is compared
+	// unless retryAfter makes it wait after the failures so far, the failures before forgetBefore
+	// are forgotten. The attempts of the key wait for each other, so no more of them pass
+	// than retryAfter lets
+	ReserveLoginAttempt(ctx context.Context, key string, at, forgetBefore time.Time,
+		retryAfter func(models.LoginFailures) time.Duration) (time.Duration, error)
+	// ReleaseLoginAttempt takes back the reserved attempt which hasn't failed
+	ReleaseLoginAttempt(ctx context.Context, key string) error
+	ResetLoginFailures(ctx context.Context, key string) error
+	// ForgetLoginFailures removes the keys without failures since before
+	ForgetLoginFailures(ctx context.Context, before time.Time) error
+}
+
+// ThrottlePolicy lets FreeAttempts failures go without a delay, then the next login waits
+// for BaseDelay doubled with every failure up to MaxDelay. After LockoutAfter failures
+// the logins wait until the failures are forgotten, 0 never locks out
+type ThrottlePolicy struct {
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	LockoutAfter int
+	// the failures are forgotten after this time without new ones
+	ForgetAfter time.Duration
+}
+
+// retryAfter returns how long the key waits before the next login
+func (p *ThrottlePolicy) retryAfter(f models.LoginFailures, now time.Time) time.Duration {
+	forgetAt := f.LastFailureAt.Add(p.ForgetAfter)
+	if !now.Before(forgetAt) || f.Failures < p.FreeAttempts {
+		return 0
+	}
+	if p.LockoutAfter > 0 && f.Failures >= p.LockoutAfter {
+		return forgetAt.Sub(now)
+	}
+
+	shift := uint(f.Failures - p.FreeAttempts)
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+	delay := p.BaseDelay << shift
+	if delay > p.MaxDelay || delay <= 0 {
+		delay = p.MaxDelay
+	}
+	if wait := f.LastFailureAt.Add(delay).Sub(now); wait > 0 {
+		return wait
+	}
+
+	return 0
+}
+
+// LoginThrottle slows down guessing the passwords of an account and from an address
+type LoginThrottle struct {
+	store   LoginAttemptStore
+	account ThrottlePolicy
+	address ThrottlePolicy
+
+	prefix    string
+	throttled *prometheus.CounterVec
+}
+
+func NewLoginThrottle(store LoginAttemptStore, account, address ThrottlePolicy) *LoginThrottle {
+	return &LoginThrottle{
+		store:     store,
+		account:   account,
+		address:   address,
+		throttled: metrics.LoginsThrottled,
+	}
+}
+
+// NewPasswordResetThrottle limits the reset letters asked for an email and from an address.
+// Every request is counted as a failure and is never taken back. The store may be the one
+// of the logins, the failures are forgotten by Forget of the login throttle if it forgets
+// them no sooner than the policies of the resets do
+func NewPasswordResetThrottle(store LoginAttemptStore, account, address ThrottlePolicy) *LoginThrottle {
+	return &LoginThrottle{
+		store:     store,
+		account:   account,
+		address:   address,
+		prefix:    passwordResetPrefix,
+		throttled: metrics.PasswordResetsThrottled,
+	}
+}
+
+func (lt *LoginThrottle) accountKey(email string) string {
+	return lt.prefix + "email:" + strings.ToLower(email)
+}
+
+func (lt *LoginThrottle) addressKey(ip string) string {
+	return lt.prefix + "ip:" + ip
+}
+
+func (lt *LoginThrottle) reserve(ctx context.Context, key string, p *ThrottlePolicy, scope string,
+	now time.Time) (time.Duration, error) {
+	wait, err := lt.store.ReserveLoginAttempt(ctx, key, now, now.Add(-p.ForgetAfter),
+		func(f models.LoginFailures) time.Duration {
+			return p.retryAfter(f, now)
+		})
+	if err == nil && wait > 0 {
+		lt.throttled.WithLabelValues(scope).Inc()
+	}
+
+	return wait, err
+}
+
+// Reserve returns how long the login has to wait, 0 if it may go on. The login which goes on
+// is counted as a failure of the account, even if it doesn't exist, and of the address
+// until Succeed or Release, so the concurrent logins can't pass together
+func (lt *LoginThrottle) Reserve(ctx context.Context, email, ip string) (time.Duration, error) {
+	now := time.Now()
+	wait, err := lt.reserve(ctx, lt.accountKey(email), &lt.account, ScopeAccount, now)
+	if err != nil || wait > 0 {
+		return wait, err
+	}
+
+	wait, err = lt.reserve(ctx, lt.addressKey(ip), &lt.address, ScopeAddress, now)
+	if err != nil || wait > 0 {
+		if relErr := lt.store.ReleaseLoginAttempt(ctx, lt.accountKey(email)); err == nil {
+			err = relErr
+		}
+	}
+
+	return wait, err
+}
+
+// Fail leaves the reserved attempt counted
+func (lt *LoginThrottle) Fail() {
+	metrics.LoginFailures.Inc()
+}
+
+// Succeed forgets the failures of the account, the address may still be guessing the others
+// so only its reserved attempt is taken back
+func (lt *LoginThrottle) Succeed(ctx context.Context, email, ip string) error {
+	err := lt.store.ResetLoginFailures(ctx, lt.accountKey(email))
+	if err != nil {
+		return err
+	}
+
+	return lt.store.ReleaseLoginAttempt(ctx, lt.addressKey(ip))
+}
+
+// Release takes back the reserved attempt which couldn't be checked
+func (lt *LoginThrottle) Release(ctx context.Context, email, ip string) error {
+	err := lt.store.ReleaseLoginAttempt(ctx, lt.accountKey(email))
+	if err != nil {
+		return err
+	}
+
+	return lt.store.ReleaseLoginAttempt(ctx, lt.addressKey(ip))
+}
+
+// Forget removes the failures of both scopes which are forgotten already
+func (lt *LoginThrottle) Forget(ctx context.Context) error {
+	forgetAfter := lt.account.ForgetAfter
+	if lt.address.ForgetAfter > forgetAfter {
+		forgetAfter = lt.address.ForgetAfter
+	}
+
+	return lt.store.ForgetLoginFailures(ctx, time.Now().Add(-forgetAfter))
+}
+
+// MemoryLoginAttemptStore keeps the failures in the process memory,
+// every instance of the service counts them on its own
+type MemoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	failures map[string]models.LoginFailures
+}
+
+var _ LoginAttemptStore = (*MemoryLoginAttemptStore)(nil)
+
+func NewMemoryLoginAttemptStore() *MemoryLoginAttemptStore {
+	return &MemoryLoginAttemptStore{
+		failures: make(map[string]models.LoginFailures),
+	}
+}
+
+func (s *MemoryLoginAttemptStore) ReserveLoginAttempt(_ context.Context, key string, at, forgetBefore time.Time,
+	retryAfter func(models.LoginFailures) time.Duration) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.failures[key]
+	if !ok || f.LastFailureAt.Before(forgetBefore) {
+		f = models.LoginFailures{Key: key}
+	}
+	if wait := retryAfter(f); wait > 0 {
+		return wait, nil
+	}
+	f.Failures++
+	f.LastFailureAt = at
+	s.failures[key] = f
+
+	return 0, nil
+}
+
+func (s *MemoryLoginAttemptStore) ReleaseLoginAttempt(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.failures[key]
+	if !ok {
+		return nil
+	}
+	f.Failures--
+	if f.Failures <= 0 {
+		delete(s.failures, key)
+		return nil
+	}
+	s.failures[key] = f
+
+	return nil
+}
+
+func (s *MemoryLoginAttemptStore) ResetLoginFailures(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.failures, key)
+
+	return nil
+}
+
+func (s *MemoryLoginAttemptStore) ForgetLoginFailures(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, f := range s.failures {
+		if f.LastFailureAt.Before(before) {
+			delete(s.failures, k)
+		}
+	}
+
+	return nil
+}
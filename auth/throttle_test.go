@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"api/models"
+)
+
+func TestThrottlePolicy(t *testing.T) {
+	p := &ThrottlePolicy{
+		FreeAttempts: 3,
+		BaseDelay:    time.Second,
+		MaxDelay:     time.Minute,
+		LockoutAfter: 10,
+		ForgetAfter:  time.Hour,
+	}
+	now := time.Now()
+	tests := []struct {
+		name     string
+		failures int
+		ago      time.Duration
+		wait     time.Duration
+	}{
+		{"free", 2, 0, 0},
+		{"first delay", 3, 0, time.Second},
+		{"doubled", 5, 0, 4 * time.Second},
+		{"passed", 5, 5 * time.Second, 0},
+		{"capped", 9, 0, time.Minute},
+		{"locked out", 10, 10 * time.Minute, 50 * time.Minute},
+		{"forgotten", 10, time.Hour, 0},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			f := models.LoginFailures{Failures: tc.failures, LastFailureAt: now.Add(-tc.ago)}
+			if wait := p.retryAfter(f, now); wait != tc.wait {
+				t.Fatalf("expected %v, got %v", tc.wait, wait)
+			}
+		})
+	}
+
+	p.LockoutAfter = 0
+	if wait := p.retryAfter(models.LoginFailures{Failures: 100, LastFailureAt: now}, now); wait != time.Minute {
+		t.Fatalf("expected capped delay without lockout, got %v", wait)
+	}
+}
+
+func TestMemoryLoginAttemptStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryLoginAttemptStore()
+	now := time.Now()
+	free := func(f models.LoginFailures) time.Duration { return 0 }
+
+	for i := 0; i < 2; i++ {
+		_, _ = s.ReserveLoginAttempt(ctx, "a", now, now.Add(-time.Hour), free)
+	}
+	_, _ = s.ReserveLoginAttempt(ctx, "b", now.Add(-2*time.Hour), now.Add(-3*time.Hour), free)
+	// the old failure of b is forgotten
+	var seen models.LoginFailures
+	wait, _ := s.ReserveLoginAttempt(ctx, "b", now, now.Add(-time.Hour), func(f models.LoginFailures) time.Duration {
+		seen = f
+		return time.Minute
+	})
+	if wait != time.Minute || seen.Failures != 0 {
+		t.Fatalf("expected forgotten failures to wait, got %v after %+v", wait, seen)
+	}
+	// the attempt which waits isn't counted
+	_, _ = s.ReserveLoginAttempt(ctx, "b", now, now.Add(-time.Hour), func(f models.LoginFailures) time.Duration {
+		seen = f
+		return 0
+	})
+	if seen.Failures != 0 || s.failures["a"].Failures != 2 || s.failures["b"].Failures != 1 {
+		t.Fatalf("unexpected failures: %+v", s.failures)
+	}
+
+	_ = s.ReleaseLoginAttempt(ctx, "a")
+	if s.failures["a"].Failures != 1 {
+		t.Fatalf("attempt is not released: %+v", s.failures["a"])
+	}
+	_ = s.ResetLoginFailures(ctx, "a")
+	_, _ = s.ReserveLoginAttempt(ctx, "c", now.Add(-2*time.Hour), now.Add(-3*time.Hour), free)
+	_ = s.ForgetLoginFailures(ctx, now.Add(-time.Hour))
+	if _, ok := s.failures["b"]; len(s.failures) != 1 || !ok {
+		t.Fatalf("unexpected failures: %+v", s.failures)
+	}
+}
+
+func TestLoginThrottleConcurrent(t *testing.T) {
+	lt := NewLoginThrottle(NewMemoryLoginAttemptStore(),
+		ThrottlePolicy{FreeAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour, ForgetAfter: 2 * time.Hour},
+		ThrottlePolicy{FreeAttempts: 100, BaseDelay: time.Hour, MaxDelay: time.Hour, ForgetAfter: 2 * time.Hour})
+
+	var passed int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wait, err := lt.Reserve(context.Background(), "alice@test.ru", "10.0.0.1")
+			if err != nil {
+				t.Error(err)
+			} else if wait == 0 {
+				atomic.AddInt32(&passed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if passed != 3 {
+		t.Fatalf("expected 3 attempts to pass, got %v", passed)
+	}
+
+	// the refused address takes back the attempt of the account
+	lt.address.FreeAttempts = 3
+	if wait, _ := lt.Reserve(context.Background(), "bobby@test.ru", "10.0.0.1"); wait == 0 {
+		t.Fatal("address is not throttled")
+	}
+	if f := lt.store.(*MemoryLoginAttemptStore).failures[lt.accountKey("bobby@test.ru")]; f.Failures != 0 {
+		t.Fatalf("account is charged for the refused attempt: %+v", f)
+	}
+	if wait, _ := lt.Reserve(context.Background(), "bobby@test.ru", "10.0.0.2"); wait != 0 {
+		t.Fatalf("account is throttled by the refused attempt for %v", wait)
+	}
+}
@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/session"
+
+	"api/models"
+)
+
+const (
+	sessionStoreTimeout = 5 * time.Second
+)
+
+// SessionStore remembers which sessions every user has and where they were created,
+// auth-service can't list them
+type SessionStore interface {
+	SaveSession(ctx context.Context, s *models.SessionInfo) error
+	DeleteSession(ctx context.Context, sID string) error
+	GetUserSessions(ctx context.Context, uID uint) ([]models.SessionInfo, error)
+	// GetSessionsCreatedBefore returns the sessions of all users created before the time
+	GetSessionsCreatedBefore(ctx context.Context, before time.Time) ([]string, error)
+	// TouchSessions saves that the sessions were seen at the time, it returns the ones it has
+	TouchSessions(ctx context.Context, sIDs []string, at time.Time) ([]string, error)
+}
+
+// TrackedSessionManager keeps the sessions of auth-service in the store, the sessions
+// created before they were tracked are deleted by Flush, so they log in again
+type TrackedSessionManager struct {
+	client SessionClient
+	store  SessionStore
+
+	mu sync.Mutex
+	// the sessions seen since the last Flush
+	seen map[string]struct{}
+}
+
+var _ SessionManager = (*TrackedSessionManager)(nil)
+
+func NewTrackedSessionManager(client SessionClient, store SessionStore) *TrackedSessionManager {
+	return &TrackedSessionManager{
+		client: client,
+		store:  store,
+		seen:   make(map[string]struct{}),
+	}
+}
+
+func (sm *TrackedSessionManager) Create(uID uint) (string, error) {
+	return sm.CreateSession(&models.SessionInfo{UserID: uID})
+}
+
+// CreateSession doesn't leave the session it can't track
+func (sm *TrackedSessionManager) CreateSession(info *models.SessionInfo) (string, error) {
+	sID, err := sm.client.Create(info.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	s := *info
+	s.SessionID = sID
+	s.CreatedAt = time.Now()
+	s.LastSeenAt = s.CreatedAt
+	ctx, cancel := context.WithTimeout(context.Background(), sessionStoreTimeout)
+	defer cancel()
+	err = sm.store.SaveSession(ctx, &s)
+	if err != nil {
+		if delErr := sm.client.Delete(sID); delErr != nil {
+			logger.Errorf("error while deleting untracked session of user %v: %v", info.UserID, delErr)
+		}
+		return "", err
+	}
+
+	return sID, nil
+}
+
+func (sm *TrackedSessionManager) Get(sID string) (uint, error) {
+	return sm.client.Get(sID)
+}
+
+func (sm *TrackedSessionManager) Delete(sID string) error {
+	err := sm.client.Delete(sID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionStoreTimeout)
+	defer cancel()
+	return sm.store.DeleteSession(ctx, sID)
+}
+
+// GetUserSessions forgets the sessions which have expired in auth-service
+func (sm *TrackedSessionManager) GetUserSessions(uID uint) ([]models.SessionInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sessionStoreTimeout)
+	defer cancel()
+	tracked, err := sm.store.GetUserSessions(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]models.SessionInfo, 0, len(tracked))
+	for _, s := range tracked {
+		owner, err := sm.client.Get(s.SessionID)
+		switch {
+		case err == session.ErrKeyNotFound || err == nil && owner != uID:
+			err = sm.store.DeleteSession(ctx, s.SessionID)
+			if err != nil {
+				return nil, err
+			}
+		case err != nil:
+			return nil, err
+		default:
+			sessions = append(sessions, s)
+		}
+	}
+
+	return sessions, nil
+}
+
+func (sm *TrackedSessionManager) DeleteUserSessions(uID uint, except string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sessionStoreTimeout)
+	defer cancel()
+	sessions, err := sm.store.GetUserSessions(ctx, uID)
+	if err != nil {
+		return err
+	}
+	for _, s := range sessions {
+		if s.SessionID == except {
+			continue
+		}
+		// expired in auth-service already
+		err = sm.client.Delete(s.SessionID)
+		if err != nil && err != session.ErrKeyNotFound {
+			return err
+		}
+		err = sm.store.DeleteSession(ctx, s.SessionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TouchSession remembers the session until Flush, the request doesn't wait for the store
+func (sm *TrackedSessionManager) TouchSession(sID string) {
+	sm.mu.Lock()
+	sm.seen[sID] = struct{}{}
+	sm.mu.Unlock()
+}
+
+// Flush saves the sessions seen since the last call as seen now, so the time is as precise
+// as the calls are frequent. The instances of the service save it on their own.
+// The sessions missing from the store can't be listed or revoked, they are deleted
+func (sm *TrackedSessionManager) Flush(ctx context.Context) error {
+	sm.mu.Lock()
+	seen := sm.seen
+	sm.seen = make(map[string]struct{})
+	sm.mu.Unlock()
+	if len(seen) == 0 {
+		return nil
+	}
+
+	sIDs := make([]string, 0, len(seen))
+	for sID := range seen {
+		sIDs = append(sIDs, sID)
+	}
+	tracked, err := sm.store.TouchSessions(ctx, sIDs, time.Now())
+	if err != nil {
+		// the next call tries again
+		sm.mu.Lock()
+		for sID := range seen {
+			sm.seen[sID] = struct{}{}
+		}
+		sm.mu.Unlock()
+		return err
+	}
+
+	for _, sID := range tracked {
+		delete(seen, sID)
+	}
+	for sID := range seen {
+		err = sm.client.Delete(sID)
+		if err != nil && err != session.ErrKeyNotFound {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Forget deletes the sessions older than ttl in auth-service and in the store, so the players
+// who never open the list of the sessions don't keep them forever and no session outlives
+// the store even if auth-service keeps it longer
+func (sm *TrackedSessionManager) Forget(ctx context.Context, ttl time.Duration) error {
+	sIDs, err := sm.store.GetSessionsCreatedBefore(ctx, time.Now().Add(-ttl))
+	if err != nil {
+		return err
+	}
+	for _, sID := range sIDs {
+		// the session stays in the store until it is deleted in auth-service
+		err = sm.client.Delete(sID)
+		if err != nil && err != session.ErrKeyNotFound {
+			return err
+		}
+		err = sm.store.DeleteSession(ctx, sID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (sm *TrackedSessionManager) Close() error {
+	return sm.client.Close()
+}
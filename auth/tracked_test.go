@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/session"
+
+	"api/models"
+)
+
+type memoryStore struct {
+	mu       sync.Mutex
+	sessions map[string]models.SessionInfo
+	touches  int
+}
+
+func (s *memoryStore) SaveSession(_ context.Context, info *models.SessionInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[info.SessionID] = *info
+	return nil
+}
+
+func (s *memoryStore) DeleteSession(_ context.Context, sID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sID)
+	return nil
+}
+
+func (s *memoryStore) GetUserSessions(_ context.Context, uID uint) ([]models.SessionInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sessions := []models.SessionInfo{}
+	for _, info := range s.sessions {
+		if info.UserID == uID {
+			sessions = append(sessions, info)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].SessionID < sessions[j].SessionID })
+	return sessions, nil
+}
+
+func (s *memoryStore) GetSessionsCreatedBefore(_ context.Context, before time.Time) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sIDs := []string{}
+	for sID, info := range s.sessions {
+		if info.CreatedAt.Before(before) {
+			sIDs = append(sIDs, sID)
+		}
+	}
+	return sIDs, nil
+}
+
+func (s *memoryStore) TouchSessions(_ context.Context, sIDs []string, at time.Time) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.touches++
+	tracked := []string{}
+	for _, sID := range sIDs {
+		if info, ok := s.sessions[sID]; ok {
+			info.LastSeenAt = at
+			s.sessions[sID] = info
+			tracked = append(tracked, sID)
+		}
+	}
+	return tracked, nil
+}
+
+func sessionIDs(sessions []models.SessionInfo) []string {
+	sIDs := []string{}
+	for _, s := range sessions {
+		sIDs = append(sIDs, s.SessionID)
+	}
+	return sIDs
+}
+
+func TestTrackedSessionManager(t *testing.T) {
+	client := NewMemorySessionManager()
+	store := &memoryStore{sessions: map[string]models.SessionInfo{}}
+	sm := NewTrackedSessionManager(client, store)
+
+	create := func(uID uint) string {
+		sID, err := sm.CreateSession(&models.SessionInfo{UserID: uID, Device: "Android", IP: "192.0.2.1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sID
+	}
+	current, other, expired, stranger := create(1), create(1), create(1), create(2)
+	if s := store.sessions[current]; s.Device != "Android" || s.IP != "192.0.2.1" || s.CreatedAt.IsZero() {
+		t.Fatalf("device is not tracked: %+v", s)
+	}
+	// auth-service forgets the session, the store still has it
+	if err := client.Delete(expired); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := sm.GetUserSessions(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected the current and the other sessions, got %v", sessionIDs(sessions))
+	}
+	if _, ok := store.sessions[expired]; ok {
+		t.Fatal("expired session is still tracked")
+	}
+
+	if err := sm.DeleteUserSessions(1, current); err != nil {
+		t.Fatal(err)
+	}
+	for sID, alive := range map[string]bool{current: true, other: false, stranger: true} {
+		if _, err := sm.Get(sID); (err == nil) != alive {
+			t.Fatalf("session %v: expected alive %v, got error %v", sID, alive, err)
+		}
+	}
+	if sessions, _ := store.GetUserSessions(context.Background(), 1); len(sessions) != 1 ||
+		sessions[0].SessionID != current {
+		t.Fatalf("expected only %v in the store, got %v", current, sessionIDs(sessions))
+	}
+
+	if err := sm.Delete(current); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sm.Get(current); err != session.ErrKeyNotFound {
+		t.Fatalf("expected %v, got %v", session.ErrKeyNotFound, err)
+	}
+	if sessions, _ := store.GetUserSessions(context.Background(), 1); len(sessions) != 0 {
+		t.Fatalf("deleted sessions are in the store: %v", sessionIDs(sessions))
+	}
+}
+
+func TestTrackedSessionManagerForget(t *testing.T) {
+	client := NewMemorySessionManager()
+	store := &memoryStore{sessions: map[string]models.SessionInfo{}}
+	sm := NewTrackedSessionManager(client, store)
+
+	old, err := sm.Create(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fresh, err := sm.Create(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := store.sessions[old]
+	s.CreatedAt = s.CreatedAt.Add(-31 * 24 * time.Hour)
+	store.sessions[old] = s
+
+	if err := sm.Forget(context.Background(), 30*24*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if sessions, _ := store.GetUserSessions(context.Background(), 1); len(sessions) != 1 ||
+		sessions[0].SessionID != fresh {
+		t.Fatalf("expected only %v in the store, got %v", fresh, sessionIDs(sessions))
+	}
+	// auth-service would keep it longer
+	if _, err := client.Get(old); err != session.ErrKeyNotFound {
+		t.Fatalf("expected the forgotten session to be deleted, got %v", err)
+	}
+	if _, err := client.Get(fresh); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTrackedSessionManagerTouch(t *testing.T) {
+	store := &memoryStore{sessions: map[string]models.SessionInfo{}}
+	sm := NewTrackedSessionManager(NewMemorySessionManager(), store)
+
+	sID, err := sm.Create(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := store.sessions[sID]
+	s.LastSeenAt = s.LastSeenAt.Add(-time.Hour)
+	store.sessions[sID] = s
+
+	// the requests don't write the time
+	for i := 0; i < 3; i++ {
+		sm.TouchSession(sID)
+	}
+	if store.touches != 0 {
+		t.Fatalf("expected no touches before the flush, got %v", store.touches)
+	}
+	if err := sm.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if s := store.sessions[sID]; store.touches != 1 || time.Since(s.LastSeenAt) > time.Minute {
+		t.Fatalf("expected one touch just now, got %v touches and %+v", store.touches, s)
+	}
+
+	// nothing is seen since the flush
+	if err := sm.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if store.touches != 1 {
+		t.Fatalf("expected no touch without the requests, got %v", store.touches)
+	}
+}
+
+func TestTrackedSessionManagerUntracked(t *testing.T) {
+	client := NewMemorySessionManager()
+	store := &memoryStore{sessions: map[string]models.SessionInfo{}}
+	sm := NewTrackedSessionManager(client, store)
+
+	// created before the sessions were tracked
+	old, err := client.Create(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sID, err := sm.Create(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm.TouchSession(old)
+	sm.TouchSession(sID)
+	if err := sm.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the untracked session logs in again, the new one is tracked
+	if _, err := sm.Get(old); err != session.ErrKeyNotFound {
+		t.Fatalf("expected the untracked session to be deleted, got %v", err)
+	}
+	if uID, err := sm.Get(sID); err != nil || uID != 1 {
+		t.Fatalf("expected the tracked session of user 1, got %v %v", uID, err)
+	}
+}
@@ -0,0 +1,155 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"api/models"
+)
+
+type achievementProgress struct {
+	games  int
+	wins   int
+	record int
+	skins  int
+	// skins which can be bought now but are not owned
+	missingSkins int
+}
+
+// achievementRules maps the rule of an achievement to its check,
+// achievements with unknown rules are never unlocked
+var achievementRules = map[string]func(p *achievementProgress, threshold int) bool{
+	models.RuleGames: func(p *achievementProgress, threshold int) bool {
+		return p.games >= threshold
+	},
+	models.RuleWins: func(p *achievementProgress, threshold int) bool {
+		return p.wins >= threshold
+	},
+	models.RuleRecord: func(p *achievementProgress, threshold int) bool {
+		return p.record >= threshold
+	},
+	models.RuleSkins: func(p *achievementProgress, threshold int) bool {
+		return p.skins >= threshold
+	},
+	models.RuleAllSkins: func(p *achievementProgress, threshold int) bool {
+		return p.missingSkins == 0
+	},
+}
+
+// TxUnlockAchievements checks the locked achievements of the user
+// and credits their rewards, it must be called after the user's stats,
+// purchases or skins are changed in the same transaction
+func TxUnlockAchievements(ctx context.Context, tx *sql.Tx, uID uint) ([]models.Achievement, error) {
+	p := &achievementProgress{}
+	err := tx.QueryRowContext(ctx, `
+		SELECT win + draws + loss, win, record,
+			(SELECT COUNT(*) FROM user_purchased_skins WHERE user_id = $1),
+			(SELECT COUNT(*) FROM skin
+				WHERE `+skinIsAvailable+` AND skin_id NOT IN (
+					SELECT skin_id FROM user_purchased_skins
+					WHERE user_id = $1
+				))
+		FROM user_profile
+		WHERE user_id = $1`,
+		uID).Scan(&p.games, &p.wins, &p.record, &p.skins, &p.missingSkins)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, UserNotFoundError{"id"}
+		}
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT achievement_id, code, achievement_name, description, rule, threshold, reward
+		FROM achievement a
+		WHERE NOT EXISTS (
+			SELECT FROM user_achievement ua
+			WHERE ua.user_id = $1 AND ua.achievement_id = a.achievement_id
+		)
+		ORDER BY achievement_id`,
+		uID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := []models.Achievement{}
+	for rows.Next() {
+		a := models.Achievement{}
+		err = rows.Scan(&a.ID, &a.Code, &a.Name, &a.Description, &a.Rule, &a.Threshold, &a.Reward)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if check, ok := achievementRules[a.Rule]; ok && check(p, a.Threshold) {
+			candidates = append(candidates, a)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	unlocked := []models.Achievement{}
+	for _, a := range candidates {
+		unlockedAt := time.Time{}
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO user_achievement (user_id, achievement_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+			RETURNING unlocked_at`,
+			uID, a.ID).Scan(&unlockedAt)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				// unlocked by a concurrent transaction
+				continue
+			}
+			return nil, err
+		}
+		a.UnlockedAt = &unlockedAt
+		if a.Reward != 0 {
+			err = TxChangeUserCoinAmount(ctx, tx, uID, a.Reward, models.CoinReasonAchievement, a.Code)
+			if err != nil {
+				return nil, err
+			}
+		}
+		unlocked = append(unlocked, a)
+	}
+
+	return unlocked, nil
+}
+
+// GetUserAchievements returns the whole catalog, unlocked achievements have unlock time
+func (pg *Postgres) GetUserAchievements(ctx context.Context, uID uint) (*[]models.Achievement, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	exists := false
+	err = dbo.GetContext(ctx, &exists, `
+		SELECT EXISTS (
+			SELECT FROM user_profile
+			WHERE user_id = $1
+		)`,
+		uID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, UserNotFoundError{"id"}
+	}
+
+	achievements := &[]models.Achievement{}
+	err = dbo.SelectContext(ctx, achievements, `
+		SELECT a.achievement_id, a.code, a.achievement_name, a.description, a.rule, a.threshold, a.reward,
+			ua.unlocked_at
+		FROM achievement a
+		LEFT JOIN user_achievement ua ON ua.achievement_id = a.achievement_id AND ua.user_id = $1
+		ORDER BY ua.unlocked_at IS NULL, ua.unlocked_at DESC, a.achievement_id`,
+		uID)
+	if err != nil {
+		return achievements, err
+	}
+
+	return achievements, nil
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+
+	"api/models"
+)
+
+func (pg *Postgres) GetCoinTransactionsPaginated(ctx context.Context, uID uint, limit, before uint64) (
+	*[]models.CoinTransaction, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := &[]models.CoinTransaction{}
+	if before == 0 {
+		err = dbo.SelectContext(ctx, transactions, `
+			SELECT transaction_id, amount, reason, reference, balance, created_at FROM coin_transaction
+			WHERE user_id = $1
+			ORDER BY transaction_id DESC
+			LIMIT $2`,
+			uID, limit)
+	} else {
+		err = dbo.SelectContext(ctx, transactions, `
+			SELECT transaction_id, amount, reason, reference, balance, created_at FROM coin_transaction
+			WHERE user_id = $1 AND transaction_id < $2
+			ORDER BY transaction_id DESC
+			LIMIT $3`,
+			uID, before, limit)
+	}
+	if err != nil {
+		return transactions, err
+	}
+
+	return transactions, nil
+}
+
+// CheckCoinLedger recomputes the balances from the ledger and returns the users
+// whose coins differ from the sum of their transactions or from the last balance
+func (pg *Postgres) CheckCoinLedger(ctx context.Context) (*models.CoinLedgerReport, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.CoinLedgerReport{
+		Drifts: []models.CoinDrift{},
+	}
+	err = dbo.GetContext(ctx, &res.CheckedUsers, `
+		SELECT COUNT(*) FROM user_profile`)
+	if err != nil {
+		return res, err
+	}
+	err = dbo.SelectContext(ctx, &res.Drifts, `
+		SELECT up.user_id, up.coins,
+			COALESCE(l.ledger_sum, 0) AS ledger_sum,
+			last.balance AS last_balance
+		FROM user_profile up
+		LEFT JOIN (
+			SELECT user_id, SUM(amount) AS ledger_sum FROM coin_transaction
+			GROUP BY user_id
+		) AS l ON l.user_id = up.user_id
+		LEFT JOIN LATERAL (
+			SELECT balance FROM coin_transaction ct
+			WHERE ct.user_id = up.user_id
+			ORDER BY transaction_id DESC
+			LIMIT 1
+		) AS last ON true
+		WHERE up.coins <> COALESCE(l.ledger_sum, 0) OR up.coins <> COALESCE(last.balance, 0)
+		ORDER BY up.user_id`)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"api/models"
+)
+
+const (
+	// days of the streak change at UTC midnight
+	todayUTC = `(now() AT TIME ZONE 'UTC')::date`
+	// rewardForDay is the reward for the day of the streak in $1
+	rewardForDay = `COALESCE((
+		SELECT coins FROM daily_reward
+		WHERE day <= $1
+		ORDER BY day DESC
+		LIMIT 1
+	), 0)`
+)
+
+// ClaimDailyReward continues the streak if the reward was claimed yesterday
+// or starts a new one, the reward can be claimed once a day
+func (pg *Postgres) ClaimDailyReward(ctx context.Context, uID uint) (*models.DailyReward, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = txLockUsers(ctx, tx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.DailyReward{}
+	var claimedToday, claimedYesterday bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT streak, claimed_on = `+todayUTC+`, claimed_on = `+todayUTC+` - 1 FROM daily_streak
+		WHERE user_id = $1`,
+		uID).Scan(&res.Streak, &claimedToday, &claimedYesterday)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	if claimedToday {
+		return nil, ErrAlreadyClaimed
+	}
+	if claimedYesterday {
+		res.Streak++
+	} else { // missed a day
+		res.Streak = 1
+	}
+
+	var today time.Time
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO daily_streak (user_id, streak, claimed_on)
+		VALUES ($1, $2, `+todayUTC+`)
+		ON CONFLICT (user_id) DO UPDATE
+		SET streak = EXCLUDED.streak, claimed_on = EXCLUDED.claimed_on
+		RETURNING claimed_on`,
+		uID, res.Streak).Scan(&today)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.GetContext(ctx, &res.Coins, `SELECT `+rewardForDay, res.Streak)
+	if err != nil {
+		return nil, err
+	}
+	if res.Coins != 0 {
+		err = TxChangeUserCoinAmount(ctx, tx.Tx, uID, res.Coins,
+			models.CoinReasonDailyReward, today.Format("2006-01-02"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, tx.Commit()
+}
+
+// GetDailyStreak returns the current streak, it is 0 if a day was missed
+func (pg *Postgres) GetDailyStreak(ctx context.Context, uID uint) (*models.DailyStreak, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.DailyStreak{}
+	err = dbo.GetContext(ctx, res, `
+		SELECT CASE WHEN claimed_on >= `+todayUTC+` - 1 THEN streak ELSE 0 END AS streak,
+			claimed_on = `+todayUTC+` AS claimed_today
+		FROM daily_streak
+		WHERE user_id = $1`,
+		uID)
+	if err != nil && err != sql.ErrNoRows {
+		return res, err
+	}
+	err = dbo.GetContext(ctx, &res.NextReward, `SELECT `+rewardForDay, res.Streak+1)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
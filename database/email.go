@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"api/models"
+)
+
+const (
+	// limit of the letters of each kind sent to a player in the last hour
+	maxEmailTokensPerHour = 3
+)
+
+// CreateEmailToken saves the token of the link sent to the user
+func (pg *Postgres) CreateEmailToken(ctx context.Context, t *models.EmailToken) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = txLockUsers(ctx, tx, t.UserID)
+	if err != nil {
+		return err
+	}
+	sent := 0
+	err = tx.GetContext(ctx, &sent, `
+		SELECT COUNT(*) FROM email_token
+		WHERE user_id = $1 AND purpose = $2 AND created_at > now() - interval '1 hour'`,
+		t.UserID, t.Purpose)
+	if err != nil {
+		return err
+	}
+	if sent >= maxEmailTokensPerHour {
+		return ErrTooManyTokens
+	}
+
+	_, err = tx.NamedExecContext(ctx, `
+		INSERT INTO email_token (token_hash, user_id, purpose, email, expires_at)
+		VALUES (:token_hash, :user_id, :purpose, :email, :expires_at)`,
+		t)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// txUseEmailToken spends the token if it is not used or expired and the user still has
+// the address the link was sent to
+func txUseEmailToken(ctx context.Context, tx *sqlx.Tx, hash, purpose string) (*models.EmailToken, error) {
+	t := &models.EmailToken{}
+	err := tx.GetContext(ctx, t, `
+		UPDATE email_token
+		SET used_at = now()
+		FROM user_profile
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > now()
+			AND user_profile.user_id = email_token.user_id AND user_profile.email = email_token.email
+		RETURNING token_hash, email_token.user_id, purpose, email_token.email, expires_at`,
+		hash, purpose)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// VerifyEmail marks the address of the token verified, it returns the ID of its user
+func (pg *Postgres) VerifyEmail(ctx context.Context, hash string) (uint, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return 0, err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	t, err := txUseEmailToken(ctx, tx, hash, models.TokenVerifyEmail)
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE user_profile
+		SET email_verified = true
+		WHERE user_id = $1`,
+		t.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.UserID, tx.Commit()
+}
+
+// ResetPassword sets the hashed password of the user of the token and spends
+// the other reset tokens, the address is verified as the letter has been received
+func (pg *Postgres) ResetPassword(ctx context.Context, hash, password string) (uint, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return 0, err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	t, err := txUseEmailToken(ctx, tx, hash, models.TokenResetPassword)
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE user_profile
+		SET password = $2, email_verified = true
+		WHERE user_id = $1`,
+		t.UserID, password)
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE email_token
+		SET used_at = now()
+		WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`,
+		t.UserID, models.TokenResetPassword)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.UserID, tx.Commit()
+}
@@ -6,6 +6,27 @@ import (
 
 var (
 	ErrNotFound = fmt.Errorf("no rows found")
+
+	ErrMatchAlreadyReported = fmt.Errorf("match has already been reported")
+
+	ErrBlocked        = fmt.Errorf("user is blocked")
+	ErrAlreadyFriends = fmt.Errorf("users are already friends")
+
+	ErrSkinNotFound      = fmt.Errorf("skin not found")
+	ErrAlreadyOwned      = fmt.Errorf("skin is already owned")
+	ErrInsufficientCoins = fmt.Errorf("not enough coins")
+	ErrSkinInUse         = fmt.Errorf("skin is in use")
+
+	ErrGiftLimitExceeded = fmt.Errorf("daily gift limit exceeded")
+
+	ErrAlreadyClaimed = fmt.Errorf("daily reward has already been claimed")
+
+	ErrPromoExpired   = fmt.Errorf("promo code has expired")
+	ErrPromoExhausted = fmt.Errorf("promo code has been redeemed the maximum number of times")
+	ErrPromoRedeemed  = fmt.Errorf("promo code has already been redeemed by the user")
+
+	ErrInvalidToken  = fmt.Errorf("token is invalid, expired or used")
+	ErrTooManyTokens = fmt.Errorf("too many emails sent")
 )
 
 type UserNotFoundError struct {
@@ -0,0 +1,342 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"api/models"
+)
+
+// txLockUsers serializes the changes of relations between the users
+func txLockUsers(ctx context.Context, tx *sqlx.Tx, uIDs ...uint) error {
+	ids := make([]int64, 0, len(uIDs))
+	for _, id := range uIDs {
+		ids = append(ids, int64(id))
+	}
+	var locked []uint
+	err := tx.SelectContext(ctx, &locked, `
+		SELECT user_id FROM user_profile
+		WHERE user_id = ANY($1)
+		ORDER BY user_id
+		FOR UPDATE`,
+		pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	if len(locked) != len(uIDs) {
+		return UserNotFoundError{"id"}
+	}
+
+	return nil
+}
+
+// txIsBlocked checks if one of the users has blocked the other
+func txIsBlocked(ctx context.Context, tx *sqlx.Tx, uID, otherID uint) (bool, error) {
+	blocked := false
+	err := tx.GetContext(ctx, &blocked, `
+		SELECT EXISTS (
+			SELECT FROM user_block
+			WHERE (user_id = $1 AND blocked_id = $2) OR (user_id = $2 AND blocked_id = $1)
+		)`,
+		uID, otherID)
+
+	return blocked, err
+}
+
+func txMakeFriends(ctx context.Context, tx *sqlx.Tx, uID, friendID uint) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO friendship (user_id, friend_id)
+		VALUES ($1, $2), ($2, $1)
+		ON CONFLICT DO NOTHING`,
+		uID, friendID)
+
+	return err
+}
+
+// SendFriendRequest returns true if the users became friends
+// because the other user has already sent a request
+func (pg *Postgres) SendFriendRequest(ctx context.Context, from, to uint) (bool, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return false, err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = txLockUsers(ctx, tx, from, to)
+	if err != nil {
+		return false, err
+	}
+	blocked, err := txIsBlocked(ctx, tx, from, to)
+	if err != nil {
+		return false, err
+	}
+	if blocked {
+		return false, ErrBlocked
+	}
+	friends := false
+	err = tx.GetContext(ctx, &friends, `
+		SELECT EXISTS (
+			SELECT FROM friendship
+			WHERE user_id = $1 AND friend_id = $2
+		)`,
+		from, to)
+	if err != nil {
+		return false, err
+	}
+	if friends {
+		return false, ErrAlreadyFriends
+	}
+
+	qres, err := tx.ExecContext(ctx, `
+		DELETE FROM friend_request
+		WHERE from_id = $1 AND to_id = $2`,
+		to, from)
+	if err != nil {
+		return false, err
+	}
+	res, err := qres.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if res != 0 {
+		err = txMakeFriends(ctx, tx, from, to)
+		if err != nil {
+			return false, err
+		}
+		return true, tx.Commit()
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO friend_request (from_id, to_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`,
+		from, to)
+	if err != nil {
+		return false, err
+	}
+
+	return false, tx.Commit()
+}
+
+func (pg *Postgres) AcceptFriendRequest(ctx context.Context, uID, from uint) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = txLockUsers(ctx, tx, uID, from)
+	if err != nil {
+		return err
+	}
+	qres, err := tx.ExecContext(ctx, `
+		DELETE FROM friend_request
+		WHERE from_id = $1 AND to_id = $2`,
+		from, uID)
+	if err != nil {
+		return err
+	}
+	res, err := qres.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return ErrNotFound
+	}
+	err = txMakeFriends(ctx, tx, uID, from)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (pg *Postgres) DeclineFriendRequest(ctx context.Context, uID, from uint) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	qres, err := dbo.ExecContext(ctx, `
+		DELETE FROM friend_request
+		WHERE from_id = $1 AND to_id = $2`,
+		from, uID)
+	if err != nil {
+		return err
+	}
+	res, err := qres.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// RemoveFriend removes the friend or cancels the sent friend request
+func (pg *Postgres) RemoveFriend(ctx context.Context, uID, friendID uint) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qres, err := tx.ExecContext(ctx, `
+		DELETE FROM friendship
+		WHERE (user_id = $1 AND friend_id = $2) OR (user_id = $2 AND friend_id = $1)`,
+		uID, friendID)
+	if err != nil {
+		return err
+	}
+	removed, err := qres.RowsAffected()
+	if err != nil {
+		return err
+	}
+	qres, err = tx.ExecContext(ctx, `
+		DELETE FROM friend_request
+		WHERE from_id = $1 AND to_id = $2`,
+		uID, friendID)
+	if err != nil {
+		return err
+	}
+	canceled, err := qres.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if removed+canceled == 0 {
+		return ErrNotFound
+	}
+
+	return tx.Commit()
+}
+
+func (pg *Postgres) BlockUser(ctx context.Context, uID, blockedID uint) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = txLockUsers(ctx, tx, uID, blockedID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_block (user_id, blocked_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`,
+		uID, blockedID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM friendship
+		WHERE (user_id = $1 AND friend_id = $2) OR (user_id = $2 AND friend_id = $1)`,
+		uID, blockedID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM friend_request
+		WHERE (from_id = $1 AND to_id = $2) OR (from_id = $2 AND to_id = $1)`,
+		uID, blockedID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (pg *Postgres) UnblockUser(ctx context.Context, uID, blockedID uint) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	qres, err := dbo.ExecContext(ctx, `
+		DELETE FROM user_block
+		WHERE user_id = $1 AND blocked_id = $2`,
+		uID, blockedID)
+	if err != nil {
+		return err
+	}
+	res, err := qres.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetFriendList(ctx context.Context, uID uint) (*models.FriendList, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.FriendList{
+		Friends:  []models.Friend{},
+		Incoming: []models.Friend{},
+		Outgoing: []models.Friend{},
+		Blocked:  []models.Friend{},
+	}
+	err = dbo.SelectContext(ctx, &res.Friends, `
+		SELECT up.user_id, up.nickname, up.avatar, up.record, f.since FROM friendship f
+		JOIN user_profile up ON up.user_id = f.friend_id
+		WHERE f.user_id = $1
+		ORDER BY up.nickname`,
+		uID)
+	if err != nil {
+		return res, err
+	}
+	err = dbo.SelectContext(ctx, &res.Incoming, `
+		SELECT up.user_id, up.nickname, up.avatar, up.record, fr.created_at AS since FROM friend_request fr
+		JOIN user_profile up ON up.user_id = fr.from_id
+		WHERE fr.to_id = $1
+		ORDER BY fr.created_at DESC`,
+		uID)
+	if err != nil {
+		return res, err
+	}
+	err = dbo.SelectContext(ctx, &res.Outgoing, `
+		SELECT up.user_id, up.nickname, up.avatar, up.record, fr.created_at AS since FROM friend_request fr
+		JOIN user_profile up ON up.user_id = fr.to_id
+		WHERE fr.from_id = $1
+		ORDER BY fr.created_at DESC`,
+		uID)
+	if err != nil {
+		return res, err
+	}
+	err = dbo.SelectContext(ctx, &res.Blocked, `
+		SELECT up.user_id, up.nickname, up.avatar, up.record, b.created_at AS since FROM user_block b
+		JOIN user_profile up ON up.user_id = b.blocked_id
+		WHERE b.user_id = $1
+		ORDER BY b.created_at DESC`,
+		uID)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
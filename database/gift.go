@@ -0,0 +1,289 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"api/models"
+)
+
+const (
+	// limits of the gifts sent by a player in the last 24 hours
+	maxGiftsPerDay     = 10
+	maxGiftCoinsPerDay = 1000
+
+	maxListedGifts = 50
+)
+
+// SendGift takes the coins or the price of the skin from the sender, they are kept in the gift
+// until the recipient accepts or declines it. The sender can't spend them while the gift waits,
+// so the accept never fails for the lack of coins and pays the price the skin had when it was sent.
+// Each step moves the coins in one transaction with the status of the gift, so they are never
+// lost or paid twice: the accept gives them to the recipient, the decline returns them
+func (pg *Postgres) SendGift(ctx context.Context, g *models.Gift) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = txLockUsers(ctx, tx, g.FromID, g.ToID)
+	if err != nil {
+		return err
+	}
+	blocked, err := txIsBlocked(ctx, tx, g.FromID, g.ToID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrBlocked
+	}
+
+	var sent, sentCoins int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(coins), 0) FROM gift
+		WHERE from_id = $1 AND created_at > now() - interval '1 day'`,
+		g.FromID).Scan(&sent, &sentCoins)
+	if err != nil {
+		return err
+	}
+	if sent >= maxGiftsPerDay || sentCoins+g.Coins > maxGiftCoinsPerDay {
+		return ErrGiftLimitExceeded
+	}
+
+	g.Price = g.Coins
+	if g.SkinID != nil {
+		err = tx.GetContext(ctx, &g.Price, `
+			SELECT price FROM (`+selectSkinWithPrice+`
+				WHERE skin.skin_id = $1 AND `+skinIsAvailable+`
+			) AS s`,
+			*g.SkinID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrSkinNotFound
+			}
+			return err
+		}
+		owned := false
+		err = tx.GetContext(ctx, &owned, `
+			SELECT EXISTS (
+				SELECT FROM user_purchased_skins
+				WHERE user_id = $1 AND skin_id = $2
+			)`,
+			g.ToID, *g.SkinID)
+		if err != nil {
+			return err
+		}
+		if owned {
+			return ErrAlreadyOwned
+		}
+	}
+
+	var coins int
+	err = tx.GetContext(ctx, &coins, `
+		SELECT coins FROM user_profile
+		WHERE user_id = $1`,
+		g.FromID)
+	if err != nil {
+		return err
+	}
+	if coins < g.Price {
+		return ErrInsufficientCoins
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO gift (from_id, to_id, skin_id, coins, price, message)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING gift_id, status, created_at`,
+		g.FromID, g.ToID, g.SkinID, g.Coins, g.Price, g.Message).Scan(&g.ID, &g.Status, &g.CreatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			// the same skin is already waiting in the inbox
+			return ErrAlreadyOwned
+		}
+		return err
+	}
+	if g.Price != 0 {
+		err = TxChangeUserCoinAmount(ctx, tx.Tx, g.FromID, -g.Price,
+			models.CoinReasonGiftSent, strconv.FormatUint(uint64(g.ID), 10))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func txGetPendingGift(ctx context.Context, tx *sqlx.Tx, uID, giftID uint) (*models.Gift, error) {
+	g := &models.Gift{}
+	err := tx.GetContext(ctx, g, `
+		SELECT gift_id, from_id, to_id, skin_id, coins, price, message, status, created_at FROM gift
+		WHERE gift_id = $1 AND to_id = $2 AND status = 'pending'
+		FOR UPDATE`,
+		giftID, uID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return g, ErrNotFound
+		}
+		return g, err
+	}
+
+	return g, nil
+}
+
+// txResolveGift sets the final status of the gift, the kept coins are returned to the sender if it is declined
+func txResolveGift(ctx context.Context, tx *sqlx.Tx, g *models.Gift, status string) error {
+	if status == models.GiftStatusDeclined && g.Price != 0 {
+		err := TxChangeUserCoinAmount(ctx, tx.Tx, g.FromID, g.Price,
+			models.CoinReasonGiftRefund, strconv.FormatUint(uint64(g.ID), 10))
+		if err != nil {
+			return err
+		}
+	}
+	_, err := tx.ExecContext(ctx, `
+		UPDATE gift
+		SET status = $2, resolved_at = now()
+		WHERE gift_id = $1`,
+		g.ID, status)
+	if err != nil {
+		return err
+	}
+	g.Status = status
+
+	return nil
+}
+
+// AcceptGift gives the gift to the recipient, if the recipient already has the skin
+// the gift is declined and ErrAlreadyOwned is returned
+func (pg *Postgres) AcceptGift(ctx context.Context, uID, giftID uint) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	g, err := txGetPendingGift(ctx, tx, uID, giftID)
+	if err != nil {
+		return err
+	}
+
+	if g.SkinID != nil {
+		qres, err := tx.ExecContext(ctx, `
+			INSERT INTO user_purchased_skins (user_id, skin_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING`,
+			uID, *g.SkinID)
+		if err != nil {
+			return err
+		}
+		res, err := qres.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if res == 0 {
+			// bought after the gift was sent
+			err = txResolveGift(ctx, tx, g, models.GiftStatusDeclined)
+			if err != nil {
+				return err
+			}
+			err = tx.Commit()
+			if err != nil {
+				return err
+			}
+			return ErrAlreadyOwned
+		}
+	} else {
+		err = TxChangeUserCoinAmount(ctx, tx.Tx, uID, g.Coins,
+			models.CoinReasonGiftReceived, strconv.FormatUint(uint64(g.ID), 10))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = txResolveGift(ctx, tx, g, models.GiftStatusAccepted)
+	if err != nil {
+		return err
+	}
+	_, err = TxUnlockAchievements(ctx, tx.Tx, uID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (pg *Postgres) DeclineGift(ctx context.Context, uID, giftID uint) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	g, err := txGetPendingGift(ctx, tx, uID, giftID)
+	if err != nil {
+		return err
+	}
+	err = txResolveGift(ctx, tx, g, models.GiftStatusDeclined)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetGiftList returns the gifts waiting in the inbox and the last sent ones
+func (pg *Postgres) GetGiftList(ctx context.Context, uID uint) (*models.GiftList, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.GiftList{
+		Incoming: []models.Gift{},
+		Sent:     []models.Gift{},
+	}
+	err = dbo.SelectContext(ctx, &res.Incoming, `
+		SELECT g.gift_id, g.from_id, f.nickname AS from_nickname, g.to_id, t.nickname AS to_nickname,
+			g.skin_id, g.coins, g.price, g.message, g.status, g.created_at
+		FROM gift g
+		JOIN user_profile f ON f.user_id = g.from_id
+		JOIN user_profile t ON t.user_id = g.to_id
+		WHERE g.to_id = $1 AND g.status = 'pending'
+		ORDER BY g.created_at DESC
+		LIMIT $2`,
+		uID, maxListedGifts)
+	if err != nil {
+		return res, err
+	}
+	err = dbo.SelectContext(ctx, &res.Sent, `
+		SELECT g.gift_id, g.from_id, f.nickname AS from_nickname, g.to_id, t.nickname AS to_nickname,
+			g.skin_id, g.coins, g.price, g.message, g.status, g.created_at
+		FROM gift g
+		JOIN user_profile f ON f.user_id = g.from_id
+		JOIN user_profile t ON t.user_id = g.to_id
+		WHERE g.from_id = $1
+		ORDER BY g.created_at DESC
+		LIMIT $2`,
+		uID, maxListedGifts)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
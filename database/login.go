@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"api/models"
+)
+
+// ReserveLoginAttempt locks the failures of the key, the instances of the service share them
+func (pg *Postgres) ReserveLoginAttempt(ctx context.Context, key string, at, forgetBefore time.Time,
+	retryAfter func(models.LoginFailures) time.Duration) (time.Duration, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return 0, err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// the first attempts of the key wait for each other on the insert
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO login_failure (key, failures, last_failure_at)
+		VALUES ($1, 0, $2)
+		ON CONFLICT (key) DO NOTHING`,
+		key, at)
+	if err != nil {
+		return 0, err
+	}
+	f := models.LoginFailures{}
+	err = tx.GetContext(ctx, &f, `
+		SELECT key, failures, last_failure_at FROM login_failure
+		WHERE key = $1
+		FOR UPDATE`,
+		key)
+	if err != nil {
+		return 0, err
+	}
+	if f.LastFailureAt.Before(forgetBefore) {
+		f.Failures = 0
+	}
+	if wait := retryAfter(f); wait > 0 {
+		return wait, nil
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE login_failure
+		SET failures = $2, last_failure_at = $3
+		WHERE key = $1`,
+		key, f.Failures+1, at)
+	if err != nil {
+		return 0, err
+	}
+
+	return 0, tx.Commit()
+}
+
+func (pg *Postgres) ReleaseLoginAttempt(ctx context.Context, key string) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	_, err = dbo.ExecContext(ctx, `
+		UPDATE login_failure
+		SET failures = failures - 1
+		WHERE key = $1 AND failures > 0`,
+		key)
+
+	return err
+}
+
+func (pg *Postgres) ResetLoginFailures(ctx context.Context, key string) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	_, err = dbo.ExecContext(ctx, `
+		DELETE FROM login_failure
+		WHERE key = $1`,
+		key)
+
+	return err
+}
+
+func (pg *Postgres) ForgetLoginFailures(ctx context.Context, before time.Time) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	_, err = dbo.ExecContext(ctx, `
+		DELETE FROM login_failure
+		WHERE last_failure_at < $1`,
+		before)
+
+	return err
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+
+	"api/models"
+)
+
+func (pg *Postgres) SaveMatchResult(ctx context.Context, m *models.MatchResult) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// concurrent retries wait for each other on the primary key
+	var playedAt time.Time
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO match (match_id)
+		VALUES ($1)
+		ON CONFLICT DO NOTHING
+		RETURNING reported_at`,
+		m.MatchID).Scan(&playedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrMatchAlreadyReported
+		}
+		return err
+	}
+
+	// update players in the same order to avoid deadlocks between matches
+	players := make([]models.PlayerResult, len(m.Players))
+	copy(players, m.Players)
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].UserID < players[j].UserID
+	})
+	for _, p := range players {
+		var win, draw, loss int
+		switch p.Outcome {
+		case models.OutcomeWin:
+			win = 1
+		case models.OutcomeDraw:
+			draw = 1
+		case models.OutcomeLoss:
+			loss = 1
+		}
+		qres, err := tx.ExecContext(ctx, `
+			UPDATE user_profile
+			SET record = GREATEST(record, $2),
+				win = win + $3,
+				draws = draws + $4,
+				loss = loss + $5
+			WHERE user_id = $1`,
+			p.UserID, p.Score, win, draw, loss)
+		if err != nil {
+			return err
+		}
+		res, err := qres.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if res == 0 {
+			return UserNotFoundError{"id"}
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO match_participant (match_id, user_id, played_at, score, outcome, coins)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			m.MatchID, p.UserID, playedAt, p.Score, p.Outcome, p.Coins)
+		if err != nil {
+			return err
+		}
+
+		if p.Coins != 0 {
+			err = TxChangeUserCoinAmount(ctx, tx, p.UserID, p.Coins, models.CoinReasonMatch, m.MatchID)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = TxUnlockAchievements(ctx, tx, p.UserID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (pg *Postgres) GetUserMatchesPaginated(ctx context.Context, uID uint, limit uint64, before *models.MatchCursor) (
+	*[]models.MatchHistoryEntry, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	matches := &[]models.MatchHistoryEntry{}
+	if before == nil {
+		err = dbo.SelectContext(ctx, matches, `
+			SELECT match_id, played_at, score, outcome, coins FROM match_participant
+			WHERE user_id = $1
+			ORDER BY played_at DESC, match_id DESC
+			LIMIT $2`,
+			uID, limit)
+	} else {
+		err = dbo.SelectContext(ctx, matches, `
+			SELECT match_id, played_at, score, outcome, coins FROM match_participant
+			WHERE user_id = $1 AND (played_at, match_id) < ($2, $3)
+			ORDER BY played_at DESC, match_id DESC
+			LIMIT $4`,
+			uID, before.PlayedAt, before.MatchID, limit)
+	}
+	if err != nil {
+		return matches, err
+	}
+	if len(*matches) == 0 {
+		return matches, nil
+	}
+
+	ids := make([]string, 0, len(*matches))
+	byID := make(map[string]*models.MatchHistoryEntry, len(*matches))
+	for i := range *matches {
+		m := &(*matches)[i]
+		m.Opponents = []models.Opponent{}
+		ids = append(ids, m.MatchID)
+		byID[m.MatchID] = m
+	}
+	rows, err := dbo.QueryxContext(ctx, `
+		SELECT mp.match_id, mp.user_id, up.nickname, mp.score, mp.outcome FROM match_participant mp
+		JOIN user_profile up ON up.user_id = mp.user_id
+		WHERE mp.match_id = ANY($1) AND mp.user_id <> $2
+		ORDER BY mp.score DESC, mp.user_id`,
+		pq.Array(ids), uID)
+	if err != nil {
+		return matches, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var matchID string
+		o := models.Opponent{}
+		err = rows.Scan(&matchID, &o.ID, &o.Nickname, &o.Score, &o.Outcome)
+		if err != nil {
+			return matches, err
+		}
+		byID[matchID].Opponents = append(byID[matchID].Opponents, o)
+	}
+
+	return matches, rows.Err()
+}
@@ -0,0 +1,340 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"api/models"
+)
+
+type memoryUser struct {
+	id            uint
+	email         string
+	emailVerified bool
+	password      string
+	nickname      string
+	avatar        *string
+	stats         models.Stats
+	coins         int
+	skin          *uint
+	isAdmin       bool
+
+	skins        map[uint]bool
+	achievements map[uint]time.Time
+
+	streak    int
+	claimedOn time.Time
+
+	friends map[uint]time.Time
+	blocked map[uint]time.Time
+}
+
+type memoryFriendRequest struct {
+	from, to uint
+}
+
+type memoryEmailToken struct {
+	models.EmailToken
+	createdAt time.Time
+	used      bool
+}
+
+type memoryTransaction struct {
+	userID uint
+	models.CoinTransaction
+}
+
+type memoryParticipant struct {
+	matchID  string
+	userID   uint
+	playedAt time.Time
+	score    int
+	outcome  string
+	coins    int
+}
+
+// Memory implements the repositories in the process memory for the local development
+// and the tests, one lock makes every method behave like a transaction
+type Memory struct {
+	mu sync.Mutex
+
+	users          map[uint]*memoryUser
+	lastUserID     uint
+	friendRequests map[memoryFriendRequest]time.Time
+	// token hash -> token
+	emailTokens map[string]*memoryEmailToken
+
+	skins        map[uint]*models.Skin
+	lastSkinID   uint
+	sales        []models.Sale
+	lastSaleID   uint
+	bundles      map[uint]*models.Bundle
+	lastBundleID uint
+
+	transactions      []memoryTransaction
+	lastTransactionID uint64
+
+	gifts      []*models.Gift
+	promoCodes []*models.PromoCode
+	// promo ID -> users who redeemed the code
+	redemptions map[uint]map[uint]bool
+
+	achievements []models.Achievement
+	// coins for the day of the streak, the last one repeats
+	dailyRewards []int
+
+	reportedMatches map[string]bool
+	participants    []memoryParticipant
+	seasons         []models.Season
+	standings       map[uint][]models.Position
+}
+
+// NewMemory returns the repositories seeded like the database after the migrations
+func NewMemory() *Memory {
+	m := &Memory{
+		users:           make(map[uint]*memoryUser),
+		friendRequests:  make(map[memoryFriendRequest]time.Time),
+		emailTokens:     make(map[string]*memoryEmailToken),
+		skins:           make(map[uint]*models.Skin),
+		bundles:         make(map[uint]*models.Bundle),
+		redemptions:     make(map[uint]map[uint]bool),
+		reportedMatches: make(map[string]bool),
+		standings:       make(map[uint][]models.Position),
+		dailyRewards:    []int{10, 15, 20, 30, 40, 50, 100},
+	}
+
+	// migrations/2_skins.sql
+	for _, s := range []struct {
+		name string
+		cost int
+	}{
+		{"Classic", 0}, // default skin
+		{"Nature", 50},
+		{"Home", 100},
+		{"Pumpkin", 150},
+		{"Freak", 200},
+		{"Christmas", 0},
+	} {
+		m.lastSkinID++
+		m.skins[m.lastSkinID] = &models.Skin{
+			ID:     m.lastSkinID,
+			Name:   s.name,
+			Cost:   s.cost,
+			Rarity: models.RarityCommon,
+		}
+	}
+
+	// migrations/8_achievements.sql
+	for i, a := range []models.Achievement{
+		{Code: "first_game", Name: "First game", Description: "Play your first game",
+			Rule: models.RuleGames, Threshold: 1, Reward: 10},
+		{Code: "win_10", Name: "Winner", Description: "Win 10 games",
+			Rule: models.RuleWins, Threshold: 10, Reward: 50},
+		{Code: "win_100", Name: "Champion", Description: "Win 100 games",
+			Rule: models.RuleWins, Threshold: 100, Reward: 200},
+		{Code: "record_1000", Name: "High score", Description: "Reach record 1000",
+			Rule: models.RuleRecord, Threshold: 1000, Reward: 100},
+		{Code: "skins_3", Name: "Collector", Description: "Own 3 skins",
+			Rule: models.RuleSkins, Threshold: 3, Reward: 30},
+		{Code: "all_skins", Name: "Fashionista", Description: "Own every skin",
+			Rule: models.RuleAllSkins, Threshold: 0, Reward: 150},
+	} {
+		a.ID = uint(i + 1)
+		m.achievements = append(m.achievements, a)
+	}
+
+	return m
+}
+
+var (
+	_ UserRepository       = (*Memory)(nil)
+	_ StoreRepository      = (*Memory)(nil)
+	_ ScoreboardRepository = (*Memory)(nil)
+)
+
+// Ping is Postgres.Ping, the memory is always available
+func (m *Memory) Ping(_ context.Context) error {
+	return nil
+}
+
+// email, nickname, season name and promo code are case-insensitive in the database
+func sameText(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+func (m *Memory) userByEmail(e string) *memoryUser {
+	for _, u := range m.users {
+		if sameText(u.email, e) {
+			return u
+		}
+	}
+
+	return nil
+}
+
+func (m *Memory) userByNickname(n string) *memoryUser {
+	for _, u := range m.users {
+		if sameText(u.nickname, n) {
+			return u
+		}
+	}
+
+	return nil
+}
+
+// boughtSkins returns the skins of the user in ascending order
+func (u *memoryUser) boughtSkins() []uint {
+	res := make([]uint, 0, len(u.skins))
+	for id := range u.skins {
+		res = append(res, id)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i] < res[j]
+	})
+
+	return res
+}
+
+func (m *Memory) changeCoins(u *memoryUser, sum int, reason, reference string) error {
+	if u.coins+sum < 0 {
+		return ErrInsufficientCoins
+	}
+	u.coins += sum
+
+	t := memoryTransaction{
+		userID: u.id,
+		CoinTransaction: models.CoinTransaction{
+			Amount:    sum,
+			Reason:    reason,
+			Balance:   u.coins,
+			CreatedAt: time.Now(),
+		},
+	}
+	m.lastTransactionID++
+	t.ID = m.lastTransactionID
+	if reference != "" {
+		t.Reference = &reference
+	}
+	m.transactions = append(m.transactions, t)
+
+	return nil
+}
+
+// unlockAchievements is TxUnlockAchievements
+func (m *Memory) unlockAchievements(u *memoryUser) {
+	now := time.Now()
+	p := &achievementProgress{
+		games:  u.stats.Win + u.stats.Draws + u.stats.Loss,
+		wins:   u.stats.Win,
+		record: u.stats.Record,
+		skins:  len(u.skins),
+	}
+	for id, s := range m.skins {
+		if skinAvailableAt(s.AvailableFrom, s.AvailableUntil, s.Hidden, s.Retired, now) && !u.skins[id] {
+			p.missingSkins++
+		}
+	}
+
+	for _, a := range m.achievements {
+		if _, ok := u.achievements[a.ID]; ok {
+			continue
+		}
+		if check, ok := achievementRules[a.Rule]; !ok || !check(p, a.Threshold) {
+			continue
+		}
+		u.achievements[a.ID] = now
+		if a.Reward != 0 {
+			// rewards are never negative
+			_ = m.changeCoins(u, a.Reward, models.CoinReasonAchievement, a.Code)
+		}
+	}
+}
+
+// skinAvailableAt is skinIsAvailable
+func skinAvailableAt(from, until *time.Time, hidden, retired bool, now time.Time) bool {
+	return !hidden && !retired &&
+		(from == nil || !from.After(now)) &&
+		(until == nil || now.Before(*until))
+}
+
+// withPrice is selectSkinWithPrice
+func (m *Memory) withPrice(s *models.Skin, now time.Time) models.Skin {
+	res := *s
+	res.Price = s.Cost
+	res.SaleEndsAt = nil
+	found := false
+	for _, sale := range m.sales {
+		if sale.SkinID != s.ID || now.Before(sale.StartsAt) || !now.Before(sale.EndsAt) {
+			continue
+		}
+		price := s.Cost
+		if sale.PercentOff != nil {
+			price -= s.Cost * *sale.PercentOff / 100
+		} else if sale.AmountOff != nil {
+			price -= *sale.AmountOff
+		}
+		if price < 0 {
+			price = 0
+		}
+		if !found || price < res.Price || price == res.Price && sale.EndsAt.After(*res.SaleEndsAt) {
+			endsAt := sale.EndsAt
+			res.Price = price
+			res.SaleEndsAt = &endsAt
+			found = true
+		}
+	}
+
+	return res
+}
+
+func (m *Memory) rewardForDay(day int) int {
+	if day <= 0 {
+		return 0
+	}
+	if day > len(m.dailyRewards) {
+		day = len(m.dailyRewards)
+	}
+
+	return m.dailyRewards[day-1]
+}
+
+// rankSorted sets ranks of the positions ordered by record DESC, user_id DESC
+func rankSorted(positions []models.Position) {
+	for i := range positions {
+		switch {
+		case i == 0:
+			positions[i].Rank, positions[i].DenseRank = 1, 1
+		case positions[i].Points == positions[i-1].Points:
+			positions[i].Rank, positions[i].DenseRank = positions[i-1].Rank, positions[i-1].DenseRank
+		default:
+			positions[i].Rank, positions[i].DenseRank = i+1, positions[i-1].DenseRank+1
+		}
+	}
+}
+
+func sortPositions(positions []models.Position) {
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Points != positions[j].Points {
+			return positions[i].Points > positions[j].Points
+		}
+		return positions[i].ID > positions[j].ID
+	})
+}
+
+func paginate(positions []models.Position, limit, page uint64) []models.Position {
+	from := limit * page
+	if from > uint64(len(positions)) {
+		from = uint64(len(positions))
+	}
+	to := from + limit
+	if to > uint64(len(positions)) {
+		to = uint64(len(positions))
+	}
+	res := make([]models.Position, to-from)
+	copy(res, positions[from:to])
+
+	return res
+}
@@ -0,0 +1,304 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+
+	"api/models"
+)
+
+func (m *Memory) SaveMatchResult(_ context.Context, res *models.MatchResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.reportedMatches[res.MatchID] {
+		return ErrMatchAlreadyReported
+	}
+	// nothing is saved if any player can't be updated
+	for _, p := range res.Players {
+		u, ok := m.users[p.UserID]
+		if !ok {
+			return UserNotFoundError{"id"}
+		}
+		if u.coins+p.Coins < 0 {
+			return ErrInsufficientCoins
+		}
+	}
+
+	m.reportedMatches[res.MatchID] = true
+	playedAt := time.Now()
+	for _, p := range res.Players {
+		u := m.users[p.UserID]
+		if p.Score > u.stats.Record {
+			u.stats.Record = p.Score
+		}
+		switch p.Outcome {
+		case models.OutcomeWin:
+			u.stats.Win++
+		case models.OutcomeDraw:
+			u.stats.Draws++
+		case models.OutcomeLoss:
+			u.stats.Loss++
+		}
+
+		m.participants = append(m.participants, memoryParticipant{
+			matchID:  res.MatchID,
+			userID:   p.UserID,
+			playedAt: playedAt,
+			score:    p.Score,
+			outcome:  p.Outcome,
+			coins:    p.Coins,
+		})
+
+		if p.Coins != 0 {
+			err := m.changeCoins(u, p.Coins, models.CoinReasonMatch, res.MatchID)
+			if err != nil {
+				return err
+			}
+		}
+
+		m.unlockAchievements(u)
+	}
+
+	return nil
+}
+
+func (m *Memory) GetCountOfUsers(_ context.Context) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.users), nil
+}
+
+// allPositions returns the ranked all-time scoreboard
+func (m *Memory) allPositions() []models.Position {
+	positions := make([]models.Position, 0, len(m.users))
+	for _, u := range m.users {
+		positions = append(positions, models.Position{
+			ID:       u.id,
+			Nickname: u.nickname,
+			Points:   u.stats.Record,
+		})
+	}
+	sortPositions(positions)
+	rankSorted(positions)
+
+	return positions
+}
+
+func (m *Memory) GetUserPositionsDescendingPaginated(_ context.Context, limit, page uint64) (
+	*[]models.Position, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	records := paginate(m.allPositions(), limit, page)
+
+	return &records, len(m.users), nil
+}
+
+func (m *Memory) GetUserPositionsDescendingAfter(_ context.Context, limit uint64, after *models.ScoreboardCursor) (
+	*[]models.Position, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	positions := m.allPositions()
+	from := sort.Search(len(positions), func(i int) bool {
+		p := positions[i]
+		return p.Points < after.Record || p.Points == after.Record && p.ID < after.UserID
+	})
+	records := paginate(positions[from:], limit, 0)
+
+	return &records, len(m.users), nil
+}
+
+func (m *Memory) GetUserPositionsAround(_ context.Context, uID uint, k uint64) (*[]models.Position, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	positions := m.allPositions()
+	for i, p := range positions {
+		if p.ID != uID {
+			continue
+		}
+		from, to := uint64(0), uint64(i)+k+1
+		if uint64(i) > k {
+			from = uint64(i) - k
+		}
+		if to > uint64(len(positions)) {
+			to = uint64(len(positions))
+		}
+		records := make([]models.Position, to-from)
+		copy(records, positions[from:to])
+		return &records, len(m.users), nil
+	}
+
+	return nil, len(m.users), UserNotFoundError{"id"}
+}
+
+func (m *Memory) GetFriendsPositionsPaginated(_ context.Context, uID uint, limit, page uint64) (
+	*[]models.Position, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		records := []models.Position{}
+		return &records, 1, nil
+	}
+
+	positions := make([]models.Position, 0, len(u.friends)+1)
+	for _, p := range m.allPositions() {
+		if _, friend := u.friends[p.ID]; friend || p.ID == uID {
+			positions = append(positions, p)
+		}
+	}
+	rankSorted(positions)
+	records := paginate(positions, limit, page)
+
+	return &records, len(u.friends) + 1, nil
+}
+
+// FoldScoreboardRecords does nothing, the positions are sorted on every request
+func (m *Memory) FoldScoreboardRecords(_ context.Context) error {
+	return nil
+}
+
+func (m *Memory) CreateSeason(_ context.Context, s *models.Season) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, other := range m.seasons {
+		if sameText(other.Name, s.Name) {
+			return db.ErrUniqueConstraintViolation
+		}
+	}
+	s.ID = uint(len(m.seasons) + 1)
+	m.seasons = append(m.seasons, *s)
+
+	return nil
+}
+
+func (m *Memory) GetAllSeasons(_ context.Context) (*[]models.Season, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seasons := make([]models.Season, len(m.seasons))
+	copy(seasons, m.seasons)
+	sort.SliceStable(seasons, func(i, j int) bool {
+		return seasons[i].StartsAt.After(seasons[j].StartsAt)
+	})
+
+	return &seasons, nil
+}
+
+func (m *Memory) GetSeasonByName(_ context.Context, name string) (*models.Season, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, s := range m.seasons {
+		if sameText(s.Name, name) {
+			res := s
+			return &res, nil
+		}
+	}
+
+	return &models.Season{}, ErrNotFound
+}
+
+func (m *Memory) GetCurrentSeason(_ context.Context) (*models.Season, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	var res *models.Season
+	for i, s := range m.seasons {
+		if s.StartsAt.After(now) || !now.Before(s.EndsAt) {
+			continue
+		}
+		if res == nil || s.StartsAt.After(res.StartsAt) {
+			res = &m.seasons[i]
+		}
+	}
+	if res == nil {
+		return &models.Season{}, ErrNotFound
+	}
+	current := *res
+
+	return &current, nil
+}
+
+// periodPositions ranks players by their best score in the matches played in [from, to)
+func (m *Memory) periodPositions(from, to time.Time) []models.Position {
+	best := make(map[uint]int)
+	for _, p := range m.participants {
+		if p.playedAt.Before(from) || !p.playedAt.Before(to) {
+			continue
+		}
+		if record, ok := best[p.userID]; !ok || p.score > record {
+			best[p.userID] = p.score
+		}
+	}
+
+	positions := make([]models.Position, 0, len(best))
+	for uID, record := range best {
+		positions = append(positions, models.Position{
+			ID:       uID,
+			Nickname: m.users[uID].nickname,
+			Points:   record,
+		})
+	}
+	sortPositions(positions)
+	rankSorted(positions)
+
+	return positions
+}
+
+// GetPeriodPositionsPaginated ranks players by their best score in the matches played in [from, to)
+func (m *Memory) GetPeriodPositionsPaginated(_ context.Context, from, to time.Time, limit, page uint64) (
+	*[]models.Position, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	positions := m.periodPositions(from, to)
+	records := paginate(positions, limit, page)
+
+	return &records, len(positions), nil
+}
+
+func (m *Memory) GetSeasonStandingsPaginated(_ context.Context, seasonID uint, limit, page uint64) (
+	*[]models.Position, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	standings := m.standings[seasonID]
+	records := paginate(standings, limit, page)
+	// nicknames may have changed since the season was archived
+	for i := range records {
+		records[i].Nickname = m.users[records[i].ID].nickname
+	}
+
+	return &records, len(standings), nil
+}
+
+// ArchiveFinishedSeasons saves the final standings of the ended seasons
+func (m *Memory) ArchiveFinishedSeasons(_ context.Context) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	archived := 0
+	for i := range m.seasons {
+		s := &m.seasons[i]
+		if s.Archived || s.EndsAt.After(now) {
+			continue
+		}
+		m.standings[s.ID] = m.periodPositions(s.StartsAt, s.EndsAt)
+		s.Archived = true
+		archived++
+	}
+
+	return archived, nil
+}
@@ -0,0 +1,707 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+
+	"api/models"
+)
+
+func (m *Memory) skinVisible(s *models.Skin, uID uint, all bool, now time.Time) bool {
+	if all || skinAvailableAt(s.AvailableFrom, s.AvailableUntil, s.Hidden, s.Retired, now) {
+		return true
+	}
+	u, ok := m.users[uID]
+	return ok && u.skins[s.ID]
+}
+
+// availableSkin returns the skin with its price if it can be bought now
+func (m *Memory) availableSkin(id uint, now time.Time) (models.Skin, bool) {
+	s, ok := m.skins[id]
+	if !ok || !skinAvailableAt(s.AvailableFrom, s.AvailableUntil, s.Hidden, s.Retired, now) {
+		return models.Skin{}, false
+	}
+
+	return m.withPrice(s, now), true
+}
+
+func (m *Memory) GetSkin(_ context.Context, id, uID uint, all bool) (*models.Skin, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	s, ok := m.skins[id]
+	if !ok || !m.skinVisible(s, uID, all, now) {
+		return &models.Skin{}, ErrNotFound
+	}
+	res := m.withPrice(s, now)
+
+	return &res, nil
+}
+
+func (m *Memory) GetAllSkins(_ context.Context, uID uint, all bool) (*[]models.Skin, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	skins := []models.Skin{}
+	for _, s := range m.skins {
+		if m.skinVisible(s, uID, all, now) {
+			skins = append(skins, m.withPrice(s, now))
+		}
+	}
+	sort.Slice(skins, func(i, j int) bool {
+		return skins[i].ID < skins[j].ID
+	})
+
+	return &skins, nil
+}
+
+func (m *Memory) CreateSkin(_ context.Context, s *models.Skin) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastSkinID++
+	s.ID = m.lastSkinID
+	stored := *s
+	m.skins[s.ID] = &stored
+
+	return nil
+}
+
+func (m *Memory) UpdateSkin(_ context.Context, s *models.Skin) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.skins[s.ID]; !ok {
+		return ErrSkinNotFound
+	}
+	stored := *s
+	m.skins[s.ID] = &stored
+
+	return nil
+}
+
+// skinInUse checks the references which prevent the deletion of the skin in the database
+func (m *Memory) skinInUse(id uint) bool {
+	for _, u := range m.users {
+		if u.skins[id] || u.skin != nil && *u.skin == id {
+			return true
+		}
+	}
+	for _, b := range m.bundles {
+		for _, skinID := range b.Skins {
+			if skinID == id {
+				return true
+			}
+		}
+	}
+	for _, g := range m.gifts {
+		if g.SkinID != nil && *g.SkinID == id {
+			return true
+		}
+	}
+	for _, p := range m.promoCodes {
+		if p.SkinID != nil && *p.SkinID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeleteSkin deletes the skin nobody has bought or equipped, such skins can only be retired
+func (m *Memory) DeleteSkin(_ context.Context, id uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.skins[id]; !ok {
+		return ErrSkinNotFound
+	}
+	if m.skinInUse(id) {
+		return ErrSkinInUse
+	}
+	delete(m.skins, id)
+	sales := m.sales[:0]
+	for _, s := range m.sales {
+		if s.SkinID != id {
+			sales = append(sales, s)
+		}
+	}
+	m.sales = sales
+
+	return nil
+}
+
+func (m *Memory) GetUserStore(_ context.Context, uID uint) (*models.Store, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		return &models.Store{}, UserNotFoundError{"id"}
+	}
+	coins := u.coins
+
+	return &models.Store{
+		Coins:          &coins,
+		PurchasedSkins: u.boughtSkins(),
+		Skin:           u.skin,
+	}, nil
+}
+
+func (m *Memory) GetBoughtSkins(_ context.Context, uID uint) (*[]uint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res := []uint{}
+	if u, ok := m.users[uID]; ok {
+		res = u.boughtSkins()
+	}
+
+	return &res, nil
+}
+
+func (m *Memory) ChangeUserCoinAmount(_ context.Context, uID uint, sum int, reason, reference string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		return UserNotFoundError{"id"}
+	}
+
+	return m.changeCoins(u, sum, reason, reference)
+}
+
+func (m *Memory) BuySkin(_ context.Context, uID, skinID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		return UserNotFoundError{"id"}
+	}
+	// the owned skin is bought already even if it can't be bought anymore
+	if u.skins[skinID] {
+		return ErrAlreadyOwned
+	}
+	skin, ok := m.availableSkin(skinID, time.Now())
+	if !ok {
+		return ErrSkinNotFound
+	}
+
+	// the price at the moment of the purchase
+	if skin.Price != 0 {
+		err := m.changeCoins(u, -skin.Price,
+			models.CoinReasonSkinPurchase, strconv.FormatUint(uint64(skin.ID), 10))
+		if err != nil {
+			return err
+		}
+	}
+	u.skins[skin.ID] = true
+	m.unlockAchievements(u)
+
+	return nil
+}
+
+func (m *Memory) ChangeSkin(_ context.Context, uID, skin uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		return UserNotFoundError{"id"}
+	}
+	if skin != 0 {
+		u.skin = &skin
+	} else { // equip default skin
+		u.skin = nil
+	}
+	m.unlockAchievements(u)
+
+	return nil
+}
+
+func (m *Memory) GetCoinTransactionsPaginated(_ context.Context, uID uint, limit, before uint64) (
+	*[]models.CoinTransaction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	transactions := []models.CoinTransaction{}
+	for i := len(m.transactions) - 1; i >= 0 && uint64(len(transactions)) < limit; i-- {
+		t := m.transactions[i]
+		if t.userID == uID && (before == 0 || t.ID < before) {
+			transactions = append(transactions, t.CoinTransaction)
+		}
+	}
+
+	return &transactions, nil
+}
+
+// CheckCoinLedger recomputes the balances from the ledger and returns the users
+// whose coins differ from the sum of their transactions or from the last balance
+func (m *Memory) CheckCoinLedger(_ context.Context) (*models.CoinLedgerReport, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sums := make(map[uint]int)
+	last := make(map[uint]int)
+	for _, t := range m.transactions {
+		sums[t.userID] += t.Amount
+		last[t.userID] = t.Balance
+	}
+
+	res := &models.CoinLedgerReport{
+		CheckedUsers: len(m.users),
+		Drifts:       []models.CoinDrift{},
+	}
+	for _, u := range m.users {
+		balance, ok := last[u.id]
+		if u.coins == sums[u.id] && u.coins == balance {
+			continue
+		}
+		d := models.CoinDrift{
+			UserID:    u.id,
+			Coins:     u.coins,
+			LedgerSum: sums[u.id],
+		}
+		if ok {
+			d.LastBalance = &balance
+		}
+		res.Drifts = append(res.Drifts, d)
+	}
+	sort.Slice(res.Drifts, func(i, j int) bool {
+		return res.Drifts[i].UserID < res.Drifts[j].UserID
+	})
+
+	return res, nil
+}
+
+func (m *Memory) GetAllSales(_ context.Context) (*[]models.Sale, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sales := make([]models.Sale, len(m.sales))
+	copy(sales, m.sales)
+	sort.Slice(sales, func(i, j int) bool {
+		if !sales[i].StartsAt.Equal(sales[j].StartsAt) {
+			return sales[i].StartsAt.After(sales[j].StartsAt)
+		}
+		return sales[i].ID > sales[j].ID
+	})
+
+	return &sales, nil
+}
+
+func (m *Memory) CreateSale(_ context.Context, s *models.Sale) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.skins[s.SkinID]; !ok {
+		return ErrSkinNotFound
+	}
+	m.lastSaleID++
+	s.ID = m.lastSaleID
+	m.sales = append(m.sales, *s)
+
+	return nil
+}
+
+func (m *Memory) DeleteSale(_ context.Context, id uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, s := range m.sales {
+		if s.ID == id {
+			m.sales = append(m.sales[:i], m.sales[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrNotFound
+}
+
+// bundleAvailableAt is bundleIsAvailable
+func bundleAvailableAt(b *models.Bundle, now time.Time) bool {
+	return !b.Hidden && !b.Retired &&
+		(b.AvailableFrom == nil || !b.AvailableFrom.After(now)) &&
+		(b.AvailableUntil == nil || now.Before(*b.AvailableUntil))
+}
+
+// GetBundles returns the bundles which can be bought now with their skins,
+// with all set every bundle is returned
+func (m *Memory) GetBundles(_ context.Context, all bool) (*[]models.Bundle, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	bundles := []models.Bundle{}
+	for _, b := range m.bundles {
+		if !all && !bundleAvailableAt(b, now) {
+			continue
+		}
+		res := *b
+		res.Skins = make([]uint, len(b.Skins))
+		copy(res.Skins, b.Skins)
+		res.FullPrice = 0
+		for _, skinID := range res.Skins {
+			res.FullPrice += m.withPrice(m.skins[skinID], now).Price
+		}
+		bundles = append(bundles, res)
+	}
+	sort.Slice(bundles, func(i, j int) bool {
+		return bundles[i].ID < bundles[j].ID
+	})
+
+	return &bundles, nil
+}
+
+func (m *Memory) CreateBundle(_ context.Context, b *models.Bundle) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	skins := []uint{}
+	added := make(map[uint]bool, len(b.Skins))
+	for _, skinID := range b.Skins {
+		if _, ok := m.skins[skinID]; !ok {
+			return ErrSkinNotFound
+		}
+		if !added[skinID] {
+			skins = append(skins, skinID)
+			added[skinID] = true
+		}
+	}
+	sort.Slice(skins, func(i, j int) bool {
+		return skins[i] < skins[j]
+	})
+
+	m.lastBundleID++
+	b.ID = m.lastBundleID
+	stored := *b
+	stored.Skins = skins
+	m.bundles[b.ID] = &stored
+
+	return nil
+}
+
+func (m *Memory) DeleteBundle(_ context.Context, id uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.bundles[id]; !ok {
+		return ErrNotFound
+	}
+	delete(m.bundles, id)
+
+	return nil
+}
+
+// BuyBundle gives all skins of the bundle at once for the bundle cost reduced in proportion
+// to the skins the user already owns, it fails if the user owns every skin of the bundle
+func (m *Memory) BuyBundle(_ context.Context, uID, bundleID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		return UserNotFoundError{"id"}
+	}
+	b, ok := m.bundles[bundleID]
+	if !ok || !bundleAvailableAt(b, time.Now()) {
+		return ErrNotFound
+	}
+	missing := []uint{}
+	for _, skinID := range b.Skins {
+		if !u.skins[skinID] {
+			missing = append(missing, skinID)
+		}
+	}
+	if len(missing) == 0 {
+		return ErrAlreadyOwned
+	}
+
+	if cost := bundleCost(b.Cost, len(missing), len(b.Skins)); cost != 0 {
+		err := m.changeCoins(u, -cost,
+			models.CoinReasonBundlePurchase, strconv.FormatUint(uint64(bundleID), 10))
+		if err != nil {
+			return err
+		}
+	}
+	for _, skinID := range missing {
+		u.skins[skinID] = true
+	}
+	m.unlockAchievements(u)
+
+	return nil
+}
+
+// SendGift takes the coins or the price of the skin from the sender,
+// they are kept in the gift until the recipient accepts or declines it
+func (m *Memory) SendGift(_ context.Context, g *models.Gift) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	from, to, err := m.usersOf(g.FromID, g.ToID)
+	if err != nil {
+		return err
+	}
+	if isBlocked(from, to) {
+		return ErrBlocked
+	}
+
+	now := time.Now()
+	var sent, sentCoins int
+	for _, sg := range m.gifts {
+		if sg.FromID == g.FromID && sg.CreatedAt.After(now.Add(-24*time.Hour)) {
+			sent++
+			sentCoins += sg.Coins
+		}
+	}
+	if sent >= maxGiftsPerDay || sentCoins+g.Coins > maxGiftCoinsPerDay {
+		return ErrGiftLimitExceeded
+	}
+
+	g.Price = g.Coins
+	if g.SkinID != nil {
+		skin, ok := m.availableSkin(*g.SkinID, now)
+		if !ok {
+			return ErrSkinNotFound
+		}
+		g.Price = skin.Price
+		if to.skins[skin.ID] {
+			return ErrAlreadyOwned
+		}
+		// the same skin is already waiting in the inbox
+		for _, pending := range m.gifts {
+			if pending.ToID == g.ToID && pending.Status == models.GiftStatusPending &&
+				pending.SkinID != nil && *pending.SkinID == skin.ID {
+				return ErrAlreadyOwned
+			}
+		}
+	}
+	if from.coins < g.Price {
+		return ErrInsufficientCoins
+	}
+
+	g.ID = uint(len(m.gifts) + 1)
+	g.Status = models.GiftStatusPending
+	g.CreatedAt = now
+	if g.Price != 0 {
+		err = m.changeCoins(from, -g.Price,
+			models.CoinReasonGiftSent, strconv.FormatUint(uint64(g.ID), 10))
+		if err != nil {
+			return err
+		}
+	}
+	stored := *g
+	m.gifts = append(m.gifts, &stored)
+
+	return nil
+}
+
+func (m *Memory) pendingGift(uID, giftID uint) (*models.Gift, error) {
+	if giftID == 0 || giftID > uint(len(m.gifts)) {
+		return nil, ErrNotFound
+	}
+	g := m.gifts[giftID-1]
+	if g.ToID != uID || g.Status != models.GiftStatusPending {
+		return nil, ErrNotFound
+	}
+
+	return g, nil
+}
+
+// resolveGift sets the final status of the gift, the kept coins are returned to the sender if it is declined
+func (m *Memory) resolveGift(g *models.Gift, status string) error {
+	if status == models.GiftStatusDeclined && g.Price != 0 {
+		err := m.changeCoins(m.users[g.FromID], g.Price,
+			models.CoinReasonGiftRefund, strconv.FormatUint(uint64(g.ID), 10))
+		if err != nil {
+			return err
+		}
+	}
+	g.Status = status
+
+	return nil
+}
+
+// AcceptGift gives the gift to the recipient, if the recipient already has the skin
+// the gift is declined and ErrAlreadyOwned is returned
+func (m *Memory) AcceptGift(_ context.Context, uID, giftID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, err := m.pendingGift(uID, giftID)
+	if err != nil {
+		return err
+	}
+	u := m.users[uID]
+
+	if g.SkinID != nil {
+		if u.skins[*g.SkinID] {
+			// bought after the gift was sent
+			err = m.resolveGift(g, models.GiftStatusDeclined)
+			if err != nil {
+				return err
+			}
+			return ErrAlreadyOwned
+		}
+		u.skins[*g.SkinID] = true
+	} else {
+		err = m.changeCoins(u, g.Coins,
+			models.CoinReasonGiftReceived, strconv.FormatUint(uint64(g.ID), 10))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = m.resolveGift(g, models.GiftStatusAccepted)
+	if err != nil {
+		return err
+	}
+	m.unlockAchievements(u)
+
+	return nil
+}
+
+func (m *Memory) DeclineGift(_ context.Context, uID, giftID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, err := m.pendingGift(uID, giftID)
+	if err != nil {
+		return err
+	}
+
+	return m.resolveGift(g, models.GiftStatusDeclined)
+}
+
+// GetGiftList returns the gifts waiting in the inbox and the last sent ones
+func (m *Memory) GetGiftList(_ context.Context, uID uint) (*models.GiftList, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res := &models.GiftList{
+		Incoming: []models.Gift{},
+		Sent:     []models.Gift{},
+	}
+	for i := len(m.gifts) - 1; i >= 0; i-- {
+		g := *m.gifts[i]
+		g.FromNickname = m.users[g.FromID].nickname
+		g.ToNickname = m.users[g.ToID].nickname
+		if g.ToID == uID && g.Status == models.GiftStatusPending && len(res.Incoming) < maxListedGifts {
+			res.Incoming = append(res.Incoming, g)
+		}
+		if g.FromID == uID && len(res.Sent) < maxListedGifts {
+			res.Sent = append(res.Sent, g)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *Memory) promoCodeByCode(code string) *models.PromoCode {
+	for _, p := range m.promoCodes {
+		if sameText(p.Code, code) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// CreatePromoCodes creates the code from the template or count random codes with the prefix
+// and the reward of the template, random codes are regenerated on collisions
+func (m *Memory) CreatePromoCodes(_ context.Context, template *models.PromoCode, count int, prefix string) (
+	*[]models.PromoCode, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if template.SkinID != nil {
+		if _, ok := m.skins[*template.SkinID]; !ok {
+			return nil, ErrSkinNotFound
+		}
+	}
+
+	custom := template.Code != ""
+	if custom {
+		count = 1
+	}
+	codes := make([]models.PromoCode, 0, count)
+	for len(codes) < count {
+		p := *template
+		if !custom {
+			var err error
+			p.Code, err = randomPromoCode(prefix)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if m.promoCodeByCode(p.Code) != nil {
+			if custom {
+				return nil, db.ErrUniqueConstraintViolation
+			}
+			continue
+		}
+		p.ID = uint(len(m.promoCodes) + 1)
+		stored := p
+		m.promoCodes = append(m.promoCodes, &stored)
+		codes = append(codes, p)
+	}
+
+	return &codes, nil
+}
+
+// RedeemPromoCode gives the reward of the code to the user,
+// the code is not spent if the user already owns its skin
+func (m *Memory) RedeemPromoCode(_ context.Context, uID uint, code string) (*models.PromoReward, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p := m.promoCodeByCode(code)
+	if p == nil {
+		return nil, ErrNotFound
+	}
+	if p.ExpiresAt != nil && !time.Now().Before(*p.ExpiresAt) {
+		return nil, ErrPromoExpired
+	}
+	if p.MaxRedemptions != nil && p.Redeemed >= *p.MaxRedemptions {
+		return nil, ErrPromoExhausted
+	}
+	u, ok := m.users[uID]
+	if !ok {
+		return nil, UserNotFoundError{"id"}
+	}
+	if m.redemptions[p.ID][uID] {
+		return nil, ErrPromoRedeemed
+	}
+	if p.SkinID != nil && u.skins[*p.SkinID] {
+		return nil, ErrAlreadyOwned
+	}
+
+	if p.SkinID != nil {
+		u.skins[*p.SkinID] = true
+	}
+	if p.Coins != 0 {
+		err := m.changeCoins(u, p.Coins, models.CoinReasonPromoCode, p.Code)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if m.redemptions[p.ID] == nil {
+		m.redemptions[p.ID] = make(map[uint]bool)
+	}
+	m.redemptions[p.ID][uID] = true
+	p.Redeemed++
+	m.unlockAchievements(u)
+
+	return &models.PromoReward{
+		Coins:  p.Coins,
+		SkinID: p.SkinID,
+	}, nil
+}
@@ -0,0 +1,647 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+
+	"api/models"
+)
+
+func (m *Memory) GetUserPassword(_ context.Context, e string) (*models.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u := m.userByEmail(e)
+	if u == nil {
+		return &models.User{}, UserNotFoundError{"email"}
+	}
+
+	return &models.User{
+		UserID: u.id,
+		UserPassword: models.UserPassword{
+			Email:    u.email,
+			Password: u.password,
+		},
+	}, nil
+}
+
+func (m *Memory) CreateNewUser(_ context.Context, u *models.RegisterProfile) (*models.Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.userByEmail(u.Email) != nil || m.userByNickname(u.Nickname) != nil {
+		return nil, db.ErrUniqueConstraintViolation
+	}
+
+	m.lastUserID++
+	skin := uint(defaultSkinID)
+	m.users[m.lastUserID] = &memoryUser{
+		id:           m.lastUserID,
+		email:        u.Email,
+		password:     u.Password,
+		nickname:     u.Nickname,
+		skin:         &skin,
+		skins:        map[uint]bool{defaultSkinID: true},
+		achievements: make(map[uint]time.Time),
+		friends:      make(map[uint]time.Time),
+		blocked:      make(map[uint]time.Time),
+	}
+
+	return &models.Profile{
+		User: models.User{
+			UserID: m.lastUserID,
+			UserPassword: models.UserPassword{
+				Email: u.Email,
+			},
+		},
+		Nickname: u.Nickname,
+	}, nil
+}
+
+func (m *Memory) UpdateUserByID(_ context.Context, id uint, u *models.RegisterProfile) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return nil
+	}
+	if other := m.userByEmail(u.Email); u.Email != "" && other != nil && other != user {
+		return db.ErrUniqueConstraintViolation
+	}
+	if other := m.userByNickname(u.Nickname); u.Nickname != "" && other != nil && other != user {
+		return db.ErrUniqueConstraintViolation
+	}
+
+	if u.Email != "" {
+		user.email = u.Email
+		user.emailVerified = false
+	}
+	if u.Password != "" {
+		user.password = u.Password
+	}
+	if u.Nickname != "" {
+		user.nickname = u.Nickname
+	}
+
+	return nil
+}
+
+func (m *Memory) RehashPassword(_ context.Context, id uint, oldHash, newHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if u, ok := m.users[id]; ok && u.password == oldHash {
+		u.password = newHash
+	}
+
+	return nil
+}
+
+func (m *Memory) GetUserProfileByID(_ context.Context, id uint, private bool) (*models.Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[id]
+	if !ok {
+		return &models.Profile{}, UserNotFoundError{"id"}
+	}
+
+	res := &models.Profile{
+		User: models.User{
+			UserID: u.id,
+		},
+		Nickname: u.nickname,
+		Avatar:   u.avatar,
+		Stats:    u.stats,
+		Store: models.Store{
+			Skin: u.skin,
+		},
+	}
+	if private {
+		coins, verified := u.coins, u.emailVerified
+		res.Email = u.email
+		res.EmailVerified = &verified
+		res.Coins = &coins
+		res.PurchasedSkins = u.boughtSkins()
+		res.Daily = m.dailyStreak(u)
+	}
+
+	return res, nil
+}
+
+func (m *Memory) GetUserProfileByNickname(_ context.Context, nickname string) (*models.Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u := m.userByNickname(nickname)
+	if u == nil {
+		return &models.Profile{}, UserNotFoundError{"nickname"}
+	}
+
+	return &models.Profile{
+		User: models.User{
+			UserID: u.id,
+		},
+		Nickname: u.nickname,
+		Avatar:   u.avatar,
+		Stats:    u.stats,
+	}, nil
+}
+
+func (m *Memory) CheckExistenceOfEmail(_ context.Context, e string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.userByEmail(e) != nil, nil
+}
+
+func (m *Memory) CheckExistenceOfNickname(_ context.Context, n string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.userByNickname(n) != nil, nil
+}
+
+func (m *Memory) IsAdmin(_ context.Context, uID uint) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		return false, UserNotFoundError{"id"}
+	}
+
+	return u.isAdmin, nil
+}
+
+func (m *Memory) UploadAvatar(_ context.Context, uID uint, path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		return &UserNotFoundError{"id"}
+	}
+	u.avatar = &path
+
+	return nil
+}
+
+func (m *Memory) DeleteAvatar(_ context.Context, uID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		return &UserNotFoundError{"id"}
+	}
+	u.avatar = nil
+
+	return nil
+}
+
+func (m *Memory) GetUserMatchesPaginated(_ context.Context, uID uint, limit uint64, before *models.MatchCursor) (
+	*[]models.MatchHistoryEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	own := []memoryParticipant{}
+	for _, p := range m.participants {
+		if p.userID != uID {
+			continue
+		}
+		if before != nil && !(p.playedAt.Before(before.PlayedAt) ||
+			p.playedAt.Equal(before.PlayedAt) && p.matchID < before.MatchID) {
+			continue
+		}
+		own = append(own, p)
+	}
+	sort.Slice(own, func(i, j int) bool {
+		if !own[i].playedAt.Equal(own[j].playedAt) {
+			return own[i].playedAt.After(own[j].playedAt)
+		}
+		return own[i].matchID > own[j].matchID
+	})
+	if uint64(len(own)) > limit {
+		own = own[:limit]
+	}
+
+	matches := make([]models.MatchHistoryEntry, 0, len(own))
+	for _, p := range own {
+		e := models.MatchHistoryEntry{
+			MatchID:   p.matchID,
+			PlayedAt:  p.playedAt,
+			Score:     p.score,
+			Outcome:   p.outcome,
+			Coins:     p.coins,
+			Opponents: []models.Opponent{},
+		}
+		for _, o := range m.participants {
+			if o.matchID != p.matchID || o.userID == uID {
+				continue
+			}
+			e.Opponents = append(e.Opponents, models.Opponent{
+				ID:       o.userID,
+				Nickname: m.users[o.userID].nickname,
+				Score:    o.score,
+				Outcome:  o.outcome,
+			})
+		}
+		sort.Slice(e.Opponents, func(i, j int) bool {
+			if e.Opponents[i].Score != e.Opponents[j].Score {
+				return e.Opponents[i].Score > e.Opponents[j].Score
+			}
+			return e.Opponents[i].ID < e.Opponents[j].ID
+		})
+		matches = append(matches, e)
+	}
+
+	return &matches, nil
+}
+
+func (m *Memory) GetUserAchievements(_ context.Context, uID uint) (*[]models.Achievement, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		return nil, UserNotFoundError{"id"}
+	}
+
+	achievements := make([]models.Achievement, 0, len(m.achievements))
+	for _, a := range m.achievements {
+		if unlockedAt, ok := u.achievements[a.ID]; ok {
+			a.UnlockedAt = &unlockedAt
+		}
+		achievements = append(achievements, a)
+	}
+	sort.SliceStable(achievements, func(i, j int) bool {
+		a, b := achievements[i].UnlockedAt, achievements[j].UnlockedAt
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.After(*b)
+	})
+
+	return &achievements, nil
+}
+
+func todayDate() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (m *Memory) ClaimDailyReward(_ context.Context, uID uint) (*models.DailyReward, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		return nil, UserNotFoundError{"id"}
+	}
+
+	today := todayDate()
+	if u.claimedOn.Equal(today) {
+		return nil, ErrAlreadyClaimed
+	}
+	if u.claimedOn.Equal(today.AddDate(0, 0, -1)) {
+		u.streak++
+	} else { // missed a day
+		u.streak = 1
+	}
+	u.claimedOn = today
+
+	res := &models.DailyReward{
+		Streak: u.streak,
+		Coins:  m.rewardForDay(u.streak),
+	}
+	if res.Coins != 0 {
+		err := m.changeCoins(u, res.Coins, models.CoinReasonDailyReward, today.Format("2006-01-02"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (m *Memory) dailyStreak(u *memoryUser) *models.DailyStreak {
+	today := todayDate()
+	res := &models.DailyStreak{
+		ClaimedToday: u.claimedOn.Equal(today),
+	}
+	if !u.claimedOn.Before(today.AddDate(0, 0, -1)) {
+		res.Streak = u.streak
+	}
+	res.NextReward = m.rewardForDay(res.Streak + 1)
+
+	return res
+}
+
+func (m *Memory) GetDailyStreak(_ context.Context, uID uint) (*models.DailyStreak, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		return &models.DailyStreak{NextReward: m.rewardForDay(1)}, nil
+	}
+
+	return m.dailyStreak(u), nil
+}
+
+// usersOf returns both users or UserNotFoundError
+func (m *Memory) usersOf(uID, otherID uint) (*memoryUser, *memoryUser, error) {
+	u, ok := m.users[uID]
+	if !ok {
+		return nil, nil, UserNotFoundError{"id"}
+	}
+	other, ok := m.users[otherID]
+	if !ok {
+		return nil, nil, UserNotFoundError{"id"}
+	}
+
+	return u, other, nil
+}
+
+func isBlocked(u, other *memoryUser) bool {
+	_, blocked := u.blocked[other.id]
+	_, blockedBy := other.blocked[u.id]
+	return blocked || blockedBy
+}
+
+func makeFriends(u, other *memoryUser) {
+	now := time.Now()
+	if _, ok := u.friends[other.id]; !ok {
+		u.friends[other.id] = now
+	}
+	if _, ok := other.friends[u.id]; !ok {
+		other.friends[u.id] = now
+	}
+}
+
+func (m *Memory) SendFriendRequest(_ context.Context, from, to uint) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, other, err := m.usersOf(from, to)
+	if err != nil {
+		return false, err
+	}
+	if isBlocked(u, other) {
+		return false, ErrBlocked
+	}
+	if _, ok := u.friends[to]; ok {
+		return false, ErrAlreadyFriends
+	}
+
+	reverse := memoryFriendRequest{from: to, to: from}
+	if _, ok := m.friendRequests[reverse]; ok {
+		delete(m.friendRequests, reverse)
+		makeFriends(u, other)
+		return true, nil
+	}
+
+	r := memoryFriendRequest{from: from, to: to}
+	if _, ok := m.friendRequests[r]; !ok {
+		m.friendRequests[r] = time.Now()
+	}
+
+	return false, nil
+}
+
+func (m *Memory) AcceptFriendRequest(_ context.Context, uID, from uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, other, err := m.usersOf(uID, from)
+	if err != nil {
+		return err
+	}
+	r := memoryFriendRequest{from: from, to: uID}
+	if _, ok := m.friendRequests[r]; !ok {
+		return ErrNotFound
+	}
+	delete(m.friendRequests, r)
+	makeFriends(u, other)
+
+	return nil
+}
+
+func (m *Memory) DeclineFriendRequest(_ context.Context, uID, from uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r := memoryFriendRequest{from: from, to: uID}
+	if _, ok := m.friendRequests[r]; !ok {
+		return ErrNotFound
+	}
+	delete(m.friendRequests, r)
+
+	return nil
+}
+
+func (m *Memory) removeFriendship(uID, friendID uint) bool {
+	removed := false
+	if u, ok := m.users[uID]; ok {
+		if _, ok := u.friends[friendID]; ok {
+			delete(u.friends, friendID)
+			removed = true
+		}
+	}
+	if f, ok := m.users[friendID]; ok {
+		if _, ok := f.friends[uID]; ok {
+			delete(f.friends, uID)
+			removed = true
+		}
+	}
+
+	return removed
+}
+
+// RemoveFriend removes the friend or cancels the sent friend request
+func (m *Memory) RemoveFriend(_ context.Context, uID, friendID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	removed := m.removeFriendship(uID, friendID)
+	r := memoryFriendRequest{from: uID, to: friendID}
+	_, canceled := m.friendRequests[r]
+	delete(m.friendRequests, r)
+	if !removed && !canceled {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (m *Memory) BlockUser(_ context.Context, uID, blockedID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, _, err := m.usersOf(uID, blockedID)
+	if err != nil {
+		return err
+	}
+	if _, ok := u.blocked[blockedID]; !ok {
+		u.blocked[blockedID] = time.Now()
+	}
+	m.removeFriendship(uID, blockedID)
+	delete(m.friendRequests, memoryFriendRequest{from: uID, to: blockedID})
+	delete(m.friendRequests, memoryFriendRequest{from: blockedID, to: uID})
+
+	return nil
+}
+
+func (m *Memory) UnblockUser(_ context.Context, uID, blockedID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[uID]
+	if !ok {
+		return ErrNotFound
+	}
+	if _, ok := u.blocked[blockedID]; !ok {
+		return ErrNotFound
+	}
+	delete(u.blocked, blockedID)
+
+	return nil
+}
+
+func (m *Memory) friend(id uint, since time.Time) models.Friend {
+	u := m.users[id]
+	return models.Friend{
+		ID:       u.id,
+		Nickname: u.nickname,
+		Avatar:   u.avatar,
+		Record:   u.stats.Record,
+		Since:    since,
+	}
+}
+
+// sortFriendsBySince orders the requests and the blocked users from the newest
+func sortFriendsBySince(friends []models.Friend) {
+	sort.Slice(friends, func(i, j int) bool {
+		if !friends[i].Since.Equal(friends[j].Since) {
+			return friends[i].Since.After(friends[j].Since)
+		}
+		return friends[i].ID > friends[j].ID
+	})
+}
+
+func (m *Memory) GetFriendList(_ context.Context, uID uint) (*models.FriendList, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res := &models.FriendList{
+		Friends:  []models.Friend{},
+		Incoming: []models.Friend{},
+		Outgoing: []models.Friend{},
+		Blocked:  []models.Friend{},
+	}
+	u, ok := m.users[uID]
+	if !ok {
+		return res, nil
+	}
+
+	for id, since := range u.friends {
+		res.Friends = append(res.Friends, m.friend(id, since))
+	}
+	sort.Slice(res.Friends, func(i, j int) bool {
+		return strings.ToLower(res.Friends[i].Nickname) < strings.ToLower(res.Friends[j].Nickname)
+	})
+	for r, createdAt := range m.friendRequests {
+		if r.to == uID {
+			res.Incoming = append(res.Incoming, m.friend(r.from, createdAt))
+		}
+		if r.from == uID {
+			res.Outgoing = append(res.Outgoing, m.friend(r.to, createdAt))
+		}
+	}
+	sortFriendsBySince(res.Incoming)
+	sortFriendsBySince(res.Outgoing)
+	for id, createdAt := range u.blocked {
+		res.Blocked = append(res.Blocked, m.friend(id, createdAt))
+	}
+	sortFriendsBySince(res.Blocked)
+
+	return res, nil
+}
+
+func (m *Memory) CreateEmailToken(_ context.Context, t *models.EmailToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[t.UserID]; !ok {
+		return UserNotFoundError{"id"}
+	}
+	now := time.Now()
+	sent := 0
+	for _, other := range m.emailTokens {
+		if other.UserID == t.UserID && other.Purpose == t.Purpose && other.createdAt.After(now.Add(-time.Hour)) {
+			sent++
+		}
+	}
+	if sent >= maxEmailTokensPerHour {
+		return ErrTooManyTokens
+	}
+	m.emailTokens[t.Hash] = &memoryEmailToken{
+		EmailToken: *t,
+		createdAt:  now,
+	}
+
+	return nil
+}
+
+// useEmailToken is txUseEmailToken
+func (m *Memory) useEmailToken(hash, purpose string) (*memoryUser, error) {
+	t, ok := m.emailTokens[hash]
+	if !ok || t.Purpose != purpose || t.used || !time.Now().Before(t.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	u, ok := m.users[t.UserID]
+	if !ok || !sameText(u.email, t.Email) {
+		return nil, ErrInvalidToken
+	}
+	t.used = true
+
+	return u, nil
+}
+
+func (m *Memory) VerifyEmail(_ context.Context, hash string) (uint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, err := m.useEmailToken(hash, models.TokenVerifyEmail)
+	if err != nil {
+		return 0, err
+	}
+	u.emailVerified = true
+
+	return u.id, nil
+}
+
+func (m *Memory) ResetPassword(_ context.Context, hash, password string) (uint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, err := m.useEmailToken(hash, models.TokenResetPassword)
+	if err != nil {
+		return 0, err
+	}
+	u.password = password
+	u.emailVerified = true
+	for _, t := range m.emailTokens {
+		if t.UserID == u.id && t.Purpose == models.TokenResetPassword {
+			t.used = true
+		}
+	}
+
+	return u.id, nil
+}
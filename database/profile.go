@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"strings"
 
@@ -15,13 +16,13 @@ const (
 	defaultSkinID = 1
 )
 
-func GetUserPassword(dm *db.DatabaseManager, e string) (*models.User, error) {
-	dbo, err := dm.DB()
+func (pg *Postgres) GetUserPassword(ctx context.Context, e string) (*models.User, error) {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return nil, err
 	}
 	res := &models.User{}
-	err = dbo.Get(res, `
+	err = dbo.GetContext(ctx, res, `
 	SELECT user_id, email, password FROM user_profile
 	WHERE email = $1`,
 		e)
@@ -35,19 +36,19 @@ func GetUserPassword(dm *db.DatabaseManager, e string) (*models.User, error) {
 	return res, nil
 }
 
-func CreateNewUser(dm *db.DatabaseManager, u *models.RegisterProfile) (*models.Profile, error) {
-	dbo, err := dm.DB()
+func (pg *Postgres) CreateNewUser(ctx context.Context, u *models.RegisterProfile) (*models.Profile, error) {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return nil, err
 	}
-	tx, err := dbo.Beginx()
+	tx, err := dbo.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
 		_ = tx.Rollback()
 	}()
-	qres := tx.QueryRowx(`
+	qres := tx.QueryRowxContext(ctx, `
 		INSERT INTO user_profile (email, password, nickname, skin)
 		VALUES ($1, $2, $3, $4) RETURNING user_id, email, nickname`,
 		u.Email, u.Password, u.Nickname, defaultSkinID)
@@ -65,7 +66,7 @@ func CreateNewUser(dm *db.DatabaseManager, u *models.RegisterProfile) (*models.P
 	if err != nil {
 		return res, err
 	}
-	_, err = tx.Exec(`
+	_, err = tx.ExecContext(ctx, `
 		INSERT INTO user_purchased_skins (user_id, skin_id)
 		VALUES ($1, $2)`,
 		res.UserID, defaultSkinID)
@@ -76,7 +77,7 @@ func CreateNewUser(dm *db.DatabaseManager, u *models.RegisterProfile) (*models.P
 	return res, tx.Commit()
 }
 
-func UpdateUserByID(dm *db.DatabaseManager, id uint, u *models.RegisterProfile) error {
+func (pg *Postgres) UpdateUserByID(ctx context.Context, id uint, u *models.RegisterProfile) error {
 	if u.Email == "" && u.Password == "" && u.Nickname == "" {
 		return nil
 	}
@@ -87,7 +88,8 @@ func UpdateUserByID(dm *db.DatabaseManager, id uint, u *models.RegisterProfile)
 		SET `)
 	hasBefore := false
 	if u.Email != "" {
-		q.WriteString("email = :email")
+		// the new address is verified again
+		q.WriteString("email = :email, email_verified = false")
 		hasBefore = true
 	}
 	if u.Password != "" {
@@ -106,11 +108,11 @@ func UpdateUserByID(dm *db.DatabaseManager, id uint, u *models.RegisterProfile)
 	q.WriteString(`
 		WHERE user_id = :user_id`)
 
-	dbo, err := dm.DB()
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return err
 	}
-	_, err = dbo.NamedExec(q.String(), &models.Profile{
+	_, err = dbo.NamedExecContext(ctx, q.String(), &models.Profile{
 		User: models.User{
 			UserID: id,
 			UserPassword: models.UserPassword{
@@ -127,8 +129,24 @@ func UpdateUserByID(dm *db.DatabaseManager, id uint, u *models.RegisterProfile)
 	return nil
 }
 
-func GetUserProfileByID(dm *db.DatabaseManager, id uint, private bool) (*models.Profile, error) {
-	dbo, err := dm.DB()
+// RehashPassword replaces the hash of the same password, it does nothing if the password
+// has been changed since the old hash was read
+func (pg *Postgres) RehashPassword(ctx context.Context, id uint, oldHash, newHash string) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	_, err = dbo.ExecContext(ctx, `
+		UPDATE user_profile
+		SET password = $3
+		WHERE user_id = $1 AND password = $2`,
+		id, oldHash, newHash)
+
+	return err
+}
+
+func (pg *Postgres) GetUserProfileByID(ctx context.Context, id uint, private bool) (*models.Profile, error) {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return nil, err
 	}
@@ -136,14 +154,14 @@ func GetUserProfileByID(dm *db.DatabaseManager, id uint, private bool) (*models.
 	q := ""
 	if private {
 		q = `
-		SELECT user_id, email, nickname, avatar, record, win, draws, loss, coins, skin FROM user_profile
+		SELECT user_id, email, email_verified, nickname, avatar, record, win, draws, loss, coins, skin FROM user_profile
 		WHERE user_id = $1`
 	} else {
 		q = `
 		SELECT user_id, nickname, avatar, record, win, draws, loss, skin FROM user_profile
 		WHERE user_id = $1`
 	}
-	err = dbo.Get(res, q, id)
+	err = dbo.GetContext(ctx, res, q, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return res, UserNotFoundError{"id"}
@@ -153,7 +171,7 @@ func GetUserProfileByID(dm *db.DatabaseManager, id uint, private bool) (*models.
 
 	if private {
 		var purchased []uint
-		err = dbo.Select(&purchased, `
+		err = dbo.SelectContext(ctx, &purchased, `
 			SELECT skin_id FROM user_purchased_skins
 			WHERE user_id = $1`,
 			id)
@@ -161,18 +179,23 @@ func GetUserProfileByID(dm *db.DatabaseManager, id uint, private bool) (*models.
 			return res, err
 		}
 		res.PurchasedSkins = purchased
+
+		res.Daily, err = pg.GetDailyStreak(ctx, id)
+		if err != nil {
+			return res, err
+		}
 	}
 
 	return res, nil
 }
 
-func GetUserProfileByNickname(dm *db.DatabaseManager, nickname string) (*models.Profile, error) {
-	dbo, err := dm.DB()
+func (pg *Postgres) GetUserProfileByNickname(ctx context.Context, nickname string) (*models.Profile, error) {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return nil, err
 	}
 	res := &models.Profile{}
-	err = dbo.Get(res, `
+	err = dbo.GetContext(ctx, res, `
 		SELECT user_id, nickname, avatar, record, win, draws, loss FROM user_profile
 		WHERE nickname = $1`,
 		nickname)
@@ -186,13 +209,13 @@ func GetUserProfileByNickname(dm *db.DatabaseManager, nickname string) (*models.
 	return res, nil
 }
 
-func CheckExistenceOfEmail(dm *db.DatabaseManager, e string) (bool, error) {
-	dbo, err := dm.DB()
+func (pg *Postgres) CheckExistenceOfEmail(ctx context.Context, e string) (bool, error) {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return false, err
 	}
 	res := &models.Profile{}
-	err = dbo.Get(res, `
+	err = dbo.GetContext(ctx, res, `
 	SELECT FROM user_profile
 	WHERE email = $1`,
 		e)
@@ -206,13 +229,13 @@ func CheckExistenceOfEmail(dm *db.DatabaseManager, e string) (bool, error) {
 	return true, nil
 }
 
-func CheckExistenceOfNickname(dm *db.DatabaseManager, n string) (bool, error) {
-	dbo, err := dm.DB()
+func (pg *Postgres) CheckExistenceOfNickname(ctx context.Context, n string) (bool, error) {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return false, err
 	}
 	res := &models.Profile{}
-	err = dbo.Get(res, `
+	err = dbo.GetContext(ctx, res, `
 		SELECT FROM user_profile
 		WHERE nickname = $1`,
 		n)
@@ -226,15 +249,35 @@ func CheckExistenceOfNickname(dm *db.DatabaseManager, n string) (bool, error) {
 	return true, nil
 }
 
-func GetCountOfUsers(dm *db.DatabaseManager) (int, error) {
-	dbo, err := dm.DB()
+func (pg *Postgres) IsAdmin(ctx context.Context, uID uint) (bool, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return false, err
+	}
+	res := false
+	err = dbo.GetContext(ctx, &res, `
+		SELECT is_admin FROM user_profile
+		WHERE user_id = $1`,
+		uID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, UserNotFoundError{"id"}
+		}
+		return false, err
+	}
+
+	return res, nil
+}
+
+func (pg *Postgres) GetCountOfUsers(ctx context.Context) (int, error) {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return 0, err
 	}
-	// TODO: optimize it
+	// scoreboard_players is maintained by triggers on user_profile
 	res := 0
-	err = dbo.Get(&res, `
-	SELECT COUNT(*) FROM user_profile`)
+	err = dbo.GetContext(ctx, &res, `
+	SELECT COALESCE(SUM(players), 0) FROM scoreboard_players`)
 	if err != nil {
 		return 0, err
 	}
@@ -242,12 +285,12 @@ func GetCountOfUsers(dm *db.DatabaseManager) (int, error) {
 	return res, nil
 }
 
-func UploadAvatar(dm *db.DatabaseManager, uID uint, path string) error {
-	dbo, err := dm.DB()
+func (pg *Postgres) UploadAvatar(ctx context.Context, uID uint, path string) error {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return err
 	}
-	qres, err := dbo.Exec(`
+	qres, err := dbo.ExecContext(ctx, `
 		UPDATE user_profile
 		SET avatar = $2
 		WHERE user_id = $1`,
@@ -266,12 +309,12 @@ func UploadAvatar(dm *db.DatabaseManager, uID uint, path string) error {
 	return nil
 }
 
-func DeleteAvatar(dm *db.DatabaseManager, uID uint) error {
-	dbo, err := dm.DB()
+func (pg *Postgres) DeleteAvatar(ctx context.Context, uID uint) error {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return err
 	}
-	qres, err := dbo.Exec(`
+	qres, err := dbo.ExecContext(ctx, `
 		UPDATE user_profile
 		SET avatar = NULL
 		WHERE user_id = $1`,
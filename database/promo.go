@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"math/big"
+	"time"
+
+	"github.com/lib/pq"
+
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+
+	"api/models"
+)
+
+const (
+	// without the letters and digits which look alike
+	promoCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	promoCodeLength   = 10
+)
+
+func randomPromoCode(prefix string) (string, error) {
+	code := make([]byte, promoCodeLength)
+	max := big.NewInt(int64(len(promoCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = promoCodeAlphabet[n.Int64()]
+	}
+
+	return prefix + string(code), nil
+}
+
+// CreatePromoCodes creates the code from the template or count random codes with the prefix
+// and the reward of the template, random codes are regenerated on collisions
+func (pg *Postgres) CreatePromoCodes(ctx context.Context, template *models.PromoCode, count int, prefix string) (
+	*[]models.PromoCode, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := dbo.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	custom := template.Code != ""
+	if custom {
+		count = 1
+	}
+	codes := make([]models.PromoCode, 0, count)
+	for len(codes) < count {
+		p := *template
+		if !custom {
+			p.Code, err = randomPromoCode(prefix)
+			if err != nil {
+				return nil, err
+			}
+		}
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO promo_code (code, coins, skin_id, max_redemptions, expires_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (code) DO NOTHING
+			RETURNING promo_id`,
+			p.Code, p.Coins, p.SkinID, p.MaxRedemptions, p.ExpiresAt).Scan(&p.ID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				if custom {
+					return nil, db.ErrUniqueConstraintViolation
+				}
+				continue
+			}
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+				return nil, ErrSkinNotFound
+			}
+			return nil, err
+		}
+		codes = append(codes, p)
+	}
+
+	return &codes, tx.Commit()
+}
+
+// RedeemPromoCode gives the reward of the code to the user,
+// the code is not spent if the user already owns its skin
+func (pg *Postgres) RedeemPromoCode(ctx context.Context, uID uint, code string) (*models.PromoReward, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := dbo.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	p := &models.PromoCode{}
+	err = tx.QueryRowContext(ctx, `
+		SELECT promo_id, code, coins, skin_id, max_redemptions, redeemed, expires_at FROM promo_code
+		WHERE code = $1
+		FOR UPDATE`,
+		code).Scan(&p.ID, &p.Code, &p.Coins, &p.SkinID, &p.MaxRedemptions, &p.Redeemed, &p.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if p.ExpiresAt != nil && !time.Now().Before(*p.ExpiresAt) {
+		return nil, ErrPromoExpired
+	}
+	if p.MaxRedemptions != nil && p.Redeemed >= *p.MaxRedemptions {
+		return nil, ErrPromoExhausted
+	}
+
+	qres, err := tx.ExecContext(ctx, `
+		INSERT INTO promo_redemption (promo_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`,
+		p.ID, uID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return nil, UserNotFoundError{"id"}
+		}
+		return nil, err
+	}
+	res, err := qres.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if res == 0 {
+		return nil, ErrPromoRedeemed
+	}
+
+	reward := &models.PromoReward{
+		Coins:  p.Coins,
+		SkinID: p.SkinID,
+	}
+	if p.SkinID != nil {
+		qres, err = tx.ExecContext(ctx, `
+			INSERT INTO user_purchased_skins (user_id, skin_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING`,
+			uID, *p.SkinID)
+		if err != nil {
+			return nil, err
+		}
+		res, err = qres.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if res == 0 {
+			return nil, ErrAlreadyOwned
+		}
+	}
+	if p.Coins != 0 {
+		err = TxChangeUserCoinAmount(ctx, tx, uID, p.Coins, models.CoinReasonPromoCode, p.Code)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE promo_code
+		SET redeemed = redeemed + 1
+		WHERE promo_id = $1`,
+		p.ID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = TxUnlockAchievements(ctx, tx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	return reward, tx.Commit()
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+
+	"api/models"
+)
+
+// UserRepository stores the profiles and everything the players do with them
+type UserRepository interface {
+	GetUserPassword(ctx context.Context, e string) (*models.User, error)
+	CreateNewUser(ctx context.Context, u *models.RegisterProfile) (*models.Profile, error)
+	UpdateUserByID(ctx context.Context, id uint, u *models.RegisterProfile) error
+	RehashPassword(ctx context.Context, id uint, oldHash, newHash string) error
+	GetUserProfileByID(ctx context.Context, id uint, private bool) (*models.Profile, error)
+	GetUserProfileByNickname(ctx context.Context, nickname string) (*models.Profile, error)
+	CheckExistenceOfEmail(ctx context.Context, e string) (bool, error)
+	CheckExistenceOfNickname(ctx context.Context, n string) (bool, error)
+	IsAdmin(ctx context.Context, uID uint) (bool, error)
+	UploadAvatar(ctx context.Context, uID uint, path string) error
+	DeleteAvatar(ctx context.Context, uID uint) error
+
+	CreateEmailToken(ctx context.Context, t *models.EmailToken) error
+	VerifyEmail(ctx context.Context, hash string) (uint, error)
+	ResetPassword(ctx context.Context, hash, password string) (uint, error)
+
+	GetUserMatchesPaginated(ctx context.Context, uID uint, limit uint64, before *models.MatchCursor) (
+		*[]models.MatchHistoryEntry, error)
+	GetUserAchievements(ctx context.Context, uID uint) (*[]models.Achievement, error)
+	ClaimDailyReward(ctx context.Context, uID uint) (*models.DailyReward, error)
+	GetDailyStreak(ctx context.Context, uID uint) (*models.DailyStreak, error)
+
+	SendFriendRequest(ctx context.Context, from, to uint) (bool, error)
+	AcceptFriendRequest(ctx context.Context, uID, from uint) error
+	DeclineFriendRequest(ctx context.Context, uID, from uint) error
+	RemoveFriend(ctx context.Context, uID, friendID uint) error
+	BlockUser(ctx context.Context, uID, blockedID uint) error
+	UnblockUser(ctx context.Context, uID, blockedID uint) error
+	GetFriendList(ctx context.Context, uID uint) (*models.FriendList, error)
+}
+
+// StoreRepository stores the skins, the coins and everything bought with them
+type StoreRepository interface {
+	GetSkin(ctx context.Context, id, uID uint, all bool) (*models.Skin, error)
+	GetAllSkins(ctx context.Context, uID uint, all bool) (*[]models.Skin, error)
+	CreateSkin(ctx context.Context, s *models.Skin) error
+	UpdateSkin(ctx context.Context, s *models.Skin) error
+	DeleteSkin(ctx context.Context, id uint) error
+	GetUserStore(ctx context.Context, uID uint) (*models.Store, error)
+	GetBoughtSkins(ctx context.Context, uID uint) (*[]uint, error)
+	ChangeUserCoinAmount(ctx context.Context, uID uint, sum int, reason, reference string) error
+	BuySkin(ctx context.Context, uID, skinID uint) error
+	ChangeSkin(ctx context.Context, uID, skin uint) error
+
+	GetCoinTransactionsPaginated(ctx context.Context, uID uint, limit, before uint64) (*[]models.CoinTransaction, error)
+	CheckCoinLedger(ctx context.Context) (*models.CoinLedgerReport, error)
+
+	GetAllSales(ctx context.Context) (*[]models.Sale, error)
+	CreateSale(ctx context.Context, s *models.Sale) error
+	DeleteSale(ctx context.Context, id uint) error
+	GetBundles(ctx context.Context, all bool) (*[]models.Bundle, error)
+	CreateBundle(ctx context.Context, b *models.Bundle) error
+	DeleteBundle(ctx context.Context, id uint) error
+	BuyBundle(ctx context.Context, uID, bundleID uint) error
+
+	SendGift(ctx context.Context, g *models.Gift) error
+	AcceptGift(ctx context.Context, uID, giftID uint) error
+	DeclineGift(ctx context.Context, uID, giftID uint) error
+	GetGiftList(ctx context.Context, uID uint) (*models.GiftList, error)
+
+	CreatePromoCodes(ctx context.Context, template *models.PromoCode, count int, prefix string) (
+		*[]models.PromoCode, error)
+	RedeemPromoCode(ctx context.Context, uID uint, code string) (*models.PromoReward, error)
+}
+
+// ScoreboardRepository stores the results of the matches and the rankings built from them
+type ScoreboardRepository interface {
+	SaveMatchResult(ctx context.Context, m *models.MatchResult) error
+
+	GetCountOfUsers(ctx context.Context) (int, error)
+	GetUserPositionsDescendingPaginated(ctx context.Context, limit, page uint64) (*[]models.Position, int, error)
+	GetUserPositionsDescendingAfter(ctx context.Context, limit uint64, after *models.ScoreboardCursor) (
+		*[]models.Position, int, error)
+	GetUserPositionsAround(ctx context.Context, uID uint, k uint64) (*[]models.Position, int, error)
+	GetFriendsPositionsPaginated(ctx context.Context, uID uint, limit, page uint64) (*[]models.Position, int, error)
+	// FoldScoreboardRecords compacts the counts of the players per record, the positions are right without it
+	FoldScoreboardRecords(ctx context.Context) error
+
+	CreateSeason(ctx context.Context, s *models.Season) error
+	GetAllSeasons(ctx context.Context) (*[]models.Season, error)
+	GetSeasonByName(ctx context.Context, name string) (*models.Season, error)
+	GetCurrentSeason(ctx context.Context) (*models.Season, error)
+	GetPeriodPositionsPaginated(ctx context.Context, from, to time.Time, limit, page uint64) (
+		*[]models.Position, int, error)
+	GetSeasonStandingsPaginated(ctx context.Context, seasonID uint, limit, page uint64) (*[]models.Position, int, error)
+	ArchiveFinishedSeasons(ctx context.Context) (int, error)
+}
+
+// Postgres implements the repositories on PostgreSQL
+type Postgres struct {
+	dm *db.DatabaseManager
+}
+
+func NewPostgres(dm *db.DatabaseManager) *Postgres {
+	return &Postgres{
+		dm: dm,
+	}
+}
+
+var (
+	_ UserRepository       = (*Postgres)(nil)
+	_ StoreRepository      = (*Postgres)(nil)
+	_ ScoreboardRepository = (*Postgres)(nil)
+)
+
+// Ping checks the connection to the database
+func (pg *Postgres) Ping(ctx context.Context) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+
+	return dbo.PingContext(ctx)
+}
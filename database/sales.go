@@ -0,0 +1,280 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/lib/pq"
+
+	"api/models"
+)
+
+func (pg *Postgres) GetAllSales(ctx context.Context) (*[]models.Sale, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	sales := &[]models.Sale{}
+	err = dbo.SelectContext(ctx, sales, `
+		SELECT sale_id, skin_id, percent_off, amount_off, starts_at, ends_at FROM skin_sale
+		ORDER BY starts_at DESC, sale_id DESC`)
+	if err != nil {
+		return sales, err
+	}
+
+	return sales, nil
+}
+
+func (pg *Postgres) CreateSale(ctx context.Context, s *models.Sale) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	err = dbo.QueryRowContext(ctx, `
+		INSERT INTO skin_sale (skin_id, percent_off, amount_off, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING sale_id`,
+		s.SkinID, s.PercentOff, s.AmountOff, s.StartsAt, s.EndsAt).Scan(&s.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return ErrSkinNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (pg *Postgres) DeleteSale(ctx context.Context, id uint) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	qres, err := dbo.ExecContext(ctx, `
+		DELETE FROM skin_sale
+		WHERE sale_id = $1`,
+		id)
+	if err != nil {
+		return err
+	}
+	res, err := qres.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// bundleIsAvailable is the condition for the bundles which can be bought now,
+// the skins of the bundle may be unavailable on their own
+const bundleIsAvailable = `NOT bundle.hidden AND NOT bundle.retired
+	AND (bundle.available_from IS NULL OR bundle.available_from <= now())
+	AND (bundle.available_until IS NULL OR now() < bundle.available_until)`
+
+// GetBundles returns the bundles which can be bought now with their skins,
+// with all set every bundle is returned
+func (pg *Postgres) GetBundles(ctx context.Context, all bool) (*[]models.Bundle, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := &[]models.Bundle{}
+	err = dbo.SelectContext(ctx, bundles, `
+		SELECT bundle_id, bundle_name, description, cost, available_from, available_until, hidden, retired
+		FROM bundle
+		WHERE $1 OR (`+bundleIsAvailable+`)
+		ORDER BY bundle_id`,
+		all)
+	if err != nil {
+		return bundles, err
+	}
+	if len(*bundles) == 0 {
+		return bundles, nil
+	}
+
+	ids := make([]int64, 0, len(*bundles))
+	byID := make(map[uint]*models.Bundle, len(*bundles))
+	for i := range *bundles {
+		b := &(*bundles)[i]
+		b.Skins = []uint{}
+		ids = append(ids, int64(b.ID))
+		byID[b.ID] = b
+	}
+	rows, err := dbo.QueryContext(ctx, `
+		SELECT bs.bundle_id, s.skin_id, s.price FROM bundle_skin bs
+		JOIN (`+selectSkinWithPrice+`) AS s ON s.skin_id = bs.skin_id
+		WHERE bs.bundle_id = ANY($1)
+		ORDER BY bs.bundle_id, s.skin_id`,
+		pq.Array(ids))
+	if err != nil {
+		return bundles, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bundleID, skinID uint
+		var price int
+		err = rows.Scan(&bundleID, &skinID, &price)
+		if err != nil {
+			return bundles, err
+		}
+		b := byID[bundleID]
+		b.Skins = append(b.Skins, skinID)
+		b.FullPrice += price
+	}
+
+	return bundles, rows.Err()
+}
+
+func (pg *Postgres) CreateBundle(ctx context.Context, b *models.Bundle) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO bundle (bundle_name, description, cost, available_from, available_until, hidden, retired)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING bundle_id`,
+		b.Name, b.Description, b.Cost, b.AvailableFrom, b.AvailableUntil, b.Hidden, b.Retired).Scan(&b.ID)
+	if err != nil {
+		return err
+	}
+	for _, skinID := range b.Skins {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO bundle_skin (bundle_id, skin_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING`,
+			b.ID, skinID)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+				return ErrSkinNotFound
+			}
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (pg *Postgres) DeleteBundle(ctx context.Context, id uint) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	qres, err := dbo.ExecContext(ctx, `
+		DELETE FROM bundle
+		WHERE bundle_id = $1`,
+		id)
+	if err != nil {
+		return err
+	}
+	res, err := qres.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// bundleCost is the part of the bundle cost for the skins the user doesn't own yet,
+// rounded up so the skins bought one by one are never cheaper
+func bundleCost(cost, missing, total int) int {
+	if missing >= total || total == 0 {
+		return cost
+	}
+
+	return (cost*missing + total - 1) / total
+}
+
+// BuyBundle gives all skins of the bundle at once for the bundle cost reduced in proportion
+// to the skins the user already owns, it fails if the user owns every skin of the bundle
+func (pg *Postgres) BuyBundle(ctx context.Context, uID, bundleID uint) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var coins int
+	err = tx.QueryRowContext(ctx, `
+		SELECT coins FROM user_profile
+		WHERE user_id = $1
+		FOR UPDATE`,
+		uID).Scan(&coins)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return UserNotFoundError{"id"}
+		}
+		return err
+	}
+
+	var cost, total int
+	err = tx.QueryRowContext(ctx, `
+		SELECT cost, (SELECT COUNT(*) FROM bundle_skin WHERE bundle_skin.bundle_id = bundle.bundle_id)
+		FROM bundle
+		WHERE bundle_id = $1 AND `+bundleIsAvailable,
+		bundleID).Scan(&cost, &total)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	qres, err := tx.ExecContext(ctx, `
+		INSERT INTO user_purchased_skins (user_id, skin_id)
+		SELECT $1, skin_id FROM bundle_skin
+		WHERE bundle_id = $2
+		ON CONFLICT DO NOTHING`,
+		uID, bundleID)
+	if err != nil {
+		return err
+	}
+	res, err := qres.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return ErrAlreadyOwned
+	}
+
+	cost = bundleCost(cost, int(res), total)
+	if cost != 0 {
+		if coins < cost {
+			return ErrInsufficientCoins
+		}
+		err = TxChangeUserCoinAmount(ctx, tx, uID, -cost,
+			models.CoinReasonBundlePurchase, strconv.FormatUint(uint64(bundleID), 10))
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == "nonnegative_coins" {
+				return ErrInsufficientCoins
+			}
+			return err
+		}
+	}
+
+	_, err = TxUnlockAchievements(ctx, tx, uID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
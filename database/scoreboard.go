@@ -1,31 +1,245 @@
 package database
 
 import (
-	"api/models"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
 
-	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+	"api/models"
 )
 
-func GetUserPositionsDescendingPaginated(dm *db.DatabaseManager, limit, page uint64) (
+// FoldScoreboardRecords adds the changes of the counts of the players per record made
+// by the triggers to scoreboard_record, the counters are locked in the order of the records
+// so the instances of the service folding together don't deadlock
+func (pg *Postgres) FoldScoreboardRecords(ctx context.Context) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		WITH folded AS (
+			DELETE FROM scoreboard_record_delta
+			RETURNING record, players
+		)
+		INSERT INTO scoreboard_record (record, players)
+		SELECT record, SUM(players) FROM folded
+		GROUP BY record
+		ORDER BY record
+		ON CONFLICT (record) DO UPDATE SET players = scoreboard_record.players + EXCLUDED.players`)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM scoreboard_record
+		WHERE players = 0`)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// rankPositions sets ranks of the positions ordered by record DESC, user_id DESC,
+// only the players above the first one are counted, it costs the same on any page
+func rankPositions(ctx context.Context, dbo *sqlx.DB, positions []models.Position) error {
+	if len(positions) == 0 {
+		return nil
+	}
+	first := positions[0]
+	var above, distinctAbove, sameAsFirst int
+	err := dbo.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(players) FILTER (WHERE record > $1), 0),
+			COUNT(*) FILTER (WHERE record > $1),
+			COALESCE(SUM(players) FILTER (WHERE record = $1), 0)
+		FROM scoreboard_players
+		WHERE record >= $1`,
+		first.Points).Scan(&above, &distinctAbove, &sameAsFirst)
+	if err != nil {
+		return err
+	}
+
+	// every record between the first and the current one is fully on the page
+	rank, denseRank, passed := above+1, distinctAbove+1, 0
+	for i := range positions {
+		if i > 0 && positions[i].Points != positions[i-1].Points {
+			rank = above + sameAsFirst + passed + 1
+			denseRank++
+		}
+		if positions[i].Points != first.Points {
+			passed++
+		}
+		positions[i].Rank = rank
+		positions[i].DenseRank = denseRank
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetUserPositionsDescendingPaginated(ctx context.Context, limit, page uint64) (
 	*[]models.Position, int, error) {
-	total, err := GetCountOfUsers(dm)
+	total, err := pg.GetCountOfUsers(ctx)
 	if err != nil {
 		return nil, total, err
 	}
 
-	// TODO: optimize it
-	dbo, err := dm.DB()
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return nil, total, err
 	}
 
+	// find the record on which the page starts, then skip only its ties
 	records := &[]models.Position{}
-	err = dbo.Select(records, `
-		SELECT user_id, nickname, record FROM user_profile
+	var pageRecord int
+	var above uint64
+	err = dbo.QueryRowContext(ctx, `
+		SELECT record, above FROM (
+			SELECT record, players,
+				COALESCE(SUM(players) OVER (ORDER BY record DESC
+					ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING), 0) AS above
+			FROM scoreboard_players
+		) AS b
+		WHERE above + players > $1
 		ORDER BY record DESC
-		LIMIT $1
-		OFFSET $2`,
-		limit, limit*page)
+		LIMIT 1`,
+		limit*page).Scan(&pageRecord, &above)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return records, total, nil
+		}
+		return records, total, err
+	}
+
+	err = dbo.SelectContext(ctx, records, `
+		SELECT user_id, nickname, record FROM user_profile
+		WHERE record <= $1
+		ORDER BY record DESC, user_id DESC
+		LIMIT $2
+		OFFSET $3`,
+		pageRecord, limit, limit*page-above)
+	if err != nil {
+		return records, total, err
+	}
+
+	return records, total, rankPositions(ctx, dbo, *records)
+}
+
+func (pg *Postgres) GetUserPositionsDescendingAfter(ctx context.Context, limit uint64, after *models.ScoreboardCursor) (
+	*[]models.Position, int, error) {
+	total, err := pg.GetCountOfUsers(ctx)
+	if err != nil {
+		return nil, total, err
+	}
+
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, total, err
+	}
+
+	records := &[]models.Position{}
+	err = dbo.SelectContext(ctx, records, `
+		SELECT user_id, nickname, record FROM user_profile
+		WHERE (record, user_id) < ($1, $2)
+		ORDER BY record DESC, user_id DESC
+		LIMIT $3`,
+		after.Record, after.UserID, limit)
+	if err != nil {
+		return records, total, err
+	}
+
+	return records, total, rankPositions(ctx, dbo, *records)
+}
+
+func (pg *Postgres) GetUserPositionsAround(ctx context.Context, uID uint, k uint64) (*[]models.Position, int, error) {
+	total, err := pg.GetCountOfUsers(ctx)
+	if err != nil {
+		return nil, total, err
+	}
+
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, total, err
+	}
+
+	user := models.Position{}
+	err = dbo.GetContext(ctx, &user, `
+		SELECT user_id, nickname, record FROM user_profile
+		WHERE user_id = $1`,
+		uID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, total, UserNotFoundError{"id"}
+		}
+		return nil, total, err
+	}
+
+	above := []models.Position{}
+	err = dbo.SelectContext(ctx, &above, `
+		SELECT user_id, nickname, record FROM user_profile
+		WHERE (record, user_id) > ($1, $2)
+		ORDER BY record, user_id
+		LIMIT $3`,
+		user.Points, user.ID, k)
+	if err != nil {
+		return nil, total, err
+	}
+	below := []models.Position{}
+	err = dbo.SelectContext(ctx, &below, `
+		SELECT user_id, nickname, record FROM user_profile
+		WHERE (record, user_id) < ($1, $2)
+		ORDER BY record DESC, user_id DESC
+		LIMIT $3`,
+		user.Points, user.ID, k)
+	if err != nil {
+		return nil, total, err
+	}
+
+	records := make([]models.Position, 0, len(above)+1+len(below))
+	for i := len(above) - 1; i >= 0; i-- {
+		records = append(records, above[i])
+	}
+	records = append(records, user)
+	records = append(records, below...)
+
+	return &records, total, rankPositions(ctx, dbo, records)
+}
+
+func (pg *Postgres) GetFriendsPositionsPaginated(ctx context.Context, uID uint, limit, page uint64) (
+	*[]models.Position, int, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := 0
+	err = dbo.GetContext(ctx, &total, `
+		SELECT COUNT(*) + 1 FROM friendship
+		WHERE user_id = $1`,
+		uID)
+	if err != nil {
+		return nil, total, err
+	}
+
+	records := &[]models.Position{}
+	err = dbo.SelectContext(ctx, records, `
+		SELECT user_id, nickname, record,
+			RANK() OVER (ORDER BY record DESC) AS rank,
+			DENSE_RANK() OVER (ORDER BY record DESC) AS dense_rank
+		FROM user_profile
+		WHERE user_id = $1 OR user_id IN (
+			SELECT friend_id FROM friendship
+			WHERE user_id = $1
+		)
+		ORDER BY record DESC, user_id DESC
+		LIMIT $2
+		OFFSET $3`,
+		uID, limit, limit*page)
 	if err != nil {
 		return records, total, err
 	}
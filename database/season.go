@@ -0,0 +1,223 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+
+	"api/models"
+)
+
+func (pg *Postgres) CreateSeason(ctx context.Context, s *models.Season) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	err = dbo.QueryRowContext(ctx, `
+		INSERT INTO season (season_name, starts_at, ends_at)
+		VALUES ($1, $2, $3) RETURNING season_id`,
+		s.Name, s.StartsAt, s.EndsAt).Scan(&s.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return db.ErrUniqueConstraintViolation
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetAllSeasons(ctx context.Context) (*[]models.Season, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	seasons := &[]models.Season{}
+	err = dbo.SelectContext(ctx, seasons, `
+		SELECT season_id, season_name, starts_at, ends_at, archived FROM season
+		ORDER BY starts_at DESC`)
+	if err != nil {
+		return seasons, err
+	}
+
+	return seasons, nil
+}
+
+func (pg *Postgres) GetSeasonByName(ctx context.Context, name string) (*models.Season, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+	res := &models.Season{}
+	err = dbo.GetContext(ctx, res, `
+		SELECT season_id, season_name, starts_at, ends_at, archived FROM season
+		WHERE season_name = $1`,
+		name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, ErrNotFound
+		}
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (pg *Postgres) GetCurrentSeason(ctx context.Context) (*models.Season, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+	res := &models.Season{}
+	err = dbo.GetContext(ctx, res, `
+		SELECT season_id, season_name, starts_at, ends_at, archived FROM season
+		WHERE starts_at <= now() AND now() < ends_at
+		ORDER BY starts_at DESC
+		LIMIT 1`)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, ErrNotFound
+		}
+		return res, err
+	}
+
+	return res, nil
+}
+
+// GetPeriodPositionsPaginated ranks players by their best score in the matches played in [from, to)
+func (pg *Postgres) GetPeriodPositionsPaginated(ctx context.Context, from, to time.Time, limit, page uint64) (
+	*[]models.Position, int, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := 0
+	err = dbo.GetContext(ctx, &total, `
+		SELECT COUNT(DISTINCT user_id) FROM match_participant
+		WHERE played_at >= $1 AND played_at < $2`,
+		from, to)
+	if err != nil {
+		return nil, total, err
+	}
+
+	records := &[]models.Position{}
+	err = dbo.SelectContext(ctx, records, `
+		SELECT user_id, nickname, record,
+			RANK() OVER (ORDER BY record DESC) AS rank,
+			DENSE_RANK() OVER (ORDER BY record DESC) AS dense_rank
+		FROM (
+			SELECT mp.user_id, up.nickname, MAX(mp.score) AS record FROM match_participant mp
+			JOIN user_profile up ON up.user_id = mp.user_id
+			WHERE mp.played_at >= $1 AND mp.played_at < $2
+			GROUP BY mp.user_id, up.nickname
+		) AS best
+		ORDER BY record DESC, user_id DESC
+		LIMIT $3
+		OFFSET $4`,
+		from, to, limit, limit*page)
+	if err != nil {
+		return records, total, err
+	}
+
+	return records, total, nil
+}
+
+func (pg *Postgres) GetSeasonStandingsPaginated(ctx context.Context, seasonID uint, limit, page uint64) (
+	*[]models.Position, int, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := 0
+	err = dbo.GetContext(ctx, &total, `
+		SELECT COUNT(*) FROM season_standing
+		WHERE season_id = $1`,
+		seasonID)
+	if err != nil {
+		return nil, total, err
+	}
+
+	records := &[]models.Position{}
+	err = dbo.SelectContext(ctx, records, `
+		SELECT ss.user_id, up.nickname, ss.record, ss.rank, ss.dense_rank FROM season_standing ss
+		JOIN user_profile up ON up.user_id = ss.user_id
+		WHERE ss.season_id = $1
+		ORDER BY ss.rank, ss.user_id DESC
+		LIMIT $2
+		OFFSET $3`,
+		seasonID, limit, limit*page)
+	if err != nil {
+		return records, total, err
+	}
+
+	return records, total, nil
+}
+
+// ArchiveFinishedSeasons saves the final standings of the ended seasons,
+// several instances of the service may run it at the same time
+func (pg *Postgres) ArchiveFinishedSeasons(ctx context.Context) (int, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	archived := 0
+	for {
+		tx, err := dbo.BeginTx(ctx, nil)
+		if err != nil {
+			return archived, err
+		}
+		var seasonID uint
+		var startsAt, endsAt time.Time
+		err = tx.QueryRowContext(ctx, `
+			SELECT season_id, starts_at, ends_at FROM season
+			WHERE NOT archived AND ends_at <= now()
+			ORDER BY ends_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED`).Scan(&seasonID, &startsAt, &endsAt)
+		if err != nil {
+			_ = tx.Rollback()
+			if err == sql.ErrNoRows {
+				return archived, nil
+			}
+			return archived, err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO season_standing (season_id, user_id, record, rank, dense_rank)
+			SELECT $1, user_id, record,
+				RANK() OVER (ORDER BY record DESC),
+				DENSE_RANK() OVER (ORDER BY record DESC)
+			FROM (
+				SELECT user_id, MAX(score) AS record FROM match_participant
+				WHERE played_at >= $2 AND played_at < $3
+				GROUP BY user_id
+			) AS best`,
+			seasonID, startsAt, endsAt)
+		if err != nil {
+			_ = tx.Rollback()
+			return archived, err
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE season
+			SET archived = true
+			WHERE season_id = $1`,
+			seasonID)
+		if err != nil {
+			_ = tx.Rollback()
+			return archived, err
+		}
+		err = tx.Commit()
+		if err != nil {
+			return archived, err
+		}
+		archived++
+	}
+}
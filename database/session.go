@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+
+	"api/models"
+)
+
+// SaveSession remembers the session of auth-service created for the user and the device
+func (pg *Postgres) SaveSession(ctx context.Context, s *models.SessionInfo) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	_, err = dbo.NamedExecContext(ctx, `
+		INSERT INTO user_session (session_id, user_id, device, user_agent, ip, created_at, last_seen_at)
+		VALUES (:session_id, :user_id, :device, :user_agent, :ip, :created_at, :last_seen_at)
+		ON CONFLICT (session_id) DO UPDATE
+		SET user_id = EXCLUDED.user_id, device = EXCLUDED.device, user_agent = EXCLUDED.user_agent,
+			ip = EXCLUDED.ip, last_seen_at = EXCLUDED.last_seen_at`,
+		s)
+
+	return err
+}
+
+func (pg *Postgres) DeleteSession(ctx context.Context, sID string) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	_, err = dbo.ExecContext(ctx, `
+		DELETE FROM user_session
+		WHERE session_id = $1`,
+		sID)
+
+	return err
+}
+
+// GetUserSessions returns the sessions of the user, the last seen first
+func (pg *Postgres) GetUserSessions(ctx context.Context, uID uint) ([]models.SessionInfo, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+	sessions := []models.SessionInfo{}
+	err = dbo.SelectContext(ctx, &sessions, `
+		SELECT session_id, user_id, device, user_agent, ip, created_at, last_seen_at FROM user_session
+		WHERE user_id = $1
+		ORDER BY last_seen_at DESC, created_at DESC`,
+		uID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (pg *Postgres) GetSessionsCreatedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+	sIDs := []string{}
+	err = dbo.SelectContext(ctx, &sIDs, `
+		SELECT session_id FROM user_session
+		WHERE created_at < $1`,
+		before)
+	if err != nil {
+		return nil, err
+	}
+
+	return sIDs, nil
+}
+
+// TouchSessions saves when the sessions were seen last, it returns the tracked ones
+func (pg *Postgres) TouchSessions(ctx context.Context, sIDs []string, at time.Time) ([]string, error) {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return nil, err
+	}
+	tracked := []string{}
+	err = dbo.SelectContext(ctx, &tracked, `
+		UPDATE user_session
+		SET last_seen_at = GREATEST(last_seen_at, $2)
+		WHERE session_id = ANY($1)
+		RETURNING session_id`,
+		pq.Array(sIDs), at)
+	if err != nil {
+		return nil, err
+	}
+
+	return tracked, nil
+}
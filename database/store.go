@@ -1,23 +1,49 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"strconv"
 
-	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+	"github.com/lib/pq"
 
 	"api/models"
 )
 
-func GetSkin(dm *db.DatabaseManager, id uint) (*models.Skin, error) {
-	dbo, err := dm.DB()
+// skinIsAvailable is the condition for the skins which can be bought now
+const skinIsAvailable = `NOT hidden AND NOT retired
+	AND (available_from IS NULL OR available_from <= now())
+	AND (available_until IS NULL OR now() < available_until)`
+
+// selectSkinWithPrice selects the skins with their price at the moment,
+// the lowest one is taken if several sales overlap
+const selectSkinWithPrice = `
+	SELECT skin.*, COALESCE(sale.price, skin.cost) AS price, sale.ends_at AS sale_ends_at FROM skin
+	LEFT JOIN LATERAL (
+		SELECT GREATEST(0, CASE
+				WHEN percent_off IS NOT NULL THEN skin.cost - skin.cost * percent_off / 100
+				ELSE skin.cost - amount_off
+			END) AS price, ends_at
+		FROM skin_sale
+		WHERE skin_sale.skin_id = skin.skin_id AND starts_at <= now() AND now() < ends_at
+		ORDER BY price, ends_at DESC
+		LIMIT 1
+	) AS sale ON true`
+
+// GetSkin returns the skin if it can be bought now or is owned by the user,
+// with all set every skin is returned
+func (pg *Postgres) GetSkin(ctx context.Context, id, uID uint, all bool) (*models.Skin, error) {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return nil, err
 	}
 	res := &models.Skin{}
-	err = dbo.Get(res, `
-		SELECT * FROM skin
-		WHERE skin_id = $1`,
-		id)
+	err = dbo.GetContext(ctx, res, selectSkinWithPrice+`
+		WHERE skin.skin_id = $1 AND ($2 OR (`+skinIsAvailable+`) OR skin_id IN (
+			SELECT skin_id FROM user_purchased_skins
+			WHERE user_id = $3
+		))`,
+		id, all, uID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return res, ErrNotFound
@@ -28,16 +54,22 @@ func GetSkin(dm *db.DatabaseManager, id uint) (*models.Skin, error) {
 	return res, nil
 }
 
-func GetAllSkins(dm *db.DatabaseManager) (*[]models.Skin, error) {
-	dbo, err := dm.DB()
+// GetAllSkins returns the skins which can be bought now and the ones owned by the user,
+// with all set every skin is returned
+func (pg *Postgres) GetAllSkins(ctx context.Context, uID uint, all bool) (*[]models.Skin, error) {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return nil, err
 	}
 
 	skins := &[]models.Skin{}
-	err = dbo.Select(skins, `
-		SELECT * FROM skin
-		ORDER BY skin_id`)
+	err = dbo.SelectContext(ctx, skins, selectSkinWithPrice+`
+		WHERE $1 OR (`+skinIsAvailable+`) OR skin_id IN (
+			SELECT skin_id FROM user_purchased_skins
+			WHERE user_id = $2
+		)
+		ORDER BY skin.skin_id`,
+		all, uID)
 	if err != nil {
 		return skins, err
 	}
@@ -45,13 +77,85 @@ func GetAllSkins(dm *db.DatabaseManager) (*[]models.Skin, error) {
 	return skins, nil
 }
 
-func GetUserStore(dm *db.DatabaseManager, uID uint) (*models.Store, error) {
-	dbo, err := dm.DB()
+func (pg *Postgres) CreateSkin(ctx context.Context, s *models.Skin) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	err = dbo.QueryRowContext(ctx, `
+		INSERT INTO skin (skin_name, cost, description, rarity, asset_url,
+			available_from, available_until, hidden, retired)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING skin_id`,
+		s.Name, s.Cost, s.Description, s.Rarity, s.AssetURL,
+		s.AvailableFrom, s.AvailableUntil, s.Hidden, s.Retired).Scan(&s.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (pg *Postgres) UpdateSkin(ctx context.Context, s *models.Skin) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	qres, err := dbo.ExecContext(ctx, `
+		UPDATE skin
+		SET skin_name = $2, cost = $3, description = $4, rarity = $5, asset_url = $6,
+			available_from = $7, available_until = $8, hidden = $9, retired = $10
+		WHERE skin_id = $1`,
+		s.ID, s.Name, s.Cost, s.Description, s.Rarity, s.AssetURL,
+		s.AvailableFrom, s.AvailableUntil, s.Hidden, s.Retired)
+	if err != nil {
+		return err
+	}
+	res, err := qres.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return ErrSkinNotFound
+	}
+
+	return nil
+}
+
+// DeleteSkin deletes the skin nobody has bought or equipped, such skins can only be retired
+func (pg *Postgres) DeleteSkin(ctx context.Context, id uint) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	qres, err := dbo.ExecContext(ctx, `
+		DELETE FROM skin
+		WHERE skin_id = $1`,
+		id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return ErrSkinInUse
+		}
+		return err
+	}
+	res, err := qres.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return ErrSkinNotFound
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetUserStore(ctx context.Context, uID uint) (*models.Store, error) {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return nil, err
 	}
 	res := &models.Store{}
-	err = dbo.Get(res, `
+	err = dbo.GetContext(ctx, res, `
 		SELECT coins, skin FROM user_profile
 		WHERE user_id = $1`,
 		uID)
@@ -62,7 +166,7 @@ func GetUserStore(dm *db.DatabaseManager, uID uint) (*models.Store, error) {
 		return res, err
 	}
 
-	purchased, err := GetBoughtSkins(dm, uID)
+	purchased, err := pg.GetBoughtSkins(ctx, uID)
 	if err != nil {
 		return res, err
 	}
@@ -71,13 +175,13 @@ func GetUserStore(dm *db.DatabaseManager, uID uint) (*models.Store, error) {
 	return res, nil
 }
 
-func GetBoughtSkins(dm *db.DatabaseManager, uID uint) (*[]uint, error) {
-	dbo, err := dm.DB()
+func (pg *Postgres) GetBoughtSkins(ctx context.Context, uID uint) (*[]uint, error) {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return nil, err
 	}
 	res := new([]uint)
-	err = dbo.Select(res, `
+	err = dbo.SelectContext(ctx, res, `
 		SELECT skin_id FROM user_purchased_skins
 		WHERE user_id = $1`,
 		uID)
@@ -88,30 +192,47 @@ func GetBoughtSkins(dm *db.DatabaseManager, uID uint) (*[]uint, error) {
 	return res, nil
 }
 
-func ChangeUserCoinAmount(dm *db.DatabaseManager, uID uint, sum int) error {
-	dbo, err := dm.DB()
+func (pg *Postgres) ChangeUserCoinAmount(ctx context.Context, uID uint, sum int, reason, reference string) error {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return err
 	}
-	_, err = dbo.Exec(`
-		UPDATE user_profile
-		SET coins = coins + $1
-		WHERE user_id = $2`,
-		sum, uID,
-	)
+	tx, err := dbo.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer func() { _ = tx.Rollback() }()
 
-	return nil
+	err = TxChangeUserCoinAmount(ctx, tx, uID, sum, reason, reference)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func TxChangeUserCoinAmount(tx *sql.Tx, uID uint, sum int) error {
-	_, err := tx.Exec(`
+// TxChangeUserCoinAmount changes the coins of the user and records it in the ledger,
+// reference is the ID of the skin, match, etc. the coins were changed for
+func TxChangeUserCoinAmount(ctx context.Context, tx *sql.Tx, uID uint, sum int, reason, reference string) error {
+	var balance int
+	err := tx.QueryRowContext(ctx, `
 		UPDATE user_profile
 		SET coins = coins + $1
-		WHERE user_id = $2`,
+		WHERE user_id = $2
+		RETURNING coins`,
 		sum, uID,
+	).Scan(&balance)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return UserNotFoundError{"id"}
+		}
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO coin_transaction (user_id, amount, reason, reference, balance)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)`,
+		uID, sum, reason, reference, balance,
 	)
 	if err != nil {
 		return err
@@ -120,49 +241,116 @@ func TxChangeUserCoinAmount(tx *sql.Tx, uID uint, sum int) error {
 	return nil
 }
 
-func BuySkin(dm *db.DatabaseManager, uID uint, skin *models.Skin) error {
-	dbo, err := dm.DB()
+// BuySkin buys the skin in one transaction, the user is locked
+// so concurrent purchases can't spend the same coins twice
+func (pg *Postgres) BuySkin(ctx context.Context, uID, skinID uint) error {
+	dbo, err := pg.dm.DB()
 	if err != nil {
 		return err
 	}
-	tx, err := dbo.Begin()
+	tx, err := dbo.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	_, err = tx.Exec(`
+	var coins int
+	err = tx.QueryRowContext(ctx, `
+		SELECT coins FROM user_profile
+		WHERE user_id = $1
+		FOR UPDATE`,
+		uID).Scan(&coins)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return UserNotFoundError{"id"}
+		}
+		return err
+	}
+
+	// the owned skin is bought already even if it can't be bought anymore
+	var owned bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM user_purchased_skins WHERE user_id = $1 AND skin_id = $2)`,
+		uID, skinID).Scan(&owned)
+	if err != nil {
+		return err
+	}
+	if owned {
+		return ErrAlreadyOwned
+	}
+
+	skin := &models.Skin{}
+	err = tx.QueryRowContext(ctx, `
+		SELECT skin_id, skin_name, price FROM (`+selectSkinWithPrice+`
+			WHERE skin.skin_id = $1 AND `+skinIsAvailable+`
+		) AS s`,
+		skinID).Scan(&skin.ID, &skin.Name, &skin.Price)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrSkinNotFound
+		}
+		return err
+	}
+
+	qres, err := tx.ExecContext(ctx, `
 		INSERT INTO user_purchased_skins (user_id, skin_id)
-		VALUES ($1, $2)`,
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`,
 		uID, skin.ID,
 	)
 	if err != nil {
 		return err
 	}
+	res, err := qres.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return ErrAlreadyOwned
+	}
 
-	if skin.Cost != 0 {
-		err = TxChangeUserCoinAmount(tx, uID, -skin.Cost)
+	// the price at the moment of the purchase
+	if skin.Price != 0 {
+		if coins < skin.Price {
+			return ErrInsufficientCoins
+		}
+		err = TxChangeUserCoinAmount(ctx, tx, uID, -skin.Price,
+			models.CoinReasonSkinPurchase, strconv.FormatUint(uint64(skin.ID), 10))
 		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == "nonnegative_coins" {
+				return ErrInsufficientCoins
+			}
 			return err
 		}
 	}
 
+	_, err = TxUnlockAchievements(ctx, tx, uID)
+	if err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
-func ChangeSkin(dm *db.DatabaseManager, uID, skin uint) error {
-	dbo, err := dm.DB()
+func (pg *Postgres) ChangeSkin(ctx context.Context, uID, skin uint) error {
+	dbo, err := pg.dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer func() { _ = tx.Rollback() }()
+
 	if skin != 0 {
-		_, err = dbo.Exec(`
+		_, err = tx.ExecContext(ctx, `
 			UPDATE user_profile
 			SET skin = $1
 			WHERE user_id = $2`,
 			skin, uID)
 	} else { // equip default skin
-		_, err = dbo.Exec(`
+		_, err = tx.ExecContext(ctx, `
 			UPDATE user_profile
 			SET skin = NULL
 			WHERE user_id = $1`,
@@ -172,5 +360,10 @@ func ChangeSkin(dm *db.DatabaseManager, uID, skin uint) error {
 		return err
 	}
 
-	return nil
+	_, err = TxUnlockAchievements(ctx, tx, uID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
@@ -1,6 +1,6 @@
 // GENERATED BY THE COMMAND ABOVE; DO NOT EDIT
 // This file was generated by swaggo/swag at
-// 2018-12-21 01:03:22.611769 +0300 MSK m=+0.086706702
+// 2026-10-18 08:39:03.389828644 +0000 UTC m=+0.136714587
 
 package docs
 
@@ -26,75 +26,62 @@ var doc = `{
     },
     "basePath": "/api",
     "paths": {
-        "/profile": {
+        "/admin/bundles": {
             "get": {
-                "description": "Получить профиль пользователя по ID, никнейму или из сессии",
+                "description": "Получить все наборы скинов, включая скрытые, снятые с продажи и недоступные сейчас. Только для администраторов",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить профиль",
-                "operationId": "get-profile",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Никнейм",
-                        "name": "nickname",
-                        "in": "query"
-                    }
-                ],
+                "summary": "Получить все наборы",
+                "operationId": "get-admin-bundles",
                 "responses": {
                     "200": {
-                        "description": "Пользователь найден, успешно",
+                        "description": "Наборы",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/models.Profile"
+                            "$ref": "#/definitions/models.BundleList"
                         }
                     },
-                    "400": {
-                        "description": "Неправильный запрос"
-                    },
                     "401": {
                         "description": "Не залогинен"
                     },
-                    "404": {
-                        "description": "Не найдено"
+                    "403": {
+                        "description": "Не администратор"
                     },
                     "500": {
                         "description": "Ошибка в бд"
                     }
                 }
             },
-            "put": {
-                "description": "Изменить профиль, должен быть залогинен",
+            "post": {
+                "description": "Добавить набор скинов с одной ценой за все скины. Только для администраторов",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Изменить профиль",
-                "operationId": "put-profile",
+                "summary": "Добавить набор",
+                "operationId": "post-admin-bundle",
                 "parameters": [
                     {
-                        "description": "Новые никнейм, и/или почта, и/или пароль",
-                        "name": "Profile",
+                        "description": "Набор",
+                        "name": "Bundle",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/models.RegisterProfile"
+                            "$ref": "#/definitions/models.Bundle"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Пользователь найден, успешно изменены данные"
+                    "201": {
+                        "description": "Набор добавлен",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.Bundle"
+                        }
                     },
                     "400": {
                         "description": "Неверный формат JSON"
@@ -103,55 +90,102 @@ var doc = `{
                         "description": "Не залогинен"
                     },
                     "403": {
-                        "description": "Ошибки при регистрации: невалидна или занята почта, занят ник, пароль не удовлетворяет правилам безопасности, другие ошибки",
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/models.ProfileErrorList"
-                        }
+                        "description": "Не администратор"
+                    },
+                    "404": {
+                        "description": "Скин не найден"
+                    },
+                    "422": {
+                        "description": "Невалидный набор"
                     },
                     "500": {
                         "description": "Ошибка в бд"
                     }
                 }
             },
+            "delete": {
+                "description": "Удалить набор скинов, купленные скины остаются у игроков. Только для администраторов",
+                "summary": "Удалить набор",
+                "operationId": "delete-admin-bundle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Набор удален"
+                    },
+                    "400": {
+                        "description": "Неправильный запрос"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "403": {
+                        "description": "Не администратор"
+                    },
+                    "404": {
+                        "description": "Набор не найден"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        },
+        "/admin/promo": {
             "post": {
-                "description": "Зарегистрировать по никнейму, почте и паролю и автоматически залогинить",
+                "description": "Создать промокод с заданным текстом или набор случайных уникальных промокодов с наградой: скин или монеты. Только для администраторов",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Зарегистрироваться и залогиниться по новому профилю",
-                "operationId": "post-profile",
+                "summary": "Создать промокоды",
+                "operationId": "post-admin-promo",
                 "parameters": [
                     {
-                        "description": "Никнейм, почта и пароль",
-                        "name": "Profile",
+                        "description": "Награда, лимит активаций, срок действия и промокод или количество и префикс",
+                        "name": "PromoBatch",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/models.RegisterProfile"
+                            "$ref": "#/definitions/models.PromoBatch"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Пользователь зарегистрирован и залогинен успешно"
+                    "201": {
+                        "description": "Промокоды созданы",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.PromoCodeList"
+                        }
                     },
                     "400": {
                         "description": "Неверный формат JSON"
                     },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
                     "403": {
-                        "description": "Ошибки при регистрации: невалидна или занята почта, занят ник, пароль не удовлетворяет правилам безопасности, другие ошибки",
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/models.ProfileErrorList"
-                        }
+                        "description": "Не администратор"
+                    },
+                    "404": {
+                        "description": "Скин не найден"
+                    },
+                    "409": {
+                        "description": "Промокод уже существует"
                     },
                     "422": {
-                        "description": "При регистрации не все параметры"
+                        "description": "Невалидные параметры"
                     },
                     "500": {
                         "description": "Ошибка в бд"
@@ -159,42 +193,111 @@ var doc = `{
                 }
             }
         },
-        "/profile/avatar": {
-            "put": {
-                "description": "Загрузить или изменить уже существующий аватар",
-                "consumes": [
-                    "multipart/form-data"
+        "/admin/sales": {
+            "get": {
+                "description": "Получить все распродажи скинов, сначала новые. Только для администраторов",
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Изменить аватар",
-                "operationId": "put-avatar",
+                "summary": "Получить распродажи",
+                "operationId": "get-admin-sales",
                 "responses": {
                     "200": {
-                        "description": "Удалена аватарка у пользователя"
+                        "description": "Распродажи",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.SaleList"
+                        }
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "403": {
+                        "description": "Не администратор"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "post": {
+                "description": "Запланировать скидку на скин в процентах или в монетах. Если распродажи пересекаются, действует наибольшая скидка. Только для администраторов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Запланировать распродажу",
+                "operationId": "post-admin-sale",
+                "parameters": [
+                    {
+                        "description": "Распродажа",
+                        "name": "Sale",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.Sale"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Распродажа создана",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.Sale"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
                     },
                     "401": {
                         "description": "Не залогинен"
                     },
+                    "403": {
+                        "description": "Не администратор"
+                    },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Скин не найден"
+                    },
+                    "422": {
+                        "description": "Невалидная распродажа"
                     },
                     "500": {
-                        "description": "Ошибка при парсинге, в бд, файловой системе"
+                        "description": "Ошибка в бд"
                     }
                 }
             },
             "delete": {
-                "description": "Удалить аватар, пользователь должен быть залогинен",
-                "summary": "Удалить аватар",
-                "operationId": "delete-avatar",
+                "description": "Удалить распродажу. Только для администраторов",
+                "summary": "Отменить распродажу",
+                "operationId": "delete-admin-sale",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Удалена аватарка у пользователя"
+                        "description": "Распродажа удалена"
+                    },
+                    "400": {
+                        "description": "Неправильный запрос"
                     },
                     "401": {
                         "description": "Не залогинен"
                     },
+                    "403": {
+                        "description": "Не администратор"
+                    },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Распродажа не найдена"
                     },
                     "500": {
                         "description": "Ошибка в бд"
@@ -202,35 +305,27 @@ var doc = `{
                 }
             }
         },
-        "/profile/skin": {
+        "/admin/skins": {
             "get": {
-                "description": "Получить информацию о скине: ID, название и стоимость",
+                "description": "Получить все скины, включая скрытые, снятые с продажи и недоступные сейчас. Только для администраторов",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить информацию об одном скине или обо всех",
-                "operationId": "get-skin",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "query"
-                    }
-                ],
+                "summary": "Получить все скины",
+                "operationId": "get-admin-skins",
                 "responses": {
                     "200": {
-                        "description": "Скин/скины найдены",
+                        "description": "Все скины",
                         "schema": {
                             "type": "object",
                             "$ref": "#/definitions/models.AllSkins"
                         }
                     },
-                    "400": {
-                        "description": "Неправильный запрос"
+                    "401": {
+                        "description": "Не залогинен"
                     },
-                    "404": {
-                        "description": "Не найдено"
+                    "403": {
+                        "description": "Не администратор"
                     },
                     "500": {
                         "description": "Ошибка в бд"
@@ -238,36 +333,42 @@ var doc = `{
                 }
             },
             "put": {
-                "description": "Изменить скин, должен быть куплен",
+                "description": "Изменить все поля скина по ID. Снятые с продажи скины остаются у владельцев. Только для администраторов",
                 "consumes": [
                     "application/json"
                 ],
                 "summary": "Изменить скин",
-                "operationId": "put-skin",
+                "operationId": "put-admin-skin",
                 "parameters": [
                     {
-                        "description": "Скин, который надеваем",
-                        "name": "Profile",
+                        "description": "Скин",
+                        "name": "Skin",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/models.RequestSkin"
+                            "$ref": "#/definitions/models.Skin"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Пользователь найден, успешно надет скин, уже надет такой скин"
+                        "description": "Скин изменен"
                     },
                     "400": {
                         "description": "Неверный формат JSON"
                     },
                     "401": {
-                        "description": "Не залогинен, пользователь не существует"
+                        "description": "Не залогинен"
+                    },
+                    "403": {
+                        "description": "Не администратор"
+                    },
+                    "404": {
+                        "description": "Скин не найден"
                     },
                     "422": {
-                        "description": "Скин не куплен"
+                        "description": "Невалидный скин"
                     },
                     "500": {
                         "description": "Ошибка в бд"
@@ -275,39 +376,83 @@ var doc = `{
                 }
             },
             "post": {
-                "description": "Купить новый скин, монет должно быть достаточно для совершения покупки",
+                "description": "Добавить скин в каталог. Только для администраторов",
                 "consumes": [
                     "application/json"
                 ],
-                "summary": "Купить новый скин",
-                "operationId": "post-skin",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавить скин",
+                "operationId": "post-admin-skin",
                 "parameters": [
                     {
-                        "description": "Скин для покупки",
-                        "name": "Profile",
+                        "description": "Скин",
+                        "name": "Skin",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/models.RequestSkin"
+                            "$ref": "#/definitions/models.Skin"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Скин куплен (или уже есть)"
+                    "201": {
+                        "description": "Скин добавлен",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.Skin"
+                        }
                     },
                     "400": {
                         "description": "Неверный формат JSON"
                     },
                     "401": {
-                        "description": "Не залогинен, профиль не существует"
+                        "description": "Не залогинен"
+                    },
+                    "403": {
+                        "description": "Не администратор"
+                    },
+                    "422": {
+                        "description": "Невалидный скин"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удалить скин, который никто не купил. Купленные скины можно только снять с продажи. Только для администраторов",
+                "summary": "Удалить скин",
+                "operationId": "delete-admin-skin",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Скин удален"
+                    },
+                    "400": {
+                        "description": "Неправильный запрос"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "403": {
+                        "description": "Не администратор"
                     },
                     "404": {
                         "description": "Скин не найден"
                     },
-                    "422": {
-                        "description": "Недостаточно средств"
+                    "409": {
+                        "description": "Скин куплен игроками"
                     },
                     "500": {
                         "description": "Ошибка в бд"
@@ -315,35 +460,100 @@ var doc = `{
                 }
             }
         },
-        "/scoreboard": {
+        "/coins/check": {
             "get": {
-                "description": "Получить таблицу лидеров (пагинация присутствует)",
+                "description": "Пересчитать балансы по журналу операций и найти расхождения с монетами игроков. Только для сервисов",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить таблицу лидеров",
-                "operationId": "get-scoreboard",
+                "summary": "Проверить журнал монет",
+                "operationId": "get-coins-check",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Пользователей на страницу",
-                        "name": "Limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Bearer \u003cтокен сервиса\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Количество проверенных игроков и расхождения",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.CoinLedgerReport"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "Страница номер",
-                        "name": "Page",
-                        "in": "query"
+                    "401": {
+                        "description": "Неверный токен сервиса"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
                     }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "produces": [
+                    "application/json"
                 ],
+                "summary": "Процесс жив",
+                "operationId": "get-healthz",
                 "responses": {
                     "200": {
-                        "description": "Таблицу лидеров или ее страница и общее количество",
+                        "description": "Жив",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/models.PositionList"
+                            "$ref": "#/definitions/models.Health"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches": {
+            "post": {
+                "description": "Сохранить результат завершенного матча: победы/ничьи/поражения, рекорд и монеты игроков. Только для игрового сервера. Повторная отправка матча с тем же ID ничего не меняет",
+                "consumes": [
+                    "application/json"
+                ],
+                "summary": "Сохранить результат матча",
+                "operationId": "post-match",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003cтокен сервиса\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Результат матча",
+                        "name": "MatchResult",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.MatchResult"
                         }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат сохранен (или уже был сохранен)"
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
+                    },
+                    "401": {
+                        "description": "Неверный токен сервиса"
+                    },
+                    "404": {
+                        "description": "Игрок не найден"
+                    },
+                    "422": {
+                        "description": "Невалидный результат матча"
                     },
                     "500": {
                         "description": "Ошибка в бд"
@@ -351,130 +561,1855 @@ var doc = `{
                 }
             }
         },
-        "/session": {
+        "/profile": {
             "get": {
-                "description": "Получить сессию пользователя, если есть сессия, то она в куке session_id",
+                "description": "Получить профиль пользователя по ID, никнейму или из сессии",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить сессию",
-                "operationId": "get-session",
+                "summary": "Получить профиль",
+                "operationId": "get-profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Никнейм",
+                        "name": "nickname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сколько последних матчей добавить в профиль по ID или никнейму",
+                        "name": "matches",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Пользователь залогинен, успешно",
+                        "description": "Пользователь найден, успешно",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/models.Session"
+                            "$ref": "#/definitions/models.Profile"
                         }
                     },
+                    "400": {
+                        "description": "Неправильный запрос"
+                    },
                     "401": {
                         "description": "Не залогинен"
                     },
+                    "404": {
+                        "description": "Не найдено"
+                    },
                     "500": {
                         "description": "Ошибка в бд"
                     }
                 }
             },
-            "post": {
-                "description": "Залогинить пользователя (создать сессию)",
+            "put": {
+                "description": "Изменить профиль, должен быть залогинен. Для смены почты или пароля нужен текущий пароль. Новую почту нужно подтвердить, письмо отправляется на нее. После смены пароля все остальные сессии завершаются",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Залогинить",
-                "operationId": "post-session",
+                "summary": "Изменить профиль",
+                "operationId": "put-profile",
                 "parameters": [
                     {
-                        "description": "Почта и пароль",
-                        "name": "UserPassword",
+                        "description": "Новые никнейм, и/или почта, и/или пароль, текущий пароль",
+                        "name": "Profile",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/models.UserPassword"
+                            "$ref": "#/definitions/models.ProfileUpdate"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешный вход / пользователь уже залогинен",
+                        "description": "Пользователь найден, успешно изменены данные"
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "403": {
+                        "description": "Ошибки при регистрации: невалидна или занята почта, занят ник, пароль не удовлетворяет правилам безопасности, неверный текущий пароль, другие ошибки",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/models.Session"
+                            "$ref": "#/definitions/models.ProfileErrorList"
                         }
                     },
-                    "400": {
-                        "description": "Неверный формат JSON, невалидные данные"
-                    },
-                    "422": {
-                        "description": "Неверная пара пользователь/пароль"
+                    "429": {
+                        "description": "Слишком много неверных паролей для пользователя или адреса, в Retry-After сколько секунд ждать"
                     },
                     "500": {
-                        "description": "Внутренняя ошибка"
+                        "description": "Ошибка в бд"
                     }
                 }
             },
-            "delete": {
-                "summary": "Разлогинить",
-                "operationId": "delete-session",
+            "post": {
+                "description": "Зарегистрировать по никнейму, почте и паролю и автоматически залогинить",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Зарегистрироваться и залогиниться по новому профилю",
+                "operationId": "post-profile",
+                "parameters": [
+                    {
+                        "description": "Никнейм, почта и пароль",
+                        "name": "Profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.RegisterProfile"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Успешный выход / пользователь уже разлогинен"
+                        "description": "Пользователь зарегистрирован и залогинен успешно"
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
+                    },
+                    "403": {
+                        "description": "Ошибки при регистрации: невалидна или занята почта, занят ник, пароль не удовлетворяет правилам безопасности, другие ошибки",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.ProfileErrorList"
+                        }
+                    },
+                    "422": {
+                        "description": "При регистрации не все параметры"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
                     }
                 }
             }
         },
-        "/static/{path/to/file}": {
+        "/profile/achievements": {
             "get": {
-                "description": "Отдать файл с диска",
-                "summary": "Отдать файл",
-                "operationId": "get-static",
+                "description": "Получить все достижения игрока по ID или из сессии, у полученных есть время получения",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить достижения",
+                "operationId": "get-profile-achievements",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Путь к файлу",
-                        "name": "PathToFile",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Файл найден"
+                        "description": "Достижения, сначала полученные",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.AchievementList"
+                        }
                     },
-                    "301": {
-                        "description": "Редирект, если имя папки не заканчивается на /"
+                    "400": {
+                        "description": "Неправильный запрос"
                     },
-                    "403": {
-                        "description": "Нет прав (сервер)"
+                    "401": {
+                        "description": "Не залогинен"
                     },
                     "404": {
-                        "description": "Файл не найден"
+                        "description": "Игрок не найден"
                     },
                     "500": {
-                        "description": "Внутренняя ошибка"
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        },
+        "/profile/avatar": {
+            "put": {
+                "description": "Загрузить или изменить уже существующий аватар",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "summary": "Изменить аватар",
+                "operationId": "put-avatar",
+                "responses": {
+                    "200": {
+                        "description": "Удалена аватарка у пользователя"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "500": {
+                        "description": "Ошибка при парсинге, в бд, файловой системе"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удалить аватар, пользователь должен быть залогинен",
+                "summary": "Удалить аватар",
+                "operationId": "delete-avatar",
+                "responses": {
+                    "200": {
+                        "description": "Удалена аватарка у пользователя"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.AllSkins": {
+        },
+        "/profile/coins/history": {
+            "get": {
+                "description": "Получить начисления и списания монет, сначала новые (пагинация по курсору)",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить историю монет",
+                "operationId": "get-profile-coins-history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Операций на страницу",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Курсор: поле next из предыдущей страницы",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Операции и курсор следующей страницы",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.CoinHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Неправильный запрос"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        },
+        "/profile/daily": {
+            "post": {
+                "description": "Получить монеты за вход сегодня (по UTC). Награда растет с каждым днем подряд и сбрасывается, если день пропущен. Также выдается при первом запросе за день",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить ежедневную награду",
+                "operationId": "post-profile-daily",
+                "responses": {
+                    "200": {
+                        "description": "Награда получена",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.DailyReward"
+                        }
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "409": {
+                        "description": "Награда за сегодня уже получена"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        },
+        "/profile/email/verify": {
+            "put": {
+                "description": "Подтвердить почту по токену из письма, залогиниться не нужно",
+                "consumes": [
+                    "application/json"
+                ],
+                "summary": "Подтвердить почту",
+                "operationId": "put-profile-email-verify",
+                "parameters": [
+                    {
+                        "description": "Токен из ссылки",
+                        "name": "Token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.EmailTokenConfirmation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Почта подтверждена"
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
+                    },
+                    "422": {
+                        "description": "Токен недействителен, истек или уже использован"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "post": {
+                "description": "Отправить на почту пользователя ссылку для подтверждения, не больше 3 писем в час",
+                "summary": "Отправить письмо для подтверждения почты",
+                "operationId": "post-profile-email-verify",
+                "responses": {
+                    "200": {
+                        "description": "Письмо отправлено"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "409": {
+                        "description": "Почта уже подтверждена"
+                    },
+                    "429": {
+                        "description": "Слишком много писем"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд или при отправке письма"
+                    }
+                }
+            }
+        },
+        "/profile/friends": {
+            "get": {
+                "description": "Получить друзей, входящие и исходящие заявки в друзья и заблокированных пользователей",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить друзей",
+                "operationId": "get-friends",
+                "responses": {
+                    "200": {
+                        "description": "Друзья и заявки",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.FriendList"
+                        }
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "put": {
+                "description": "Принять или отклонить заявку в друзья, заблокировать или разблокировать пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "summary": "Ответить на заявку или заблокировать",
+                "operationId": "put-friend",
+                "parameters": [
+                    {
+                        "description": "ID или никнейм пользователя и действие: accept, decline, block, unblock",
+                        "name": "FriendAction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.FriendAction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешно"
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON, неизвестное действие"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "404": {
+                        "description": "Пользователь или заявка не найдены"
+                    },
+                    "422": {
+                        "description": "Действие над собой"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "post": {
+                "description": "Отправить заявку в друзья по ID или никнейму. Если пользователь уже отправил заявку, то становимся друзьями",
+                "consumes": [
+                    "application/json"
+                ],
+                "summary": "Отправить заявку в друзья",
+                "operationId": "post-friend",
+                "parameters": [
+                    {
+                        "description": "ID или никнейм пользователя",
+                        "name": "FriendAction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.FriendAction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заявка отправлена (или уже друзья)"
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "403": {
+                        "description": "Пользователь заблокирован"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "422": {
+                        "description": "Нельзя добавить в друзья себя"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удалить из друзей или отменить отправленную заявку",
+                "summary": "Удалить из друзей",
+                "operationId": "delete-friend",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Удален из друзей"
+                    },
+                    "400": {
+                        "description": "Неправильный запрос"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "404": {
+                        "description": "Не друзья и нет заявки"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        },
+        "/profile/gifts": {
+            "get": {
+                "description": "Получить ожидающие подарки для игрока и последние отправленные им подарки",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить подарки",
+                "operationId": "get-gifts",
+                "responses": {
+                    "200": {
+                        "description": "Подарки",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.GiftList"
+                        }
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "put": {
+                "description": "Принять подарок или отклонить его, тогда монеты вернутся отправителю. Если скин уже есть, подарок отклоняется",
+                "consumes": [
+                    "application/json"
+                ],
+                "summary": "Принять или отклонить подарок",
+                "operationId": "put-gift",
+                "parameters": [
+                    {
+                        "description": "ID подарка и действие: accept, decline",
+                        "name": "GiftAction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.GiftAction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешно"
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON, неизвестное действие"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "404": {
+                        "description": "Подарок не найден"
+                    },
+                    "409": {
+                        "description": "Скин уже есть, подарок отклонен"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "post": {
+                "description": "Купить скин другому игроку или передать ему монеты по ID или никнейму. Монеты списываются сразу и возвращаются, если подарок отклонен. Количество подарков и монет в день ограничено",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Подарить скин или монеты",
+                "operationId": "post-gift",
+                "parameters": [
+                    {
+                        "description": "Получатель, скин или монеты и сообщение",
+                        "name": "SendGift",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.SendGift"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Подарок отправлен",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.Gift"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "403": {
+                        "description": "Пользователь заблокирован"
+                    },
+                    "404": {
+                        "description": "Пользователь или скин не найден"
+                    },
+                    "409": {
+                        "description": "У получателя уже есть этот скин"
+                    },
+                    "422": {
+                        "description": "Невалидный подарок, недостаточно средств"
+                    },
+                    "429": {
+                        "description": "Превышен дневной лимит подарков"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        },
+        "/profile/matches": {
+            "get": {
+                "description": "Получить историю матчей игрока по ID или из сессии, сначала новые (пагинация по курсору)",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить историю матчей",
+                "operationId": "get-profile-matches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Матчей на страницу",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор: поле next из предыдущей страницы",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Матчи и курсор следующей страницы",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.MatchHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Неправильный запрос"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        },
+        "/profile/password/reset": {
+            "put": {
+                "description": "Задать новый пароль по токену из письма, залогиниться не нужно. Почта при этом подтверждается, все сессии пользователя завершаются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Задать новый пароль",
+                "operationId": "put-profile-password-reset",
+                "parameters": [
+                    {
+                        "description": "Токен из ссылки и новый пароль",
+                        "name": "Reset",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.PasswordReset"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пароль изменен"
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
+                    },
+                    "403": {
+                        "description": "Пароль не удовлетворяет правилам безопасности",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.ProfileErrorList"
+                        }
+                    },
+                    "422": {
+                        "description": "Токен недействителен, истек или уже использован"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "post": {
+                "description": "Отправить на почту ссылку для восстановления пароля. Письмо отправляется в фоне, ответ не зависит от того, есть ли пользователь с такой почтой",
+                "consumes": [
+                    "application/json"
+                ],
+                "summary": "Запросить восстановление пароля",
+                "operationId": "post-profile-password-reset",
+                "parameters": [
+                    {
+                        "description": "Почта",
+                        "name": "Email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.PasswordResetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Письмо будет отправлено, если пользователь с такой почтой есть"
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON или невалидная почта"
+                    },
+                    "429": {
+                        "description": "Слишком много запросов для почты или адреса, в Retry-After сколько секунд ждать"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        },
+        "/profile/skin": {
+            "get": {
+                "description": "Получить информацию о скине: ID, название, стоимость, цену со скидкой и конец распродажи, редкость и доступность. Возвращаются скины, которые можно купить сейчас, и купленные игроком",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить информацию об одном скине или обо всех",
+                "operationId": "get-skin",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Скин/скины найдены",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.AllSkins"
+                        }
+                    },
+                    "400": {
+                        "description": "Неправильный запрос"
+                    },
+                    "404": {
+                        "description": "Не найдено"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "put": {
+                "description": "Изменить скин, должен быть куплен",
+                "consumes": [
+                    "application/json"
+                ],
+                "summary": "Изменить скин",
+                "operationId": "put-skin",
+                "parameters": [
+                    {
+                        "description": "Скин, который надеваем",
+                        "name": "Profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.RequestSkin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пользователь найден, успешно надет скин, уже надет такой скин"
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
+                    },
+                    "401": {
+                        "description": "Не залогинен, пользователь не существует"
+                    },
+                    "422": {
+                        "description": "Скин не куплен"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "post": {
+                "description": "Купить новый скин по цене с текущей скидкой, монет должно быть достаточно для совершения покупки",
+                "consumes": [
+                    "application/json"
+                ],
+                "summary": "Купить новый скин",
+                "operationId": "post-skin",
+                "parameters": [
+                    {
+                        "description": "Скин для покупки",
+                        "name": "Profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.RequestSkin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Скин куплен (или уже есть)"
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
+                    },
+                    "401": {
+                        "description": "Не залогинен, профиль не существует"
+                    },
+                    "404": {
+                        "description": "Скин не найден"
+                    },
+                    "422": {
+                        "description": "Недостаточно средств"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Проверить базу данных и сервис авторизации. Результат кешируется на пару секунд",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Готовность принимать запросы",
+                "operationId": "get-readyz",
+                "responses": {
+                    "200": {
+                        "description": "Готов",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.Health"
+                        }
+                    },
+                    "503": {
+                        "description": "Недоступна обязательная зависимость или сервер останавливается",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.Health"
+                        }
+                    }
+                }
+            }
+        },
+        "/scoreboard": {
+            "get": {
+                "description": "Получить таблицу лидеров с местами игроков за все время (пагинация по номеру страницы или по курсору) или за период по очкам в матчах. По номеру страницы доступны первые 10000 мест, дальше только по курсору",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить таблицу лидеров",
+                "operationId": "get-scoreboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Пользователей на страницу",
+                        "name": "Limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Страница номер",
+                        "name": "Page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор \u003crecord,user_id\u003e: поле next из предыдущей страницы",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "me или ID игрока: место игрока и его соседи сверху и снизу",
+                        "name": "around",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество соседей с каждой стороны для around",
+                        "name": "k",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "all (по умолчанию), daily, weekly или season",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Название сезона для period=season, по умолчанию текущий",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "friends: только игрок и его друзья",
+                        "name": "scope",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Таблицу лидеров или ее страница и общее количество",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.PositionList"
+                        }
+                    },
+                    "400": {
+                        "description": "Неправильный запрос или слишком далекая страница"
+                    },
+                    "401": {
+                        "description": "Не залогинен (для around=me и scope=friends)"
+                    },
+                    "404": {
+                        "description": "Игрок или сезон не найден"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        },
+        "/scoreboard/seasons": {
+            "get": {
+                "description": "Получить все сезоны, сначала новые. Таблица лидеров сезона: /scoreboard?period=season\u0026season=\u003cname\u003e",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить список сезонов",
+                "operationId": "get-seasons",
+                "responses": {
+                    "200": {
+                        "description": "Сезоны",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.SeasonList"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "post": {
+                "description": "Создать сезон с названием и датами начала и конца. После конца итоговая таблица сезона сохраняется",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Создать сезон",
+                "operationId": "post-season",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003cтокен сервиса\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Название, начало и конец сезона",
+                        "name": "Season",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.Season"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сезон создан",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.Season"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
+                    },
+                    "401": {
+                        "description": "Неверный токен сервиса"
+                    },
+                    "409": {
+                        "description": "Сезон с таким названием уже есть"
+                    },
+                    "422": {
+                        "description": "Нет названия или конец сезона раньше начала"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        },
+        "/session": {
+            "get": {
+                "description": "Получить сессию пользователя, если есть сессия, то она в куке session_id",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить сессию",
+                "operationId": "get-session",
+                "responses": {
+                    "200": {
+                        "description": "Пользователь залогинен, успешно",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.Session"
+                        }
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "post": {
+                "description": "Залогинить пользователя (создать сессию)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Залогинить",
+                "operationId": "post-session",
+                "parameters": [
+                    {
+                        "description": "Почта и пароль",
+                        "name": "UserPassword",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.UserPassword"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный вход / пользователь уже залогинен",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.Session"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON, невалидные данные"
+                    },
+                    "422": {
+                        "description": "Неверная пара пользователь/пароль"
+                    },
+                    "429": {
+                        "description": "Слишком много неудачных попыток для пользователя или адреса, в Retry-After сколько секунд ждать"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка"
+                    }
+                }
+            },
+            "delete": {
+                "summary": "Разлогинить",
+                "operationId": "delete-session",
+                "responses": {
+                    "200": {
+                        "description": "Успешный выход / пользователь уже разлогинен"
+                    }
+                }
+            }
+        },
+        "/session/all": {
+            "get": {
+                "description": "Получить устройства, на которых залогинен пользователь, сначала недавние. Текущая сессия отмечена",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить все сессии",
+                "operationId": "get-session-all",
+                "responses": {
+                    "200": {
+                        "description": "Успешно",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.SessionList"
+                        }
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд или сервисе авторизации"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Разлогинить пользователя на всех устройствах, с except_current=true кроме текущего",
+                "summary": "Завершить все сессии",
+                "operationId": "delete-session-all",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Оставить текущую сессию",
+                        "name": "except_current",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сессии завершены"
+                    },
+                    "400": {
+                        "description": "Неправильный запрос"
+                    },
+                    "401": {
+                        "description": "Не залогинен"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд или сервисе авторизации"
+                    }
+                }
+            }
+        },
+        "/static/{path/to/file}": {
+            "get": {
+                "description": "Отдать файл с диска",
+                "summary": "Отдать файл",
+                "operationId": "get-static",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Путь к файлу",
+                        "name": "PathToFile",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл найден"
+                    },
+                    "301": {
+                        "description": "Редирект, если имя папки не заканчивается на /"
+                    },
+                    "403": {
+                        "description": "Нет прав (сервер)"
+                    },
+                    "404": {
+                        "description": "Файл не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка"
+                    }
+                }
+            }
+        },
+        "/store/bundles": {
+            "get": {
+                "description": "Получить наборы, которые можно купить сейчас: скины, цену набора и сумму цен скинов по отдельности",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить наборы скинов",
+                "operationId": "get-store-bundles",
+                "responses": {
+                    "200": {
+                        "description": "Наборы",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.BundleList"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            },
+            "post": {
+                "description": "Купить все скины набора за цену набора, монет должно быть достаточно для совершения покупки. Если часть скинов уже куплена, цена уменьшается пропорционально: цена набора * некупленные скины / все скины, с округлением вверх",
+                "consumes": [
+                    "application/json"
+                ],
+                "summary": "Купить набор скинов",
+                "operationId": "post-store-bundle",
+                "parameters": [
+                    {
+                        "description": "Набор для покупки",
+                        "name": "Bundle",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.RequestBundle"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Набор куплен (или все скины уже есть)"
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
+                    },
+                    "401": {
+                        "description": "Не залогинен, профиль не существует"
+                    },
+                    "404": {
+                        "description": "Набор не найден"
+                    },
+                    "422": {
+                        "description": "Недостаточно средств"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        },
+        "/store/redeem": {
+            "post": {
+                "description": "Получить монеты или скин по промокоду, каждый игрок может активировать код один раз",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Активировать промокод",
+                "operationId": "post-store-redeem",
+                "parameters": [
+                    {
+                        "description": "Промокод",
+                        "name": "RedeemCode",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.RedeemCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Награда получена",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.PromoReward"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат JSON"
+                    },
+                    "401": {
+                        "description": "Не залогинен, профиль не существует"
+                    },
+                    "404": {
+                        "description": "Промокод не найден"
+                    },
+                    "409": {
+                        "description": "Промокод уже активирован игроком или скин уже есть"
+                    },
+                    "410": {
+                        "description": "Срок действия истек или промокод закончился"
+                    },
+                    "500": {
+                        "description": "Ошибка в бд"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Achievement": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "win_10"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Win 10 games"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Winner"
+                },
+                "reward": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "unlocked_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AchievementList": {
+            "type": "object",
+            "properties": {
+                "achievements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Achievement"
+                    }
+                }
+            }
+        },
+        "models.AllSkins": {
+            "type": "object",
+            "properties": {
+                "skins": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Skin"
+                    }
+                }
+            }
+        },
+        "models.Bundle": {
+            "type": "object",
+            "properties": {
+                "available_from": {
+                    "type": "string"
+                },
+                "available_until": {
+                    "type": "string"
+                },
+                "cost": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "description": {
+                    "type": "string"
+                },
+                "full_price": {
+                    "description": "sum of the current prices of the skins",
+                    "type": "integer",
+                    "example": 350
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Halloween"
+                },
+                "retired": {
+                    "type": "boolean"
+                },
+                "skins": {
+                    "type": "array",
+                    "items": {
+                        "type": "uint"
+                    }
+                }
+            }
+        },
+        "models.BundleList": {
+            "type": "object",
+            "properties": {
+                "bundles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Bundle"
+                    }
+                }
+            }
+        },
+        "models.CoinDrift": {
+            "type": "object",
+            "properties": {
+                "coins": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "last_balance": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "ledger_sum": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "models.CoinHistory": {
+            "type": "object",
+            "properties": {
+                "next": {
+                    "type": "string",
+                    "example": "1000"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CoinTransaction"
+                    }
+                }
+            }
+        },
+        "models.CoinLedgerReport": {
+            "type": "object",
+            "properties": {
+                "checked_users": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "drifts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CoinDrift"
+                    }
+                }
+            }
+        },
+        "models.CoinTransaction": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": -100
+                },
+                "balance": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "skin_purchase"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "3"
+                }
+            }
+        },
+        "models.DailyReward": {
+            "type": "object",
+            "properties": {
+                "coins": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "streak": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "models.DailyStreak": {
+            "type": "object",
+            "properties": {
+                "claimed_today": {
+                    "type": "boolean"
+                },
+                "next_reward": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "streak": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.DependencyHealth": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "hard": {
+                    "description": "the instance is not ready without the hard dependency",
+                    "type": "boolean",
+                    "example": true
+                },
+                "latency_ms": {
+                    "type": "number",
+                    "example": 1.25
+                },
+                "name": {
+                    "type": "string",
+                    "example": "postgres"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "models.EmailTokenConfirmation": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "Nc9rKj1Uq0S0n8mJ2Tz4bA"
+                }
+            }
+        },
+        "models.Friend": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "nickname": {
+                    "type": "string",
+                    "example": "Nick"
+                },
+                "record": {
+                    "type": "integer",
+                    "example": 100500
+                },
+                "since": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FriendAction": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "accept"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "nickname": {
+                    "type": "string",
+                    "example": "Nick"
+                }
+            }
+        },
+        "models.FriendList": {
+            "type": "object",
+            "properties": {
+                "blocked": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Friend"
+                    }
+                },
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Friend"
+                    }
+                },
+                "incoming": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Friend"
+                    }
+                },
+                "outgoing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Friend"
+                    }
+                }
+            }
+        },
+        "models.Gift": {
+            "type": "object",
+            "properties": {
+                "coins": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "from_nickname": {
+                    "type": "string",
+                    "example": "Nick"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Happy birthday!"
+                },
+                "skin": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "to": {
+                    "type": "integer",
+                    "example": 43
+                },
+                "to_nickname": {
+                    "type": "string",
+                    "example": "Kate"
+                }
+            }
+        },
+        "models.GiftAction": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "accept"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "models.GiftList": {
+            "type": "object",
+            "properties": {
+                "incoming": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Gift"
+                    }
+                },
+                "sent": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Gift"
+                    }
+                }
+            }
+        },
+        "models.Health": {
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DependencyHealth"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "models.MatchHistory": {
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MatchHistoryEntry"
+                    }
+                },
+                "next": {
+                    "type": "string",
+                    "example": "1545343402611769000,3f2a9c0e-game-42"
+                }
+            }
+        },
+        "models.MatchHistoryEntry": {
+            "type": "object",
+            "properties": {
+                "coins": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "match_id": {
+                    "type": "string",
+                    "example": "3f2a9c0e-game-42"
+                },
+                "opponents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Opponent"
+                    }
+                },
+                "played_at": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string",
+                    "example": "win"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 1500
+                }
+            }
+        },
+        "models.MatchResult": {
+            "type": "object",
+            "properties": {
+                "match_id": {
+                    "type": "string",
+                    "example": "3f2a9c0e-game-42"
+                },
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlayerResult"
+                    }
+                }
+            }
+        },
+        "models.Opponent": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 43
+                },
+                "nickname": {
+                    "type": "string",
+                    "example": "Nick"
+                },
+                "result": {
+                    "type": "string",
+                    "example": "loss"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "models.PasswordReset": {
             "type": "object",
             "properties": {
-                "skins": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Skin"
-                    }
+                "password": {
+                    "type": "string",
+                    "example": "password"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "Nc9rKj1Uq0S0n8mJ2Tz4bA"
+                }
+            }
+        },
+        "models.PasswordResetRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "email@email.com"
+                }
+            }
+        },
+        "models.PlayerResult": {
+            "type": "object",
+            "properties": {
+                "coins": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "result": {
+                    "type": "string",
+                    "example": "win"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 1500
                 }
             }
         },
         "models.Position": {
             "type": "object",
             "properties": {
+                "dense_rank": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "id": {
                     "type": "integer",
                     "example": 42
@@ -483,6 +2418,10 @@ var doc = `{
                     "type": "string",
                     "example": "Nick"
                 },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "record": {
                     "type": "integer",
                     "example": 100500
@@ -492,6 +2431,10 @@ var doc = `{
         "models.PositionList": {
             "type": "object",
             "properties": {
+                "next": {
+                    "type": "string",
+                    "example": "100500,42"
+                },
                 "players": {
                     "type": "array",
                     "items": {
@@ -516,6 +2459,10 @@ var doc = `{
                 "current_skin": {
                     "type": "integer"
                 },
+                "daily": {
+                    "type": "object",
+                    "$ref": "#/definitions/models.DailyStreak"
+                },
                 "draws": {
                     "type": "integer"
                 },
@@ -523,9 +2470,18 @@ var doc = `{
                     "type": "string",
                     "example": "email@email.com"
                 },
+                "email_verified": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "last_matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MatchHistoryEntry"
+                    }
+                },
                 "loss": {
                     "type": "integer"
                 },
@@ -575,6 +2531,131 @@ var doc = `{
                 }
             }
         },
+        "models.ProfileUpdate": {
+            "type": "object",
+            "properties": {
+                "current_password": {
+                    "description": "required to change email or password",
+                    "type": "string",
+                    "example": "password"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "email@email.com"
+                },
+                "nickname": {
+                    "type": "string",
+                    "example": "Nick"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password"
+                }
+            }
+        },
+        "models.PromoBatch": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "KETNIPZ2026"
+                },
+                "coins": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_redemptions": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "NY"
+                },
+                "redeemed": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "skin": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "models.PromoCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "KETNIPZ2026"
+                },
+                "coins": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_redemptions": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "redeemed": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "skin": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "models.PromoCodeList": {
+            "type": "object",
+            "properties": {
+                "codes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PromoCode"
+                    }
+                }
+            }
+        },
+        "models.PromoReward": {
+            "type": "object",
+            "properties": {
+                "coins": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "skin": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "models.RedeemCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "KETNIPZ2026"
+                }
+            }
+        },
         "models.RegisterProfile": {
             "type": "object",
             "properties": {
@@ -592,6 +2673,14 @@ var doc = `{
                 }
             }
         },
+        "models.RequestBundle": {
+            "type": "object",
+            "properties": {
+                "bundle": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.RequestSkin": {
             "type": "object",
             "properties": {
@@ -600,6 +2689,100 @@ var doc = `{
                 }
             }
         },
+        "models.Sale": {
+            "type": "object",
+            "properties": {
+                "amount_off": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "percent_off": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "skin": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SaleList": {
+            "type": "object",
+            "properties": {
+                "sales": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Sale"
+                    }
+                }
+            }
+        },
+        "models.Season": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Winter 2019"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SeasonList": {
+            "type": "object",
+            "properties": {
+                "seasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Season"
+                    }
+                }
+            }
+        },
+        "models.SendGift": {
+            "type": "object",
+            "properties": {
+                "coins": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 43
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Happy birthday!"
+                },
+                "nickname": {
+                    "type": "string",
+                    "example": "Kate"
+                },
+                "skin": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
         "models.Session": {
             "type": "object",
             "properties": {
@@ -609,17 +2792,85 @@ var doc = `{
                 }
             }
         },
+        "models.SessionInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "device": {
+                    "type": "string",
+                    "example": "Android"
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "192.0.2.1"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0 (Linux; Android 9)"
+                }
+            }
+        },
+        "models.SessionList": {
+            "type": "object",
+            "properties": {
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SessionInfo"
+                    }
+                }
+            }
+        },
         "models.Skin": {
             "type": "object",
             "properties": {
+                "asset_url": {
+                    "type": "string"
+                },
+                "available_from": {
+                    "type": "string"
+                },
+                "available_until": {
+                    "type": "string"
+                },
                 "cost": {
                     "type": "integer"
                 },
+                "description": {
+                    "type": "string",
+                    "example": "Spooky"
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
+                },
+                "price": {
+                    "description": "price with the best current sale, cost is the original price",
+                    "type": "integer",
+                    "example": 40
+                },
+                "rarity": {
+                    "type": "string",
+                    "example": "rare"
+                },
+                "retired": {
+                    "type": "boolean"
+                },
+                "sale_ends_at": {
+                    "type": "string"
                 }
             }
         },
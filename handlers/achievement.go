@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
+
+	"api/database"
+	"api/models"
+)
+
+func AchievementHandler(users database.UserRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getAchievements(w, r, users)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// @Summary Получить достижения
+// @Description Получить все достижения игрока по ID или из сессии, у полученных есть время получения
+// @ID get-profile-achievements
+// @Produce json
+// @Param id query uint false "ID"
+// @Success 200 {object} models.AchievementList "Достижения, сначала полученные"
+// @Failure 400 "Неправильный запрос"
+// @Failure 401 "Не залогинен"
+// @Failure 404 "Игрок не найден"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/achievements [GET]
+func getAchievements(w http.ResponseWriter, r *http.Request, users database.UserRepository) {
+	rawID := r.URL.Query().Get("id")
+	var id uint64
+	var err error
+	if rawID != "" {
+		id, err = strconv.ParseUint(rawID, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	if id == 0 {
+		if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		id = uint64(r.Context().Value(middleware.KeyUserID).(uint))
+	}
+
+	achievements, err := users.GetUserAchievements(r.Context(), uint(id))
+	if err != nil {
+		switch err.(type) {
+		case database.UserNotFoundError:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			logger.Errorf("database error while getting achievements of user %v: %v", id, err)
+			w.WriteHeader(dbErrorStatus(r, err))
+		}
+		return
+	}
+
+	list := models.AchievementList{
+		Achievements: *achievements,
+	}
+	json, err := list.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
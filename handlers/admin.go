@@ -0,0 +1,623 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
+
+	"api/database"
+	"api/models"
+)
+
+const (
+	maxSkinNameLength = 32
+
+	maxPromoCodeLength   = 32
+	maxPromoPrefixLength = 16
+	maxPromoBatch        = 1000
+)
+
+var promoCodeRegexp = regexp.MustCompile(`^[A-Z0-9_-]+$`)
+
+// checkAdmin writes the error status and returns false if the user is not an admin
+func checkAdmin(w http.ResponseWriter, r *http.Request, users database.UserRepository) bool {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return false
+	}
+
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	admin, err := users.IsAdmin(r.Context(), uID)
+	if err != nil {
+		switch err.(type) {
+		case database.UserNotFoundError:
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			logger.Errorf("database error while checking admin rights of user %v: %v", uID, err)
+			w.WriteHeader(dbErrorStatus(r, err))
+		}
+		return false
+	}
+	if !admin {
+		w.WriteHeader(http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
+
+func SkinAdminHandler(users database.UserRepository, store database.StoreRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !checkAdmin(w, r, users) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			getAdminSkins(w, r, store)
+		case http.MethodPost:
+			postAdminSkin(w, r, store)
+		case http.MethodPut:
+			putAdminSkin(w, r, store)
+		case http.MethodDelete:
+			deleteAdminSkin(w, r, store)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func validateSkin(s *models.Skin) error {
+	if s.Name == "" || len([]rune(s.Name)) > maxSkinNameLength {
+		return fmt.Errorf("Невалидное название скина")
+	}
+	if s.Cost < 0 {
+		return fmt.Errorf("Отрицательная стоимость")
+	}
+	switch s.Rarity {
+	case "":
+		s.Rarity = models.RarityCommon
+	case models.RarityCommon, models.RarityRare, models.RarityEpic, models.RarityLegendary:
+	default:
+		return fmt.Errorf("Неизвестная редкость")
+	}
+	if s.AvailableFrom != nil && s.AvailableUntil != nil && !s.AvailableFrom.Before(*s.AvailableUntil) {
+		return fmt.Errorf("Невалидный период доступности")
+	}
+
+	return nil
+}
+
+// @Summary Получить все скины
+// @Description Получить все скины, включая скрытые, снятые с продажи и недоступные сейчас. Только для администраторов
+// @ID get-admin-skins
+// @Produce json
+// @Success 200 {object} models.AllSkins "Все скины"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Не администратор"
+// @Failure 500 "Ошибка в бд"
+// @Router /admin/skins [GET]
+func getAdminSkins(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	skins, err := store.GetAllSkins(r.Context(), 0, true)
+	if err != nil {
+		logger.Errorf("database error while getting all skins: %v", err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	skinsList := &models.AllSkins{
+		Skins: *skins,
+	}
+	json, err := skinsList.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
+
+// @Summary Добавить скин
+// @Description Добавить скин в каталог. Только для администраторов
+// @ID post-admin-skin
+// @Accept json
+// @Produce json
+// @Param Skin body models.Skin true "Скин"
+// @Success 201 {object} models.Skin "Скин добавлен"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Не администратор"
+// @Failure 422 "Невалидный скин"
+// @Failure 500 "Ошибка в бд"
+// @Router /admin/skins [POST]
+func postAdminSkin(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	s := &models.Skin{}
+	err := unmarshalJSONBodyToStruct(r, s)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err = validateSkin(s); err != nil {
+		sendError(w, err, http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = store.CreateSkin(r.Context(), s)
+	if err != nil {
+		logger.Errorf("database error while creating skin %v: %v", s.Name, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	logger.Infof("skin %v (%v) was created by user %v", s.ID, s.Name, r.Context().Value(middleware.KeyUserID))
+
+	json, err := s.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintln(w, string(json))
+}
+
+// @Summary Изменить скин
+// @Description Изменить все поля скина по ID. Снятые с продажи скины остаются у владельцев. Только для администраторов
+// @ID put-admin-skin
+// @Accept json
+// @Param Skin body models.Skin true "Скин"
+// @Success 200 "Скин изменен"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Не администратор"
+// @Failure 404 "Скин не найден"
+// @Failure 422 "Невалидный скин"
+// @Failure 500 "Ошибка в бд"
+// @Router /admin/skins [PUT]
+func putAdminSkin(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	s := &models.Skin{}
+	err := unmarshalJSONBodyToStruct(r, s)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err = validateSkin(s); err != nil {
+		sendError(w, err, http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = store.UpdateSkin(r.Context(), s)
+	if err != nil {
+		if err == database.ErrSkinNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logger.Errorf("database error while updating skin %v: %v", s.ID, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	logger.Infof("skin %v was updated by user %v", s.ID, r.Context().Value(middleware.KeyUserID))
+}
+
+// @Summary Удалить скин
+// @Description Удалить скин, который никто не купил. Купленные скины можно только снять с продажи. Только для администраторов
+// @ID delete-admin-skin
+// @Param id query uint true "ID"
+// @Success 200 "Скин удален"
+// @Failure 400 "Неправильный запрос"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Не администратор"
+// @Failure 404 "Скин не найден"
+// @Failure 409 "Скин куплен игроками"
+// @Failure 500 "Ошибка в бд"
+// @Router /admin/skins [DELETE]
+func deleteAdminSkin(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	id, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = store.DeleteSkin(r.Context(), uint(id))
+	if err != nil {
+		switch err {
+		case database.ErrSkinNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		case database.ErrSkinInUse:
+			w.WriteHeader(http.StatusConflict)
+		default:
+			logger.Errorf("database error while deleting skin %v: %v", id, err)
+			w.WriteHeader(dbErrorStatus(r, err))
+		}
+		return
+	}
+	logger.Infof("skin %v was deleted by user %v", id, r.Context().Value(middleware.KeyUserID))
+}
+
+func SaleAdminHandler(users database.UserRepository, store database.StoreRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !checkAdmin(w, r, users) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			getAdminSales(w, r, store)
+		case http.MethodPost:
+			postAdminSale(w, r, store)
+		case http.MethodDelete:
+			deleteAdminSale(w, r, store)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func validateSale(s *models.Sale) error {
+	if (s.PercentOff == nil) == (s.AmountOff == nil) {
+		return fmt.Errorf("Должна быть указана одна скидка: в процентах или в монетах")
+	}
+	if s.PercentOff != nil && (*s.PercentOff <= 0 || *s.PercentOff > 100) {
+		return fmt.Errorf("Невалидная скидка в процентах")
+	}
+	if s.AmountOff != nil && *s.AmountOff <= 0 {
+		return fmt.Errorf("Невалидная скидка в монетах")
+	}
+	if !s.StartsAt.Before(s.EndsAt) {
+		return fmt.Errorf("Невалидный период распродажи")
+	}
+
+	return nil
+}
+
+// @Summary Получить распродажи
+// @Description Получить все распродажи скинов, сначала новые. Только для администраторов
+// @ID get-admin-sales
+// @Produce json
+// @Success 200 {object} models.SaleList "Распродажи"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Не администратор"
+// @Failure 500 "Ошибка в бд"
+// @Router /admin/sales [GET]
+func getAdminSales(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	sales, err := store.GetAllSales(r.Context())
+	if err != nil {
+		logger.Errorf("database error while getting sales: %v", err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	saleList := &models.SaleList{
+		Sales: *sales,
+	}
+	json, err := saleList.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
+
+// @Summary Запланировать распродажу
+// @Description Запланировать скидку на скин в процентах или в монетах. Если распродажи пересекаются, действует наибольшая скидка. Только для администраторов
+// @ID post-admin-sale
+// @Accept json
+// @Produce json
+// @Param Sale body models.Sale true "Распродажа"
+// @Success 201 {object} models.Sale "Распродажа создана"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Не администратор"
+// @Failure 404 "Скин не найден"
+// @Failure 422 "Невалидная распродажа"
+// @Failure 500 "Ошибка в бд"
+// @Router /admin/sales [POST]
+func postAdminSale(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	s := &models.Sale{}
+	err := unmarshalJSONBodyToStruct(r, s)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err = validateSale(s); err != nil {
+		sendError(w, err, http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = store.CreateSale(r.Context(), s)
+	if err != nil {
+		if err == database.ErrSkinNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logger.Errorf("database error while creating sale of skin %v: %v", s.SkinID, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	logger.Infof("sale %v of skin %v was created by user %v", s.ID, s.SkinID, r.Context().Value(middleware.KeyUserID))
+
+	json, err := s.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintln(w, string(json))
+}
+
+// @Summary Отменить распродажу
+// @Description Удалить распродажу. Только для администраторов
+// @ID delete-admin-sale
+// @Param id query uint true "ID"
+// @Success 200 "Распродажа удалена"
+// @Failure 400 "Неправильный запрос"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Не администратор"
+// @Failure 404 "Распродажа не найдена"
+// @Failure 500 "Ошибка в бд"
+// @Router /admin/sales [DELETE]
+func deleteAdminSale(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	id, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = store.DeleteSale(r.Context(), uint(id))
+	if err != nil {
+		if err == database.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logger.Errorf("database error while deleting sale %v: %v", id, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	logger.Infof("sale %v was deleted by user %v", id, r.Context().Value(middleware.KeyUserID))
+}
+
+func BundleAdminHandler(users database.UserRepository, store database.StoreRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !checkAdmin(w, r, users) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			getAdminBundles(w, r, store)
+		case http.MethodPost:
+			postAdminBundle(w, r, store)
+		case http.MethodDelete:
+			deleteAdminBundle(w, r, store)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func validateBundle(b *models.Bundle) error {
+	if b.Name == "" || len([]rune(b.Name)) > maxSkinNameLength {
+		return fmt.Errorf("Невалидное название набора")
+	}
+	if b.Cost < 0 {
+		return fmt.Errorf("Отрицательная стоимость")
+	}
+	if len(b.Skins) == 0 {
+		return fmt.Errorf("Нет скинов в наборе")
+	}
+	if b.AvailableFrom != nil && b.AvailableUntil != nil && !b.AvailableFrom.Before(*b.AvailableUntil) {
+		return fmt.Errorf("Невалидный период доступности")
+	}
+
+	return nil
+}
+
+// @Summary Получить все наборы
+// @Description Получить все наборы скинов, включая скрытые, снятые с продажи и недоступные сейчас. Только для администраторов
+// @ID get-admin-bundles
+// @Produce json
+// @Success 200 {object} models.BundleList "Наборы"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Не администратор"
+// @Failure 500 "Ошибка в бд"
+// @Router /admin/bundles [GET]
+func getAdminBundles(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	bundles, err := store.GetBundles(r.Context(), true)
+	if err != nil {
+		logger.Errorf("database error while getting bundles: %v", err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	bundleList := &models.BundleList{
+		Bundles: *bundles,
+	}
+	json, err := bundleList.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
+
+// @Summary Добавить набор
+// @Description Добавить набор скинов с одной ценой за все скины. Только для администраторов
+// @ID post-admin-bundle
+// @Accept json
+// @Produce json
+// @Param Bundle body models.Bundle true "Набор"
+// @Success 201 {object} models.Bundle "Набор добавлен"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Не администратор"
+// @Failure 404 "Скин не найден"
+// @Failure 422 "Невалидный набор"
+// @Failure 500 "Ошибка в бд"
+// @Router /admin/bundles [POST]
+func postAdminBundle(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	b := &models.Bundle{}
+	err := unmarshalJSONBodyToStruct(r, b)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err = validateBundle(b); err != nil {
+		sendError(w, err, http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = store.CreateBundle(r.Context(), b)
+	if err != nil {
+		if err == database.ErrSkinNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logger.Errorf("database error while creating bundle %v: %v", b.Name, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	logger.Infof("bundle %v (%v) was created by user %v", b.ID, b.Name, r.Context().Value(middleware.KeyUserID))
+
+	json, err := b.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintln(w, string(json))
+}
+
+// @Summary Удалить набор
+// @Description Удалить набор скинов, купленные скины остаются у игроков. Только для администраторов
+// @ID delete-admin-bundle
+// @Param id query uint true "ID"
+// @Success 200 "Набор удален"
+// @Failure 400 "Неправильный запрос"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Не администратор"
+// @Failure 404 "Набор не найден"
+// @Failure 500 "Ошибка в бд"
+// @Router /admin/bundles [DELETE]
+func deleteAdminBundle(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	id, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = store.DeleteBundle(r.Context(), uint(id))
+	if err != nil {
+		if err == database.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logger.Errorf("database error while deleting bundle %v: %v", id, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	logger.Infof("bundle %v was deleted by user %v", id, r.Context().Value(middleware.KeyUserID))
+}
+
+func PromoAdminHandler(users database.UserRepository, store database.StoreRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !checkAdmin(w, r, users) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			postAdminPromo(w, r, store)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func validatePromoBatch(b *models.PromoBatch) error {
+	if (b.SkinID == nil) == (b.Coins == 0) || b.Coins < 0 {
+		return fmt.Errorf("Должна быть указана одна награда: скин или монеты")
+	}
+	if b.MaxRedemptions != nil && *b.MaxRedemptions <= 0 {
+		return fmt.Errorf("Невалидное количество активаций")
+	}
+	if b.Code != "" {
+		if len(b.Code) > maxPromoCodeLength || !promoCodeRegexp.MatchString(b.Code) {
+			return fmt.Errorf("Невалидный промокод")
+		}
+		return nil
+	}
+	if b.Count <= 0 || b.Count > maxPromoBatch {
+		return fmt.Errorf("Количество кодов должно быть от 1 до %v", maxPromoBatch)
+	}
+	if len(b.Prefix) > maxPromoPrefixLength || b.Prefix != "" && !promoCodeRegexp.MatchString(b.Prefix) {
+		return fmt.Errorf("Невалидный префикс")
+	}
+
+	return nil
+}
+
+// @Summary Создать промокоды
+// @Description Создать промокод с заданным текстом или набор случайных уникальных промокодов с наградой: скин или монеты. Только для администраторов
+// @ID post-admin-promo
+// @Accept json
+// @Produce json
+// @Param PromoBatch body models.PromoBatch true "Награда, лимит активаций, срок действия и промокод или количество и префикс"
+// @Success 201 {object} models.PromoCodeList "Промокоды созданы"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Не администратор"
+// @Failure 404 "Скин не найден"
+// @Failure 409 "Промокод уже существует"
+// @Failure 422 "Невалидные параметры"
+// @Failure 500 "Ошибка в бд"
+// @Router /admin/promo [POST]
+func postAdminPromo(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	b := &models.PromoBatch{}
+	err := unmarshalJSONBodyToStruct(r, b)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	b.Code = strings.ToUpper(b.Code)
+	b.Prefix = strings.ToUpper(b.Prefix)
+	if err = validatePromoBatch(b); err != nil {
+		sendError(w, err, http.StatusUnprocessableEntity)
+		return
+	}
+
+	codes, err := store.CreatePromoCodes(r.Context(), &b.PromoCode, b.Count, b.Prefix)
+	if err != nil {
+		switch err {
+		case db.ErrUniqueConstraintViolation:
+			w.WriteHeader(http.StatusConflict)
+		case database.ErrSkinNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			logger.Errorf("database error while creating promo codes: %v", err)
+			w.WriteHeader(dbErrorStatus(r, err))
+		}
+		return
+	}
+	logger.Infof("%v promo codes were created by user %v", len(*codes), r.Context().Value(middleware.KeyUserID))
+
+	codeList := &models.PromoCodeList{
+		Codes: *codes,
+	}
+	json, err := codeList.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintln(w, string(json))
+}
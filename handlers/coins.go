@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
+
+	"api/database"
+	"api/models"
+)
+
+const (
+	defaultCoinHistoryLimit = 20
+	maxCoinHistoryLimit     = 100
+)
+
+func CoinHistoryHandler(store database.StoreRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getCoinHistory(w, r, store)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// @Summary Получить историю монет
+// @Description Получить начисления и списания монет, сначала новые (пагинация по курсору)
+// @ID get-profile-coins-history
+// @Produce json
+// @Param limit query uint false "Операций на страницу"
+// @Param before query uint false "Курсор: поле next из предыдущей страницы"
+// @Success 200 {object} models.CoinHistory "Операции и курсор следующей страницы"
+// @Failure 400 "Неправильный запрос"
+// @Failure 401 "Не залогинен"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/coins/history [GET]
+func getCoinHistory(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	rawLimit := query.Get("limit")
+	var limit uint64
+	var err error
+	if rawLimit != "" {
+		limit, err = strconv.ParseUint(rawLimit, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	if limit == 0 {
+		limit = defaultCoinHistoryLimit
+	}
+	if limit > maxCoinHistoryLimit {
+		limit = maxCoinHistoryLimit
+	}
+	rawBefore := query.Get("before")
+	var before uint64
+	if rawBefore != "" {
+		before, err = strconv.ParseUint(rawBefore, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	transactions, err := store.GetCoinTransactionsPaginated(r.Context(), uID, limit, before)
+	if err != nil {
+		logger.Errorf("database error while getting coin history of user %v: %v", uID, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	history := models.CoinHistory{
+		Transactions: *transactions,
+	}
+	if uint64(len(*transactions)) == limit {
+		history.Next = strconv.FormatUint((*transactions)[len(*transactions)-1].ID, 10)
+	}
+
+	json, err := history.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
+
+func CoinLedgerHandler(store database.StoreRepository, serviceToken string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			checkCoinLedger(w, r, store, serviceToken)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// @Summary Проверить журнал монет
+// @Description Пересчитать балансы по журналу операций и найти расхождения с монетами игроков. Только для сервисов
+// @ID get-coins-check
+// @Produce json
+// @Param Authorization header string true "Bearer <токен сервиса>"
+// @Success 200 {object} models.CoinLedgerReport "Количество проверенных игроков и расхождения"
+// @Failure 401 "Неверный токен сервиса"
+// @Failure 500 "Ошибка в бд"
+// @Router /coins/check [GET]
+func checkCoinLedger(w http.ResponseWriter, r *http.Request, store database.StoreRepository, serviceToken string) {
+	if !isServiceRequest(r, serviceToken) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	report, err := store.CheckCoinLedger(r.Context())
+	if err != nil {
+		logger.Errorf("database error while checking coin ledger: %v", err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	if len(report.Drifts) != 0 {
+		logger.Errorf("coin ledger drift found for %v users", len(report.Drifts))
+	}
+
+	json, err := report.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
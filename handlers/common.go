@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strings"
 )
 
 func unmarshalJSONBodyToStruct(r *http.Request, s json.Unmarshaler) error {
@@ -20,3 +22,17 @@ func unmarshalJSONBodyToStruct(r *http.Request, s json.Unmarshaler) error {
 
 	return nil
 }
+
+// isServiceRequest checks the token of the trusted services (e.g. the game server),
+// requests are never trusted if the token is not configured
+func isServiceRequest(r *http.Request, token string) bool {
+	if token == "" {
+		return false
+	}
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, "Bearer ") {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(h, "Bearer ")), []byte(token)) == 1
+}
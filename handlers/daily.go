@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
+
+	"api/database"
+)
+
+// dailyClaims remembers the users who got the daily reward today,
+// so the database is asked once a day per user by every instance
+type dailyClaims struct {
+	mu    sync.Mutex
+	day   string
+	users map[uint]bool
+}
+
+// claim returns true if the user hasn't been seen today
+func (c *dailyClaims) claim(uID uint, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	day := now.UTC().Format("2006-01-02")
+	if c.day != day {
+		c.day = day
+		c.users = make(map[uint]bool)
+	}
+	if c.users[uID] {
+		return false
+	}
+	c.users[uID] = true
+
+	return true
+}
+
+func (c *dailyClaims) forget(uID uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.users, uID)
+}
+
+var todayClaims = &dailyClaims{}
+
+// DailyRewardMiddleware gives the daily reward on the first authenticated request of the UTC day,
+// it must be called after the session middleware
+func DailyRewardMiddleware(next http.Handler, users database.UserRepository) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+			uID := r.Context().Value(middleware.KeyUserID).(uint)
+			if todayClaims.claim(uID, time.Now()) {
+				reward, err := users.ClaimDailyReward(r.Context(), uID)
+				switch err {
+				case nil:
+					logger.Infof("user %v got daily reward %v for day %v of the streak", uID, reward.Coins, reward.Streak)
+				case database.ErrAlreadyClaimed:
+				default:
+					// try again on the next request
+					todayClaims.forget(uID)
+					logger.Errorf("database error while giving daily reward to user %v: %v", uID, err)
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func DailyRewardHandler(users database.UserRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			postDailyReward(w, r, users)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// @Summary Получить ежедневную награду
+// @Description Получить монеты за вход сегодня (по UTC). Награда растет с каждым днем подряд и сбрасывается, если день пропущен. Также выдается при первом запросе за день
+// @ID post-profile-daily
+// @Produce json
+// @Success 200 {object} models.DailyReward "Награда получена"
+// @Failure 401 "Не залогинен"
+// @Failure 409 "Награда за сегодня уже получена"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/daily [POST]
+func postDailyReward(w http.ResponseWriter, r *http.Request, users database.UserRepository) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	reward, err := users.ClaimDailyReward(r.Context(), uID)
+	if err != nil {
+		if err == database.ErrAlreadyClaimed {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		switch err.(type) {
+		case database.UserNotFoundError:
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			logger.Errorf("database error while giving daily reward to user %v: %v", uID, err)
+			w.WriteHeader(dbErrorStatus(r, err))
+		}
+		return
+	}
+
+	json, err := reward.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
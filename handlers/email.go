@@ -0,0 +1,411 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
+
+	"api/auth"
+	"api/database"
+	"api/mail"
+	"api/models"
+)
+
+type letter struct {
+	ttl     time.Duration
+	path    string
+	subject string
+	// with the link and the lifetime of the link in hours
+	body string
+}
+
+var letters = map[string]letter{
+	models.TokenVerifyEmail: {
+		ttl:     24 * time.Hour,
+		path:    "/verify-email",
+		subject: "Подтверждение почты",
+		body: "Чтобы подтвердить почту, перейдите по ссылке:\n\n%v\n\n" +
+			"Ссылка действует %v ч. Если вы не регистрировались в игре, просто удалите это письмо.\n",
+	},
+	models.TokenResetPassword: {
+		ttl:     time.Hour,
+		path:    "/reset-password",
+		subject: "Восстановление пароля",
+		body: "Чтобы задать новый пароль, перейдите по ссылке:\n\n%v\n\n" +
+			"Ссылка действует %v ч. Если вы не запрашивали восстановление пароля, просто удалите это письмо.\n",
+	},
+}
+
+const (
+	// the letters wait for the worker, the requests never wait for the mail server
+	letterQueueSize = 100
+	letterTimeout   = 30 * time.Second
+)
+
+// Letters sends the players the links to the frontend with the single-use tokens,
+// the letters are sent one by one in the background
+type Letters struct {
+	mailer mail.Mailer
+	appURL string
+
+	mu      sync.Mutex
+	closed  bool
+	jobs    chan func(ctx context.Context)
+	pending sync.WaitGroup
+}
+
+func NewLetters(mailer mail.Mailer, appURL string) *Letters {
+	l := &Letters{
+		mailer: mailer,
+		appURL: appURL,
+		jobs:   make(chan func(ctx context.Context), letterQueueSize),
+	}
+	go l.run()
+
+	return l
+}
+
+func (l *Letters) run() {
+	for job := range l.jobs {
+		ctx, cancel := context.WithTimeout(context.Background(), letterTimeout)
+		job(ctx)
+		cancel()
+		l.pending.Done()
+	}
+}
+
+// enqueue doesn't block the request, the job is dropped if the queue is full or closed
+func (l *Letters) enqueue(job func(ctx context.Context)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		logger.Error("letter queue is closed, the letter is dropped")
+		return
+	}
+	l.pending.Add(1)
+	select {
+	case l.jobs <- job:
+	default:
+		l.pending.Done()
+		logger.Error("letter queue is full, the letter is dropped")
+	}
+}
+
+// Wait blocks until the queued letters are sent
+func (l *Letters) Wait() {
+	l.pending.Wait()
+}
+
+// Close sends the queued letters, the letters after it are dropped
+func (l *Letters) Close() {
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.jobs)
+	}
+	l.mu.Unlock()
+	l.pending.Wait()
+}
+
+// letter creates the token of the purpose for the user and writes the link with it to the address
+func (l *Letters) letter(ctx context.Context, users database.UserRepository, uID uint, email, purpose string) (
+	*mail.Message, error) {
+	lt := letters[purpose]
+	token, hash, err := auth.NewToken()
+	if err != nil {
+		return nil, err
+	}
+	err = users.CreateEmailToken(ctx, &models.EmailToken{
+		Hash:      hash,
+		UserID:    uID,
+		Purpose:   purpose,
+		Email:     email,
+		ExpiresAt: time.Now().Add(lt.ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	link := l.appURL + lt.path + "?token=" + url.QueryEscape(token)
+	return &mail.Message{
+		To:      email,
+		Subject: lt.subject,
+		Body:    fmt.Sprintf(lt.body, link, int(lt.ttl.Hours())),
+	}, nil
+}
+
+// mail sends the letter in the background
+func (l *Letters) mail(uID uint, m *mail.Message) {
+	l.enqueue(func(ctx context.Context) {
+		err := l.mailer.Send(ctx, m)
+		if err != nil {
+			logger.Errorf("error while sending letter to user %v: %v", uID, err)
+		}
+	})
+}
+
+// send creates the token in the request and mails the link in the background
+func (l *Letters) send(ctx context.Context, users database.UserRepository, uID uint, email, purpose string) error {
+	m, err := l.letter(ctx, users, uID, email, purpose)
+	if err != nil {
+		return err
+	}
+	l.mail(uID, m)
+
+	return nil
+}
+
+// sendVerification is used after the address is set, the player can ask for the letter again
+func (l *Letters) sendVerification(r *http.Request, users database.UserRepository, uID uint, email string) {
+	err := l.send(r.Context(), users, uID, email, models.TokenVerifyEmail)
+	if err != nil {
+		logger.Errorf("error while sending verification letter to user %v: %v", uID, err)
+	}
+}
+
+// sendPasswordReset looks for the user in the background so the response
+// doesn't depend on whether the address is registered
+func (l *Letters) sendPasswordReset(users database.UserRepository, email string) {
+	l.enqueue(func(ctx context.Context) {
+		u, err := users.GetUserPassword(ctx, email)
+		if err != nil {
+			if _, ok := err.(database.UserNotFoundError); !ok {
+				logger.Errorf("database error while getting user with email %v: %v", email, err)
+			}
+			return
+		}
+
+		// the letter goes to the address of the user, not the one of the request
+		m, err := l.letter(ctx, users, u.UserID, u.Email, models.TokenResetPassword)
+		switch err {
+		case nil:
+			logger.Infof("user %v requested password reset", u.UserID)
+		case database.ErrTooManyTokens:
+			logger.Infof("too many password reset letters for user %v", u.UserID)
+			return
+		default:
+			logger.Errorf("error while creating password reset letter for user %v: %v", u.UserID, err)
+			return
+		}
+		err = l.mailer.Send(ctx, m)
+		if err != nil {
+			logger.Errorf("error while sending password reset letter to user %v: %v", u.UserID, err)
+		}
+	})
+}
+
+func EmailVerificationHandler(users database.UserRepository, l *Letters) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			postEmailVerification(w, r, users, l)
+		case http.MethodPut:
+			putEmailVerification(w, r, users)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// @Summary Отправить письмо для подтверждения почты
+// @Description Отправить на почту пользователя ссылку для подтверждения, не больше 3 писем в час
+// @ID post-profile-email-verify
+// @Success 200 "Письмо отправлено"
+// @Failure 401 "Не залогинен"
+// @Failure 409 "Почта уже подтверждена"
+// @Failure 429 "Слишком много писем"
+// @Failure 500 "Ошибка в бд или при отправке письма"
+// @Router /profile/email/verify [POST]
+func postEmailVerification(w http.ResponseWriter, r *http.Request, users database.UserRepository, l *Letters) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+
+	profile, err := users.GetUserProfileByID(r.Context(), uID, true)
+	if err != nil {
+		logger.Errorf("database error while getting profile of user %v: %v", uID, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	if profile.EmailVerified != nil && *profile.EmailVerified {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	err = l.send(r.Context(), users, uID, profile.Email, models.TokenVerifyEmail)
+	switch err {
+	case nil:
+	case database.ErrTooManyTokens:
+		w.WriteHeader(http.StatusTooManyRequests)
+	default:
+		logger.Errorf("error while sending verification letter to user %v: %v", uID, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+	}
+}
+
+// @Summary Подтвердить почту
+// @Description Подтвердить почту по токену из письма, залогиниться не нужно
+// @ID put-profile-email-verify
+// @Accept json
+// @Param Token body models.EmailTokenConfirmation true "Токен из ссылки"
+// @Success 200 "Почта подтверждена"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 422 "Токен недействителен, истек или уже использован"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/email/verify [PUT]
+func putEmailVerification(w http.ResponseWriter, r *http.Request, users database.UserRepository) {
+	c := &models.EmailTokenConfirmation{}
+	err := unmarshalJSONBodyToStruct(r, c)
+	if err != nil {
+		switch err.(type) {
+		case ParseJSONError:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	uID, err := users.VerifyEmail(r.Context(), auth.HashToken(c.Token))
+	switch err {
+	case nil:
+		logger.Infof("user %v verified email", uID)
+	case database.ErrInvalidToken:
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	default:
+		logger.Errorf("database error while verifying email: %v", err)
+		w.WriteHeader(dbErrorStatus(r, err))
+	}
+}
+
+func PasswordResetHandler(users database.UserRepository, sm auth.SessionManager, h *auth.PasswordHasher,
+	l *Letters, rt *auth.LoginThrottle) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			postPasswordReset(w, r, users, l, rt)
+		case http.MethodPut:
+			putPasswordReset(w, r, users, sm, h)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// @Summary Запросить восстановление пароля
+// @Description Отправить на почту ссылку для восстановления пароля. Письмо отправляется в фоне, ответ не зависит от того, есть ли пользователь с такой почтой
+// @ID post-profile-password-reset
+// @Accept json
+// @Param Email body models.PasswordResetRequest true "Почта"
+// @Success 200 "Письмо будет отправлено, если пользователь с такой почтой есть"
+// @Failure 400 "Неверный формат JSON или невалидная почта"
+// @Failure 429 "Слишком много запросов для почты или адреса, в Retry-After сколько секунд ждать"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/password/reset [POST]
+func postPasswordReset(w http.ResponseWriter, r *http.Request, users database.UserRepository, l *Letters,
+	rt *auth.LoginThrottle) {
+	req := &models.PasswordResetRequest{}
+	err := unmarshalJSONBodyToStruct(r, req)
+	if err != nil {
+		switch err.(type) {
+		case ParseJSONError:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	if !govalidator.IsEmail(req.Email) {
+		sendError(w, fmt.Errorf("Невалидная почта"), http.StatusBadRequest)
+		return
+	}
+
+	// the request is counted whether the address is registered or not, so the limit doesn't tell it
+	ip := clientIP(r)
+	wait, err := rt.Reserve(r.Context(), req.Email, ip)
+	if err != nil {
+		logger.Errorf("database error while reserving password reset: %v", err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	if wait > 0 {
+		logger.Infof("password reset of %v from %v is throttled for %v", req.Email, ip, wait)
+		sendThrottled(w, wait)
+		return
+	}
+
+	l.sendPasswordReset(users, req.Email)
+}
+
+// @Summary Задать новый пароль
+// @Description Задать новый пароль по токену из письма, залогиниться не нужно. Почта при этом подтверждается, все сессии пользователя завершаются
+// @ID put-profile-password-reset
+// @Accept json
+// @Produce json
+// @Param Reset body models.PasswordReset true "Токен из ссылки и новый пароль"
+// @Success 200 "Пароль изменен"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 403 {object} models.ProfileErrorList "Пароль не удовлетворяет правилам безопасности"
+// @Failure 422 "Токен недействителен, истек или уже использован"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/password/reset [PUT]
+func putPasswordReset(w http.ResponseWriter, r *http.Request, users database.UserRepository, sm auth.SessionManager,
+	h *auth.PasswordHasher) {
+	reset := &models.PasswordReset{}
+	err := unmarshalJSONBodyToStruct(r, reset)
+	if err != nil {
+		switch err.(type) {
+		case ParseJSONError:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if fieldErrors := validatePassword(reset.Password); len(fieldErrors) != 0 {
+		sendList := models.ProfileErrorList{Errors: fieldErrors}
+		json, err := sendList.MarshalJSON()
+		if err != nil {
+			logger.Error(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintln(w, string(json))
+		return
+	}
+	password, err := h.Hash(reset.Password)
+	if err != nil {
+		logger.Errorf("hash and salt password error: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	uID, err := users.ResetPassword(r.Context(), auth.HashToken(reset.Token), password)
+	switch err {
+	case nil:
+		logger.Infof("user %v reset password", uID)
+		err = sm.DeleteUserSessions(uID, "")
+		if err != nil {
+			logger.Errorf("error while deleting sessions of user %v: %v", uID, err)
+		}
+	case database.ErrInvalidToken:
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	default:
+		logger.Errorf("database error while resetting password: %v", err)
+		w.WriteHeader(dbErrorStatus(r, err))
+	}
+}
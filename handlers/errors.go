@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
 
 	"api/models"
@@ -30,3 +33,18 @@ func sendError(w http.ResponseWriter, e error, status int) {
 	w.WriteHeader(status)
 	fmt.Fprintln(w, string(m))
 }
+
+// dbErrorStatus is the status of the failed database call: 504 if the request ran out of its
+// time for the queries, 503 if the client went away or the database is not connected, 500 otherwise
+func dbErrorStatus(r *http.Request, err error) int {
+	// canceled queries fail with the error of the driver, not the one of the context
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || r.Context().Err() == context.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled) || r.Context().Err() == context.Canceled ||
+		err == db.ErrConnRefused:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
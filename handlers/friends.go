@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
+
+	"api/database"
+	"api/models"
+)
+
+func FriendsHandler(users database.UserRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getFriends(w, r, users)
+		case http.MethodPost:
+			postFriend(w, r, users)
+		case http.MethodPut:
+			putFriend(w, r, users)
+		case http.MethodDelete:
+			deleteFriend(w, r, users)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// resolveUserID finds the user by ID or nickname
+func resolveUserID(ctx context.Context, users database.UserRepository, id uint, nickname string) (uint, error) {
+	if id != 0 || nickname == "" {
+		return id, nil
+	}
+	profile, err := users.GetUserProfileByNickname(ctx, nickname)
+	if err != nil {
+		return 0, err
+	}
+
+	return profile.UserID, nil
+}
+
+func sendFriendError(w http.ResponseWriter, r *http.Request, err error) {
+	if err == database.ErrNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err == database.ErrBlocked {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	switch err.(type) {
+	case database.UserNotFoundError:
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		logger.Error(err)
+		w.WriteHeader(dbErrorStatus(r, err))
+	}
+}
+
+// @Summary Получить друзей
+// @Description Получить друзей, входящие и исходящие заявки в друзья и заблокированных пользователей
+// @ID get-friends
+// @Produce json
+// @Success 200 {object} models.FriendList "Друзья и заявки"
+// @Failure 401 "Не залогинен"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/friends [GET]
+func getFriends(w http.ResponseWriter, r *http.Request, users database.UserRepository) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	friends, err := users.GetFriendList(r.Context(), uID)
+	if err != nil {
+		logger.Errorf("database error while getting friends of user %v: %v", uID, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+
+	json, err := friends.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
+
+// @Summary Отправить заявку в друзья
+// @Description Отправить заявку в друзья по ID или никнейму. Если пользователь уже отправил заявку, то становимся друзьями
+// @ID post-friend
+// @Accept json
+// @Param FriendAction body models.FriendAction true "ID или никнейм пользователя"
+// @Success 200 "Заявка отправлена (или уже друзья)"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Пользователь заблокирован"
+// @Failure 404 "Пользователь не найден"
+// @Failure 422 "Нельзя добавить в друзья себя"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/friends [POST]
+func postFriend(w http.ResponseWriter, r *http.Request, users database.UserRepository) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	a := &models.FriendAction{}
+	err := unmarshalJSONBodyToStruct(r, a)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	friendID, err := resolveUserID(r.Context(), users, a.ID, a.Nickname)
+	if err != nil {
+		sendFriendError(w, r, err)
+		return
+	}
+	if friendID == 0 || friendID == uID {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	}
+
+	accepted, err := users.SendFriendRequest(r.Context(), uID, friendID)
+	if err != nil {
+		if err == database.ErrAlreadyFriends {
+			return
+		}
+		sendFriendError(w, r, err)
+		return
+	}
+	if accepted {
+		logger.Infof("users %v and %v are friends now", uID, friendID)
+	}
+}
+
+// @Summary Ответить на заявку или заблокировать
+// @Description Принять или отклонить заявку в друзья, заблокировать или разблокировать пользователя
+// @ID put-friend
+// @Accept json
+// @Param FriendAction body models.FriendAction true "ID или никнейм пользователя и действие: accept, decline, block, unblock"
+// @Success 200 "Успешно"
+// @Failure 400 "Неверный формат JSON, неизвестное действие"
+// @Failure 401 "Не залогинен"
+// @Failure 404 "Пользователь или заявка не найдены"
+// @Failure 422 "Действие над собой"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/friends [PUT]
+func putFriend(w http.ResponseWriter, r *http.Request, users database.UserRepository) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	a := &models.FriendAction{}
+	err := unmarshalJSONBodyToStruct(r, a)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	otherID, err := resolveUserID(r.Context(), users, a.ID, a.Nickname)
+	if err != nil {
+		sendFriendError(w, r, err)
+		return
+	}
+	if otherID == 0 || otherID == uID {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	}
+
+	switch a.Action {
+	case models.FriendActionAccept:
+		err = users.AcceptFriendRequest(r.Context(), uID, otherID)
+	case models.FriendActionDecline:
+		err = users.DeclineFriendRequest(r.Context(), uID, otherID)
+	case models.FriendActionBlock:
+		err = users.BlockUser(r.Context(), uID, otherID)
+	case models.FriendActionUnblock:
+		err = users.UnblockUser(r.Context(), uID, otherID)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		sendFriendError(w, r, err)
+	}
+}
+
+// @Summary Удалить из друзей
+// @Description Удалить из друзей или отменить отправленную заявку
+// @ID delete-friend
+// @Param id query uint true "ID"
+// @Success 200 "Удален из друзей"
+// @Failure 400 "Неправильный запрос"
+// @Failure 401 "Не залогинен"
+// @Failure 404 "Не друзья и нет заявки"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/friends [DELETE]
+func deleteFriend(w http.ResponseWriter, r *http.Request, users database.UserRepository) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = users.RemoveFriend(r.Context(), r.Context().Value(middleware.KeyUserID).(uint), uint(id))
+	if err != nil {
+		sendFriendError(w, r, err)
+	}
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
+
+	"api/database"
+	"api/models"
+)
+
+const (
+	maxGiftMessageLength = 140
+)
+
+func GiftHandler(users database.UserRepository, store database.StoreRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getGifts(w, r, store)
+		case http.MethodPost:
+			postGift(w, r, users, store)
+		case http.MethodPut:
+			putGift(w, r, store)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func sendGiftError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case database.ErrNotFound, database.ErrSkinNotFound:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	case database.ErrBlocked:
+		w.WriteHeader(http.StatusForbidden)
+		return
+	case database.ErrAlreadyOwned:
+		w.WriteHeader(http.StatusConflict)
+		return
+	case database.ErrInsufficientCoins:
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	case database.ErrGiftLimitExceeded:
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+	switch err.(type) {
+	case database.UserNotFoundError:
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		logger.Error(err)
+		w.WriteHeader(dbErrorStatus(r, err))
+	}
+}
+
+// @Summary Получить подарки
+// @Description Получить ожидающие подарки для игрока и последние отправленные им подарки
+// @ID get-gifts
+// @Produce json
+// @Success 200 {object} models.GiftList "Подарки"
+// @Failure 401 "Не залогинен"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/gifts [GET]
+func getGifts(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	gifts, err := store.GetGiftList(r.Context(), uID)
+	if err != nil {
+		logger.Errorf("database error while getting gifts of user %v: %v", uID, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+
+	json, err := gifts.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
+
+// @Summary Подарить скин или монеты
+// @Description Купить скин другому игроку или передать ему монеты по ID или никнейму. Монеты списываются сразу и возвращаются, если подарок отклонен. Количество подарков и монет в день ограничено
+// @ID post-gift
+// @Accept json
+// @Produce json
+// @Param SendGift body models.SendGift true "Получатель, скин или монеты и сообщение"
+// @Success 201 {object} models.Gift "Подарок отправлен"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 401 "Не залогинен"
+// @Failure 403 "Пользователь заблокирован"
+// @Failure 404 "Пользователь или скин не найден"
+// @Failure 409 "У получателя уже есть этот скин"
+// @Failure 422 "Невалидный подарок, недостаточно средств"
+// @Failure 429 "Превышен дневной лимит подарков"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/gifts [POST]
+func postGift(w http.ResponseWriter, r *http.Request, users database.UserRepository, store database.StoreRepository) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s := &models.SendGift{}
+	err := unmarshalJSONBodyToStruct(r, s)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if (s.SkinID == 0) == (s.Coins == 0) || s.Coins < 0 ||
+		len([]rune(s.Message)) > maxGiftMessageLength {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	toID, err := resolveUserID(r.Context(), users, s.ID, s.Nickname)
+	if err != nil {
+		sendGiftError(w, r, err)
+		return
+	}
+	if toID == 0 || toID == uID {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	}
+
+	g := &models.Gift{
+		FromID:  uID,
+		ToID:    toID,
+		Coins:   s.Coins,
+		Message: s.Message,
+	}
+	if s.SkinID != 0 {
+		g.SkinID = &s.SkinID
+	}
+	err = store.SendGift(r.Context(), g)
+	if err != nil {
+		sendGiftError(w, r, err)
+		return
+	}
+	logger.Infof("user %v sent gift %v to user %v", uID, g.ID, toID)
+
+	json, err := g.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintln(w, string(json))
+}
+
+// @Summary Принять или отклонить подарок
+// @Description Принять подарок или отклонить его, тогда монеты вернутся отправителю. Если скин уже есть, подарок отклоняется
+// @ID put-gift
+// @Accept json
+// @Param GiftAction body models.GiftAction true "ID подарка и действие: accept, decline"
+// @Success 200 "Успешно"
+// @Failure 400 "Неверный формат JSON, неизвестное действие"
+// @Failure 401 "Не залогинен"
+// @Failure 404 "Подарок не найден"
+// @Failure 409 "Скин уже есть, подарок отклонен"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/gifts [PUT]
+func putGift(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	a := &models.GiftAction{}
+	err := unmarshalJSONBodyToStruct(r, a)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	switch a.Action {
+	case models.GiftActionAccept:
+		err = store.AcceptGift(r.Context(), uID, a.ID)
+	case models.GiftActionDecline:
+		err = store.DeclineGift(r.Context(), uID, a.ID)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		sendGiftError(w, r, err)
+	}
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
+
+	"api/database"
+	"api/models"
+)
+
+const (
+	maxMatchIDLength = 64
+
+	defaultMatchHistoryLimit = 10
+	maxMatchHistoryLimit     = 50
+	maxEmbeddedMatches       = 10
+)
+
+func MatchHandler(scoreboard database.ScoreboardRepository, serviceToken string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			postMatch(w, r, scoreboard, serviceToken)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func validateMatchResult(m *models.MatchResult) error {
+	if m.MatchID == "" || len(m.MatchID) > maxMatchIDLength {
+		return fmt.Errorf("Невалидный ID матча")
+	}
+	if len(m.Players) == 0 {
+		return fmt.Errorf("Нет игроков")
+	}
+	seen := make(map[uint]bool, len(m.Players))
+	for _, p := range m.Players {
+		if seen[p.UserID] {
+			return fmt.Errorf("Игрок %v указан несколько раз", p.UserID)
+		}
+		seen[p.UserID] = true
+		switch p.Outcome {
+		case models.OutcomeWin, models.OutcomeDraw, models.OutcomeLoss:
+		default:
+			return fmt.Errorf("Невалидный результат игрока %v", p.UserID)
+		}
+		if p.Score < 0 || p.Coins < 0 {
+			return fmt.Errorf("Отрицательные очки или монеты у игрока %v", p.UserID)
+		}
+	}
+
+	return nil
+}
+
+// @Summary Сохранить результат матча
+// @Description Сохранить результат завершенного матча: победы/ничьи/поражения, рекорд и монеты игроков. Только для игрового сервера. Повторная отправка матча с тем же ID ничего не меняет
+// @ID post-match
+// @Accept json
+// @Param Authorization header string true "Bearer <токен сервиса>"
+// @Param MatchResult body models.MatchResult true "Результат матча"
+// @Success 200 "Результат сохранен (или уже был сохранен)"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 401 "Неверный токен сервиса"
+// @Failure 404 "Игрок не найден"
+// @Failure 422 "Невалидный результат матча"
+// @Failure 500 "Ошибка в бд"
+// @Router /matches [POST]
+func postMatch(w http.ResponseWriter, r *http.Request, scoreboard database.ScoreboardRepository,
+	serviceToken string) {
+	if !isServiceRequest(r, serviceToken) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	m := &models.MatchResult{}
+	err := unmarshalJSONBodyToStruct(r, m)
+	if err != nil {
+		switch err.(type) {
+		case ParseJSONError:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			logger.Error(err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	err = validateMatchResult(m)
+	if err != nil {
+		sendError(w, err, http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = scoreboard.SaveMatchResult(r.Context(), m)
+	if err != nil {
+		if err == database.ErrMatchAlreadyReported {
+			logger.Infof("match %v has already been reported", m.MatchID)
+			return
+		}
+		switch err.(type) {
+		case database.UserNotFoundError:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			logger.Errorf("database error while saving match %v: %v", m.MatchID, err)
+			w.WriteHeader(dbErrorStatus(r, err))
+		}
+		return
+	}
+	logger.Infof("match %v with %v players saved", m.MatchID, len(m.Players))
+}
+
+func MatchHistoryHandler(users database.UserRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getMatchHistory(w, r, users)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func parseMatchCursor(s string) (*models.MatchCursor, error) {
+	parts := strings.SplitN(s, ",", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, fmt.Errorf("invalid match cursor: %v", s)
+	}
+	ns, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MatchCursor{
+		PlayedAt: time.Unix(0, ns),
+		MatchID:  parts[1],
+	}, nil
+}
+
+func formatMatchCursor(m *models.MatchHistoryEntry) string {
+	return strconv.FormatInt(m.PlayedAt.UnixNano(), 10) + "," + m.MatchID
+}
+
+// @Summary Получить историю матчей
+// @Description Получить историю матчей игрока по ID или из сессии, сначала новые (пагинация по курсору)
+// @ID get-profile-matches
+// @Produce json
+// @Param id query uint false "ID"
+// @Param limit query uint false "Матчей на страницу"
+// @Param before query string false "Курсор: поле next из предыдущей страницы"
+// @Success 200 {object} models.MatchHistory "Матчи и курсор следующей страницы"
+// @Failure 400 "Неправильный запрос"
+// @Failure 401 "Не залогинен"
+// @Failure 500 "Ошибка в бд"
+// @Router /profile/matches [GET]
+func getMatchHistory(w http.ResponseWriter, r *http.Request, users database.UserRepository) {
+	query := r.URL.Query()
+	rawID := query.Get("id")
+	var id uint64
+	var err error
+	if rawID != "" {
+		id, err = strconv.ParseUint(rawID, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	if id == 0 {
+		if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		id = uint64(r.Context().Value(middleware.KeyUserID).(uint))
+	}
+
+	rawLimit := query.Get("limit")
+	var limit uint64
+	if rawLimit != "" {
+		limit, err = strconv.ParseUint(rawLimit, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	if limit == 0 {
+		limit = defaultMatchHistoryLimit
+	}
+	if limit > maxMatchHistoryLimit {
+		limit = maxMatchHistoryLimit
+	}
+	var before *models.MatchCursor
+	if rawBefore := query.Get("before"); rawBefore != "" {
+		before, err = parseMatchCursor(rawBefore)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	matches, err := users.GetUserMatchesPaginated(r.Context(), uint(id), limit, before)
+	if err != nil {
+		logger.Errorf("database error while getting matches of user %v: %v", id, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	history := models.MatchHistory{
+		Matches: *matches,
+	}
+	if uint64(len(*matches)) == limit {
+		history.Next = formatMatchCursor(&(*matches)[len(*matches)-1])
+	}
+
+	json, err := history.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
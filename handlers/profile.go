@@ -1,24 +1,28 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/asaskevich/govalidator"
-	"golang.org/x/crypto/bcrypt"
 
 	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
-	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/session"
 
+	"api/auth"
 	"api/database"
 	"api/filesystem"
 	"api/models"
 )
 
-func validateNickname(dm *db.DatabaseManager, s string) ([]models.ProfileError, error) {
+// MaxAvatarMemory is how much of the uploaded avatar is kept in memory, the rest is stored on disk
+const MaxAvatarMemory = 5 << 20 // 5 MB
+
+func validateNickname(ctx context.Context, users database.UserRepository, s string) ([]models.ProfileError, error) {
 	var errors []models.ProfileError
 
 	isValid := govalidator.StringLength(s, "4", "20")
@@ -30,7 +34,7 @@ func validateNickname(dm *db.DatabaseManager, s string) ([]models.ProfileError,
 		return errors, nil
 	}
 
-	exists, err := database.CheckExistenceOfNickname(dm, s)
+	exists, err := users.CheckExistenceOfNickname(ctx, s)
 	if err != nil {
 		logger.Error(err)
 		return errors, err
@@ -45,7 +49,7 @@ func validateNickname(dm *db.DatabaseManager, s string) ([]models.ProfileError,
 	return errors, nil
 }
 
-func validateEmail(dm *db.DatabaseManager, s string) ([]models.ProfileError, error) {
+func validateEmail(ctx context.Context, users database.UserRepository, s string) ([]models.ProfileError, error) {
 	var errors []models.ProfileError
 
 	isValid := govalidator.IsEmail(s)
@@ -57,7 +61,7 @@ func validateEmail(dm *db.DatabaseManager, s string) ([]models.ProfileError, err
 		return errors, nil
 	}
 
-	exists, err := database.CheckExistenceOfEmail(dm, s)
+	exists, err := users.CheckExistenceOfEmail(ctx, s)
 	if err != nil {
 		logger.Error(err)
 		return errors, err
@@ -86,16 +90,17 @@ func validatePassword(s string) []models.ProfileError {
 	return errors
 }
 
-func validateFields(dm *db.DatabaseManager, u *models.RegisterProfile) ([]models.ProfileError, error) {
+func validateFields(ctx context.Context, users database.UserRepository, u *models.RegisterProfile) (
+	[]models.ProfileError, error) {
 	var errors []models.ProfileError
 
-	valErrors, dbErr := validateNickname(dm, u.Nickname)
+	valErrors, dbErr := validateNickname(ctx, users, u.Nickname)
 	if dbErr != nil {
 		return []models.ProfileError{}, dbErr
 	}
 	errors = append(errors, valErrors...)
 
-	valErrors, dbErr = validateEmail(dm, u.Email)
+	valErrors, dbErr = validateEmail(ctx, users, u.Email)
 	if dbErr != nil {
 		return []models.ProfileError{}, dbErr
 	}
@@ -105,36 +110,81 @@ func validateFields(dm *db.DatabaseManager, u *models.RegisterProfile) ([]models
 	return errors, nil
 }
 
-func hashAndSalt(password string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+// validateCurrentPassword checks the password the user must confirm the change of email or password with,
+// the guesses are throttled like the logins of the account, wait is how long the next one has to wait
+func validateCurrentPassword(r *http.Request, users database.UserRepository, h *auth.PasswordHasher,
+	lt *auth.LoginThrottle, uID uint, s string) ([]models.ProfileError, time.Duration, error) {
+	var errors []models.ProfileError
+
+	if s == "" {
+		errors = append(errors, models.ProfileError{
+			Field: "current_password",
+			Text:  "Current password is required to change email or password.",
+		})
+		return errors, 0, nil
+	}
+
+	profile, err := users.GetUserProfileByID(r.Context(), uID, true)
+	if err != nil {
+		return errors, 0, err
+	}
+	ip := clientIP(r)
+	wait, err := lt.Reserve(r.Context(), profile.Email, ip)
+	if err != nil || wait > 0 {
+		return errors, wait, err
+	}
+	u, err := users.GetUserPassword(r.Context(), profile.Email)
+	if err != nil {
+		releaseLogin(r, lt, profile.Email, ip)
+		return errors, 0, err
+	}
+	ok, _, err := h.Compare(u.Password, s)
 	if err != nil {
-		return "", err
+		releaseLogin(r, lt, profile.Email, ip)
+		return errors, 0, err
+	}
+	if !ok {
+		lt.Fail()
+		errors = append(errors, models.ProfileError{
+			Field: "current_password",
+			Text:  "Wrong current password.",
+		})
+		return errors, 0, nil
+	}
+	err = lt.Succeed(r.Context(), profile.Email, ip)
+	if err != nil {
+		logger.Errorf("database error while resetting login failures of user %v: %v", uID, err)
 	}
 
-	return string(hash), err
+	return errors, 0, nil
 }
 
-func comparePasswords(hashed string, clean string) (bool, error) {
-	err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(clean))
-	switch err {
-	case nil:
-		return true, nil
-	case bcrypt.ErrMismatchedHashAndPassword:
-		return false, nil
-	default:
-		return false, err
+func embedLastMatches(ctx context.Context, users database.UserRepository, p *models.Profile, n uint64) error {
+	if n == 0 {
+		return nil
+	}
+	if n > maxEmbeddedMatches {
+		n = maxEmbeddedMatches
 	}
+	matches, err := users.GetUserMatchesPaginated(ctx, p.UserID, n, nil)
+	if err != nil {
+		return err
+	}
+	p.LastMatches = *matches
+
+	return nil
 }
 
-func ProfileHandler(dm *db.DatabaseManager, sm *session.SessionManager) http.HandlerFunc {
+func ProfileHandler(users database.UserRepository, sm auth.SessionManager, h *auth.PasswordHasher,
+	lt *auth.LoginThrottle, l *Letters) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
-			getProfile(w, r, dm)
+			getProfile(w, r, users)
 		case http.MethodPost:
-			postProfile(w, r, dm, sm)
+			postProfile(w, r, users, sm, h, l)
 		case http.MethodPut:
-			putProfile(w, r, dm)
+			putProfile(w, r, users, sm, h, lt, l)
 		default:
 			w.WriteHeader(http.StatusMethodNotAllowed)
 		}
@@ -147,13 +197,14 @@ func ProfileHandler(dm *db.DatabaseManager, sm *session.SessionManager) http.Han
 // @Produce json
 // @Param id query uint false "ID"
 // @Param nickname query string false "Никнейм"
+// @Param matches query uint false "Сколько последних матчей добавить в профиль по ID или никнейму"
 // @Success 200 {object} models.Profile "Пользователь найден, успешно"
 // @Failure 400 "Неправильный запрос"
 // @Failure 401 "Не залогинен"
 // @Failure 404 "Не найдено"
 // @Failure 500 "Ошибка в бд"
 // @Router /profile [GET]
-func getProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
+func getProfile(w http.ResponseWriter, r *http.Request, users database.UserRepository) {
 	query := r.URL.Query()
 	rawID := query.Get("id")
 	var id uint64
@@ -165,8 +216,17 @@ func getProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 			return
 		}
 	}
+	rawMatches := query.Get("matches")
+	var matchesCount uint64
+	if rawMatches != "" {
+		matchesCount, err = strconv.ParseUint(rawMatches, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
 	if id != 0 {
-		profile, err := database.GetUserProfileByID(dm, uint(id), false)
+		profile, err := users.GetUserProfileByID(r.Context(), uint(id), false)
 		if err != nil {
 			switch err.(type) {
 			case database.UserNotFoundError:
@@ -174,11 +234,18 @@ func getProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 				return
 			default:
 				logger.Error(err)
-				w.WriteHeader(http.StatusInternalServerError)
+				w.WriteHeader(dbErrorStatus(r, err))
 				return
 			}
 		}
 
+		err = embedLastMatches(r.Context(), users, profile, matchesCount)
+		if err != nil {
+			logger.Error(err)
+			w.WriteHeader(dbErrorStatus(r, err))
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		json, err := profile.MarshalJSON()
 		if err != nil {
@@ -191,7 +258,7 @@ func getProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 	}
 	nickname := query.Get("nickname")
 	if nickname != "" {
-		profile, err := database.GetUserProfileByNickname(dm, nickname)
+		profile, err := users.GetUserProfileByNickname(r.Context(), nickname)
 		if err != nil {
 			switch err.(type) {
 			case database.UserNotFoundError:
@@ -199,11 +266,18 @@ func getProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 				return
 			default:
 				logger.Error(err)
-				w.WriteHeader(http.StatusInternalServerError)
+				w.WriteHeader(dbErrorStatus(r, err))
 				return
 			}
 		}
 
+		err = embedLastMatches(r.Context(), users, profile, matchesCount)
+		if err != nil {
+			logger.Error(err)
+			w.WriteHeader(dbErrorStatus(r, err))
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		json, err := profile.MarshalJSON()
 		if err != nil {
@@ -219,7 +293,7 @@ func getProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 		w.WriteHeader(http.StatusUnauthorized)
 		return
 	}
-	profile, err := database.GetUserProfileByID(dm, r.Context().Value(middleware.KeyUserID).(uint), true)
+	profile, err := users.GetUserProfileByID(r.Context(), r.Context().Value(middleware.KeyUserID).(uint), true)
 	if err != nil {
 		switch err.(type) {
 		case database.UserNotFoundError:
@@ -227,7 +301,7 @@ func getProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 			return
 		default:
 			logger.Error(err)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(dbErrorStatus(r, err))
 			return
 		}
 	}
@@ -254,7 +328,8 @@ func getProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 // @Failure 422 "При регистрации не все параметры"
 // @Failure 500 "Ошибка в бд"
 // @Router /profile [POST]
-func postProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager, sm *session.SessionManager) {
+func postProfile(w http.ResponseWriter, r *http.Request, users database.UserRepository, sm auth.SessionManager,
+	h *auth.PasswordHasher, l *Letters) {
 	u := &models.RegisterProfile{}
 	err := unmarshalJSONBodyToStruct(r, u)
 	if err != nil {
@@ -272,9 +347,9 @@ func postProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager,
 		return
 	}
 
-	fieldErrors, err := validateFields(dm, u)
+	fieldErrors, err := validateFields(r.Context(), users, u)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(dbErrorStatus(r, err))
 		return
 	}
 
@@ -291,13 +366,13 @@ func postProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager,
 		w.WriteHeader(http.StatusForbidden)
 		fmt.Fprintln(w, string(json))
 	} else {
-		u.Password, err = hashAndSalt(u.Password)
+		u.Password, err = h.Hash(u.Password)
 		if err != nil {
 			logger.Errorf("hash and salt password error: %v", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
-		newU, err := database.CreateNewUser(dm, u)
+		newU, err := users.CreateNewUser(r.Context(), u)
 		if err != nil {
 			if err == db.ErrUniqueConstraintViolation ||
 				err == db.ErrNotNullConstraintViolation {
@@ -305,39 +380,44 @@ func postProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager,
 				return
 			}
 			logger.Error(err)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(dbErrorStatus(r, err))
 			return
 		}
 
-		err = loginUser(w, sm, newU.UserID)
+		err = loginUser(w, r, sm, newU.UserID)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 		logger.Infof("New user with id %v, email %v and nickname %v logged in", newU.UserID, newU.Email, newU.Nickname)
+
+		l.sendVerification(r, users, newU.UserID, newU.Email)
 	}
 }
 
 // @Summary Изменить профиль
-// @Description Изменить профиль, должен быть залогинен
+// @Description Изменить профиль, должен быть залогинен. Для смены почты или пароля нужен текущий пароль. Новую почту нужно подтвердить, письмо отправляется на нее. После смены пароля все остальные сессии завершаются
 // @ID put-profile
 // @Accept json
 // @Produce json
-// @Param Profile body models.RegisterProfile true "Новые никнейм, и/или почта, и/или пароль"
+// @Param Profile body models.ProfileUpdate true "Новые никнейм, и/или почта, и/или пароль, текущий пароль"
 // @Success 200 "Пользователь найден, успешно изменены данные"
 // @Failure 400 "Неверный формат JSON"
 // @Failure 401 "Не залогинен"
-// @Failure 403 {object} models.ProfileErrorList "Ошибки при регистрации: невалидна или занята почта, занят ник, пароль не удовлетворяет правилам безопасности, другие ошибки"
+// @Failure 403 {object} models.ProfileErrorList "Ошибки при регистрации: невалидна или занята почта, занят ник, пароль не удовлетворяет правилам безопасности, неверный текущий пароль, другие ошибки"
+// @Failure 429 "Слишком много неверных паролей для пользователя или адреса, в Retry-After сколько секунд ждать"
 // @Failure 500 "Ошибка в бд"
 // @Router /profile [PUT]
-func putProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
+func putProfile(w http.ResponseWriter, r *http.Request, users database.UserRepository, sm auth.SessionManager,
+	h *auth.PasswordHasher, lt *auth.LoginThrottle, l *Letters) {
 	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
 		w.WriteHeader(http.StatusUnauthorized)
 		return
 	}
+	id := r.Context().Value(middleware.KeyUserID).(uint)
 
-	u := &models.RegisterProfile{}
-	err := unmarshalJSONBodyToStruct(r, u)
+	update := &models.ProfileUpdate{}
+	err := unmarshalJSONBodyToStruct(r, update)
 	if err != nil {
 		switch err.(type) {
 		case ParseJSONError:
@@ -347,6 +427,7 @@ func putProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 		}
 		return
 	}
+	u := &update.RegisterProfile
 
 	if u.Nickname == "" && u.Email == "" && u.Password == "" {
 		return
@@ -354,20 +435,40 @@ func putProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 
 	var fieldErrors []models.ProfileError
 
+	if u.Email != "" || u.Password != "" {
+		valErrors, wait, dbErr := validateCurrentPassword(r, users, h, lt, id, update.CurrentPassword)
+		if wait > 0 {
+			logger.Infof("current password check of user %v is throttled for %v", id, wait)
+			sendThrottled(w, wait)
+			return
+		}
+		if dbErr != nil {
+			switch dbErr.(type) {
+			case database.UserNotFoundError:
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				logger.Error(dbErr)
+				w.WriteHeader(dbErrorStatus(r, dbErr))
+			}
+			return
+		}
+		fieldErrors = append(fieldErrors, valErrors...)
+	}
+
 	if u.Nickname != "" {
-		valErrors, dbErr := validateNickname(dm, u.Nickname)
+		valErrors, dbErr := validateNickname(r.Context(), users, u.Nickname)
 		if dbErr != nil {
 			logger.Error(dbErr)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(dbErrorStatus(r, dbErr))
 			return
 		}
 		fieldErrors = append(fieldErrors, valErrors...)
 	}
 	if u.Email != "" {
-		valErrors, dbErr := validateEmail(dm, u.Email)
+		valErrors, dbErr := validateEmail(r.Context(), users, u.Email)
 		if dbErr != nil {
 			logger.Error(dbErr)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(dbErrorStatus(r, dbErr))
 			return
 		}
 		fieldErrors = append(fieldErrors, valErrors...)
@@ -389,29 +490,47 @@ func putProfile(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 		w.WriteHeader(http.StatusForbidden)
 		fmt.Fprintln(w, string(json))
 	} else {
-		id := r.Context().Value(middleware.KeyUserID).(uint)
-		err := database.UpdateUserByID(dm, id, u)
+		if u.Password != "" {
+			u.Password, err = h.Hash(u.Password)
+			if err != nil {
+				logger.Errorf("hash and salt password error: %v", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+		err := users.UpdateUserByID(r.Context(), id, u)
 		if err != nil {
 			switch err.(type) {
 			case database.UserNotFoundError:
 				w.WriteHeader(http.StatusNotFound)
 			default:
 				logger.Error(err)
-				w.WriteHeader(http.StatusInternalServerError)
+				w.WriteHeader(dbErrorStatus(r, err))
 			}
 			return
 		}
 		logger.Infof("user with id %v changed to %v %v", id, u.Nickname, u.Email)
+
+		if u.Password != "" {
+			// the stolen session must not outlive the password
+			err = sm.DeleteUserSessions(id, r.Context().Value(middleware.KeySessionID).(string))
+			if err != nil {
+				logger.Errorf("error while deleting other sessions of user %v: %v", id, err)
+			}
+		}
+		if u.Email != "" {
+			l.sendVerification(r, users, id, u.Email)
+		}
 	}
 }
 
-func AvatarHandler(dm *db.DatabaseManager) http.HandlerFunc {
+func AvatarHandler(users database.UserRepository) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodPut:
-			putAvatar(w, r, dm)
+			putAvatar(w, r, users)
 		case http.MethodDelete:
-			deleteAvatar(w, r, dm)
+			deleteAvatar(w, r, users)
 		default:
 			w.WriteHeader(http.StatusMethodNotAllowed)
 		}
@@ -427,13 +546,13 @@ func AvatarHandler(dm *db.DatabaseManager) http.HandlerFunc {
 // @Failure 404 "Пользователь не найден"
 // @Failure 500 "Ошибка при парсинге, в бд, файловой системе"
 // @Router /profile/avatar [PUT]
-func putAvatar(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
+func putAvatar(w http.ResponseWriter, r *http.Request, users database.UserRepository) {
 	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
 		w.WriteHeader(http.StatusUnauthorized)
 		return
 	}
 
-	err := r.ParseMultipartForm(5 * (1 << 20)) // 5 MB
+	err := r.ParseMultipartForm(MaxAvatarMemory)
 	if err != nil {
 		if err == http.ErrNotMultipart || err == http.ErrMissingBoundary {
 			w.WriteHeader(http.StatusBadRequest)
@@ -466,14 +585,14 @@ func putAvatar(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
 		return
 	}
 
-	err = database.UploadAvatar(dm, uID, "/"+dir+filename)
+	err = users.UploadAvatar(r.Context(), uID, "/"+dir+filename)
 	if err != nil {
 		switch err.(type) {
 		case *database.UserNotFoundError:
 			w.WriteHeader(http.StatusNotFound)
 		default:
 			logger.Error(err)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(dbErrorStatus(r, err))
 		}
 		return
 	}
@@ -487,35 +606,35 @@ func putAvatar(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
 // @Failure 404 "Пользователь не найден"
 // @Failure 500 "Ошибка в бд"
 // @Router /profile/avatar [DELETE]
-func deleteAvatar(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
+func deleteAvatar(w http.ResponseWriter, r *http.Request, users database.UserRepository) {
 	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
 		w.WriteHeader(http.StatusUnauthorized)
 		return
 	}
 
-	err := database.DeleteAvatar(dm, r.Context().Value(middleware.KeyUserID).(uint))
+	err := users.DeleteAvatar(r.Context(), r.Context().Value(middleware.KeyUserID).(uint))
 	if err != nil {
 		switch err.(type) {
 		case *database.UserNotFoundError:
 			w.WriteHeader(http.StatusNotFound)
 		default:
 			logger.Error(err)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(dbErrorStatus(r, err))
 		}
 		return
 	}
 }
 
-func CheckAvailabilityHandler(dm *db.DatabaseManager) http.HandlerFunc {
+func CheckAvailabilityHandler(users database.UserRepository) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet {
 			query := r.URL.Query()
 			nickname := query.Get("nickname")
 			if nickname != "" {
-				exists, err := database.CheckExistenceOfNickname(dm, nickname)
+				exists, err := users.CheckExistenceOfNickname(r.Context(), nickname)
 				if err != nil {
 					logger.Errorf("check availability error: %v", err)
-					w.WriteHeader(http.StatusInternalServerError)
+					w.WriteHeader(dbErrorStatus(r, err))
 					return
 				}
 				if exists {
@@ -525,10 +644,10 @@ func CheckAvailabilityHandler(dm *db.DatabaseManager) http.HandlerFunc {
 			}
 			email := query.Get("email")
 			if email != "" {
-				exists, err := database.CheckExistenceOfEmail(dm, email)
+				exists, err := users.CheckExistenceOfEmail(r.Context(), email)
 				if err != nil {
 					logger.Errorf("check availability error: %v", err)
-					w.WriteHeader(http.StatusInternalServerError)
+					w.WriteHeader(dbErrorStatus(r, err))
 					return
 				}
 				if exists {
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// RealIPMiddleware takes the address of the client from the header set by the trusted proxy,
+// the header is ignored if it is empty as the clients could set it themselves
+func RealIPMiddleware(next http.Handler, header string) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// X-Forwarded-For has the proxies after the client
+		ip := strings.TrimSpace(strings.Split(r.Header.Get(header), ",")[0])
+		if net.ParseIP(ip) != nil {
+			r.RemoteAddr = net.JoinHostPort(ip, "0")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIP is the address of the client without the port
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -4,77 +4,260 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
-	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
 
 	"api/database"
 	"api/models"
 )
 
+const (
+	scopeFriends = "friends"
+
+	defaultScoreboardNeighbours = 5
+	maxScoreboardNeighbours     = 50
+
+	// the pages skip the players before them, the deeper ones are read by the cursor
+	maxScoreboardOffset = 10000
+)
+
+func parseScoreboardCursor(s string) (*models.ScoreboardCursor, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid scoreboard cursor: %v", s)
+	}
+	// the columns are integer
+	record, err := strconv.ParseInt(parts[0], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	uID, err := strconv.ParseUint(parts[1], 10, 31)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ScoreboardCursor{
+		Record: int(record),
+		UserID: uint(uID),
+	}, nil
+}
+
+func formatScoreboardCursor(p *models.Position) string {
+	return strconv.Itoa(p.Points) + "," + strconv.FormatUint(uint64(p.ID), 10)
+}
+
+// periodBounds returns the UTC day or the ISO week containing now
+func periodBounds(period string, now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if period == models.PeriodDaily {
+		return from, from.AddDate(0, 0, 1)
+	}
+	// time.Sunday is 0, weeks start on Monday
+	from = from.AddDate(0, 0, -(int(from.Weekday())+6)%7)
+
+	return from, from.AddDate(0, 0, 7)
+}
+
+func ScoreboardHandler(scoreboard database.ScoreboardRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getScoreboard(w, r, scoreboard)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
 // @Summary Получить таблицу лидеров
-// @Description Получить таблицу лидеров (пагинация присутствует)
+// @Description Получить таблицу лидеров с местами игроков за все время (пагинация по номеру страницы или по курсору) или за период по очкам в матчах. По номеру страницы доступны первые 10000 мест, дальше только по курсору
 // @ID get-scoreboard
 // @Produce json
 // @Param Limit query uint false "Пользователей на страницу"
 // @Param Page query uint false "Страница номер"
+// @Param after query string false "Курсор <record,user_id>: поле next из предыдущей страницы"
+// @Param around query string false "me или ID игрока: место игрока и его соседи сверху и снизу"
+// @Param k query uint false "Количество соседей с каждой стороны для around"
+// @Param period query string false "all (по умолчанию), daily, weekly или season"
+// @Param season query string false "Название сезона для period=season, по умолчанию текущий"
+// @Param scope query string false "friends: только игрок и его друзья"
 // @Success 200 {object} models.PositionList "Таблицу лидеров или ее страница и общее количество"
+// @Failure 400 "Неправильный запрос или слишком далекая страница"
+// @Failure 401 "Не залогинен (для around=me и scope=friends)"
+// @Failure 404 "Игрок или сезон не найден"
 // @Failure 500 "Ошибка в бд"
 // @Router /scoreboard [GET]
-func ScoreboardHandler(dm *db.DatabaseManager) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			query := r.URL.Query()
-			rawLimit := query.Get("limit")
-			var limit uint64
-			var err error
-			if rawLimit != "" {
-				limit, err = strconv.ParseUint(rawLimit, 10, 64)
-				if err != nil {
-					w.WriteHeader(http.StatusBadRequest)
-					return
-				}
-			}
-			// default limit value
-			if limit == 0 {
-				limit = 5
-			}
-			// limit the limit value
-			if limit > 100 {
-				limit = 100
-			}
-			rawPage := query.Get("page")
-			var page uint64
-			if rawPage != "" {
-				page, err = strconv.ParseUint(rawPage, 10, 64)
-				if err != nil {
-					w.WriteHeader(http.StatusBadRequest)
-					return
-				}
+func getScoreboard(w http.ResponseWriter, r *http.Request, scoreboard database.ScoreboardRepository) {
+	query := r.URL.Query()
+	rawLimit := query.Get("limit")
+	var limit uint64
+	var err error
+	if rawLimit != "" {
+		limit, err = strconv.ParseUint(rawLimit, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	// default limit value
+	if limit == 0 {
+		limit = 5
+	}
+	// limit the limit value
+	if limit > 100 {
+		limit = 100
+	}
+	rawPage := query.Get("page")
+	var page uint64
+	if rawPage != "" {
+		page, err = strconv.ParseUint(rawPage, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	// limit*page fits any integer then
+	if page > maxScoreboardOffset/limit {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var after *models.ScoreboardCursor
+	if rawAfter := query.Get("after"); rawAfter != "" {
+		after, err = parseScoreboardCursor(rawAfter)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	var aroundID uint64
+	if rawAround := query.Get("around"); rawAround != "" {
+		if rawAround == "me" {
+			if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
 			}
-			records, total, err := database.GetUserPositionsDescendingPaginated(
-				dm, limit, page)
+			aroundID = uint64(r.Context().Value(middleware.KeyUserID).(uint))
+		} else {
+			aroundID, err = strconv.ParseUint(rawAround, 10, 31)
 			if err != nil {
-				logger.Error(err)
-				w.WriteHeader(http.StatusInternalServerError)
+				w.WriteHeader(http.StatusBadRequest)
 				return
 			}
+		}
+	}
+	rawK := query.Get("k")
+	var k uint64
+	if rawK != "" {
+		k, err = strconv.ParseUint(rawK, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	if k == 0 {
+		k = defaultScoreboardNeighbours
+	}
+	if k > maxScoreboardNeighbours {
+		k = maxScoreboardNeighbours
+	}
 
-			positionsList := models.PositionList{
-				List:  *records,
-				Total: total,
-			}
-			json, err := positionsList.MarshalJSON()
-			if err != nil {
-				logger.Error(err)
-				w.WriteHeader(http.StatusInternalServerError)
-				return
+	period := query.Get("period")
+	if period != "" && period != models.PeriodAll && (after != nil || aroundID != 0) {
+		// periods are paginated by page only
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	scope := query.Get("scope")
+	if scope != "" && scope != scopeFriends {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if scope == scopeFriends {
+		if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		// friends are paginated by page only
+		if period != "" && period != models.PeriodAll || after != nil || aroundID != 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	var records *[]models.Position
+	var total int
+	switch period {
+	case "", models.PeriodAll:
+		switch {
+		case scope == scopeFriends:
+			records, total, err = scoreboard.GetFriendsPositionsPaginated(
+				r.Context(), r.Context().Value(middleware.KeyUserID).(uint), limit, page)
+		case aroundID != 0:
+			records, total, err = scoreboard.GetUserPositionsAround(r.Context(), uint(aroundID), k)
+		case after != nil:
+			records, total, err = scoreboard.GetUserPositionsDescendingAfter(r.Context(), limit, after)
+		default:
+			records, total, err = scoreboard.GetUserPositionsDescendingPaginated(r.Context(), limit, page)
+		}
+	case models.PeriodDaily, models.PeriodWeekly:
+		from, to := periodBounds(period, time.Now())
+		records, total, err = scoreboard.GetPeriodPositionsPaginated(r.Context(), from, to, limit, page)
+	case models.PeriodSeason:
+		var season *models.Season
+		if name := query.Get("season"); name != "" {
+			season, err = scoreboard.GetSeasonByName(r.Context(), name)
+		} else {
+			season, err = scoreboard.GetCurrentSeason(r.Context())
+		}
+		if err == nil {
+			if season.Archived {
+				records, total, err = scoreboard.GetSeasonStandingsPaginated(r.Context(), season.ID, limit, page)
+			} else {
+				records, total, err = scoreboard.GetPeriodPositionsPaginated(
+					r.Context(), season.StartsAt, season.EndsAt, limit, page)
 			}
-			w.Header().Set("Content-Type", "application/json")
-			fmt.Fprintln(w, string(json))
+		}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		if err == database.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch err.(type) {
+		case database.UserNotFoundError:
+			w.WriteHeader(http.StatusNotFound)
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			logger.Error(err)
+			w.WriteHeader(dbErrorStatus(r, err))
 		}
+		return
+	}
+
+	positionsList := models.PositionList{
+		List:  *records,
+		Total: total,
+	}
+	// cursors are only valid for the all-time scoreboard of all players,
+	// the page around the player is continued downwards with the same cursor
+	if (period == "" || period == models.PeriodAll) && scope == "" &&
+		(uint64(len(*records)) == limit || aroundID != 0 && len(*records) != 0) {
+		positionsList.Next = formatScoreboardCursor(&(*records)[len(*records)-1])
+	}
+	json, err := positionsList.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
 }
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+
+	"api/database"
+	"api/models"
+)
+
+func SeasonHandler(scoreboard database.ScoreboardRepository, serviceToken string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getSeasons(w, r, scoreboard)
+		case http.MethodPost:
+			postSeason(w, r, scoreboard, serviceToken)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// @Summary Получить список сезонов
+// @Description Получить все сезоны, сначала новые. Таблица лидеров сезона: /scoreboard?period=season&season=<name>
+// @ID get-seasons
+// @Produce json
+// @Success 200 {object} models.SeasonList "Сезоны"
+// @Failure 500 "Ошибка в бд"
+// @Router /scoreboard/seasons [GET]
+func getSeasons(w http.ResponseWriter, r *http.Request, scoreboard database.ScoreboardRepository) {
+	seasons, err := scoreboard.GetAllSeasons(r.Context())
+	if err != nil {
+		logger.Errorf("database error while getting seasons: %v", err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+
+	list := models.SeasonList{Seasons: *seasons}
+	json, err := list.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
+
+// @Summary Создать сезон
+// @Description Создать сезон с названием и датами начала и конца. После конца итоговая таблица сезона сохраняется
+// @ID post-season
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer <токен сервиса>"
+// @Param Season body models.Season true "Название, начало и конец сезона"
+// @Success 200 {object} models.Season "Сезон создан"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 401 "Неверный токен сервиса"
+// @Failure 409 "Сезон с таким названием уже есть"
+// @Failure 422 "Нет названия или конец сезона раньше начала"
+// @Failure 500 "Ошибка в бд"
+// @Router /scoreboard/seasons [POST]
+func postSeason(w http.ResponseWriter, r *http.Request, scoreboard database.ScoreboardRepository, serviceToken string) {
+	if !isServiceRequest(r, serviceToken) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s := &models.Season{}
+	err := unmarshalJSONBodyToStruct(r, s)
+	if err != nil {
+		switch err.(type) {
+		case ParseJSONError:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			logger.Error(err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	if s.Name == "" || !s.StartsAt.Before(s.EndsAt) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = scoreboard.CreateSeason(r.Context(), s)
+	if err != nil {
+		if err == db.ErrUniqueConstraintViolation {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		logger.Errorf("database error while creating season %v: %v", s.Name, err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	logger.Infof("season %v from %v to %v created", s.Name, s.StartsAt, s.EndsAt)
+
+	json, err := s.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
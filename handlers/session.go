@@ -2,22 +2,63 @@ package handlers
 
 import (
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/asaskevich/govalidator"
 
-	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
-	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/session"
 
+	"api/auth"
 	"api/database"
 	"api/models"
 )
 
-func loginUser(w http.ResponseWriter, sm *session.SessionManager, userID uint) error {
-	sessionID, err := sm.Create(userID)
+const (
+	allSessionsPath = "/session/all"
+	maxUserAgentLen = 512
+)
+
+// devices are checked in order, iPhone and Android user agents have Mac OS and Linux too
+var devices = []struct {
+	marker string
+	name   string
+}{
+	{"iPad", "iPad"},
+	{"iPhone", "iPhone"},
+	{"Android", "Android"},
+	{"Windows", "Windows"},
+	{"CrOS", "Chromebook"},
+	{"Macintosh", "Mac"},
+	{"Linux", "Linux"},
+}
+
+func deviceName(userAgent string) string {
+	for _, d := range devices {
+		if strings.Contains(userAgent, d.marker) {
+			return d.name
+		}
+	}
+
+	return "Unknown"
+}
+
+// loginUser creates the session of the device the request is sent from
+func loginUser(w http.ResponseWriter, r *http.Request, sm auth.SessionManager, userID uint) error {
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxUserAgentLen {
+		userAgent = userAgent[:maxUserAgentLen]
+	}
+	sessionID, err := sm.CreateSession(&models.SessionInfo{
+		UserID:    userID,
+		Device:    deviceName(userAgent),
+		UserAgent: userAgent,
+		IP:        clientIP(r),
+	})
 	if err != nil {
 		logger.Error(err)
 		return err
@@ -35,13 +76,56 @@ func loginUser(w http.ResponseWriter, sm *session.SessionManager, userID uint) e
 	return nil
 }
 
-func SessionHandler(dm *db.DatabaseManager, sm *session.SessionManager) http.HandlerFunc {
+// rehashPassword upgrades the hash to the current policy while the password is known,
+// the user still logs in if it fails
+func rehashPassword(r *http.Request, users database.UserRepository, h *auth.PasswordHasher, u *models.User,
+	password string) {
+	hash, err := h.Hash(password)
+	if err != nil {
+		logger.Errorf("hash and salt password error: %v", err)
+		return
+	}
+	err = users.RehashPassword(r.Context(), u.UserID, u.Password, hash)
+	if err != nil {
+		logger.Errorf("database error while rehashing password of user %v: %v", u.UserID, err)
+		return
+	}
+	logger.Infof("password hash of user %v is upgraded to %v", u.UserID, h.Algorithm)
+}
+
+// releaseLogin takes back the reserved attempt when the password couldn't be checked
+func releaseLogin(r *http.Request, lt *auth.LoginThrottle, email, ip string) {
+	err := lt.Release(r.Context(), email, ip)
+	if err != nil {
+		logger.Errorf("database error while releasing login attempt of %v from %v: %v", email, ip, err)
+	}
+}
+
+// sendThrottled tells the client how many seconds to wait before the next attempt
+func sendThrottled(w http.ResponseWriter, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	w.WriteHeader(http.StatusTooManyRequests)
+}
+
+func SessionHandler(users database.UserRepository, sm auth.SessionManager, h *auth.PasswordHasher,
+	lt *auth.LoginThrottle) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == allSessionsPath {
+			switch r.Method {
+			case http.MethodGet:
+				getAllSessions(w, r, sm)
+			case http.MethodDelete:
+				deleteAllSessions(w, r, sm)
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+			return
+		}
 		switch r.Method {
 		case http.MethodGet:
 			getSession(w, r)
 		case http.MethodPost:
-			postSession(w, r, dm, sm)
+			postSession(w, r, users, sm, h, lt)
 		case http.MethodDelete:
 			deleteSession(w, r, sm)
 		default:
@@ -84,9 +168,11 @@ func getSession(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} models.Session "Успешный вход / пользователь уже залогинен"
 // @Failure 400 "Неверный формат JSON, невалидные данные"
 // @Failure 422 "Неверная пара пользователь/пароль"
+// @Failure 429 "Слишком много неудачных попыток для пользователя или адреса, в Retry-After сколько секунд ждать"
 // @Failure 500 "Внутренняя ошибка"
 // @Router /session [POST]
-func postSession(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager, sm *session.SessionManager) {
+func postSession(w http.ResponseWriter, r *http.Request, users database.UserRepository, sm auth.SessionManager,
+	h *auth.PasswordHasher, lt *auth.LoginThrottle) {
 	if r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
 		// user has already logged in
 		return
@@ -110,31 +196,58 @@ func postSession(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager,
 		return
 	}
 
-	dbResponse, err := database.GetUserPassword(dm, u.Email)
+	ip := clientIP(r)
+	// the attempt is counted as a failure before the compare, so the concurrent ones can't pass together
+	wait, err := lt.Reserve(r.Context(), u.Email, ip)
+	if err != nil {
+		logger.Errorf("database error while reserving login attempt: %v", err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	if wait > 0 {
+		logger.Infof("login of %v from %v is throttled for %v", u.Email, ip, wait)
+		sendThrottled(w, wait)
+		return
+	}
+
+	dbResponse, err := users.GetUserPassword(r.Context(), u.Email)
 
 	if err != nil {
 		switch err.(type) {
 		case database.UserNotFoundError:
+			// as slow as the wrong password
+			h.CompareDummy(u.Password)
+			lt.Fail()
 			w.WriteHeader(http.StatusUnprocessableEntity)
 		default:
-			w.WriteHeader(http.StatusInternalServerError)
+			releaseLogin(r, lt, u.Email, ip)
+			w.WriteHeader(dbErrorStatus(r, err))
 		}
 		return
 	}
-	passwordsMatch, err := comparePasswords(dbResponse.Password, u.Password)
+	passwordsMatch, outdated, err := h.Compare(dbResponse.Password, u.Password)
 	if err != nil {
+		releaseLogin(r, lt, u.Email, ip)
 		logger.Errorf("compare passwords error: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 	if u.Email == dbResponse.Email && passwordsMatch {
-		err := loginUser(w, sm, dbResponse.UserID)
+		if outdated {
+			rehashPassword(r, users, h, dbResponse, u.Password)
+		}
+		err = lt.Succeed(r.Context(), u.Email, ip)
+		if err != nil {
+			logger.Errorf("database error while resetting login failures of user %v: %v", dbResponse.UserID, err)
+		}
+		err := loginUser(w, r, sm, dbResponse.UserID)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 		logger.Info("user with id %v and email %v logged in", dbResponse.UserID, dbResponse.Email)
 	} else {
+		lt.Fail()
 		w.WriteHeader(http.StatusUnprocessableEntity)
 	}
 }
@@ -144,7 +257,7 @@ func postSession(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager,
 // @ID delete-session
 // @Success 200 "Успешный выход / пользователь уже разлогинен"
 // @Router /session [DELETE]
-func deleteSession(w http.ResponseWriter, r *http.Request, sm *session.SessionManager) {
+func deleteSession(w http.ResponseWriter, r *http.Request, sm auth.SessionManager) {
 	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
 		// user has already logged out
 		return
@@ -154,6 +267,10 @@ func deleteSession(w http.ResponseWriter, r *http.Request, sm *session.SessionMa
 		logger.Error(err)
 	}
 
+	deleteSessionCookie(w)
+}
+
+func deleteSessionCookie(w http.ResponseWriter) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     "session_id",
 		Expires:  time.Now().AddDate(0, 0, -1),
@@ -161,3 +278,81 @@ func deleteSession(w http.ResponseWriter, r *http.Request, sm *session.SessionMa
 		HttpOnly: true,
 	})
 }
+
+// @Summary Получить все сессии
+// @Description Получить устройства, на которых залогинен пользователь, сначала недавние. Текущая сессия отмечена
+// @ID get-session-all
+// @Produce json
+// @Success 200 {object} models.SessionList "Успешно"
+// @Failure 401 "Не залогинен"
+// @Failure 500 "Ошибка в бд или сервисе авторизации"
+// @Router /session/all [GET]
+func getAllSessions(w http.ResponseWriter, r *http.Request, sm auth.SessionManager) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	current := r.Context().Value(middleware.KeySessionID).(string)
+
+	sessions, err := sm.GetUserSessions(uID)
+	if err != nil {
+		logger.Errorf("error while getting sessions of user %v: %v", uID, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].SessionID == current
+	}
+
+	json, err := models.SessionList{Sessions: sessions}.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
+
+// @Summary Завершить все сессии
+// @Description Разлогинить пользователя на всех устройствах, с except_current=true кроме текущего
+// @ID delete-session-all
+// @Param except_current query bool false "Оставить текущую сессию"
+// @Success 200 "Сессии завершены"
+// @Failure 400 "Неправильный запрос"
+// @Failure 401 "Не залогинен"
+// @Failure 500 "Ошибка в бд или сервисе авторизации"
+// @Router /session/all [DELETE]
+func deleteAllSessions(w http.ResponseWriter, r *http.Request, sm auth.SessionManager) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	exceptCurrent := false
+	if v := r.URL.Query().Get("except_current"); v != "" {
+		var err error
+		exceptCurrent, err = strconv.ParseBool(v)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+
+	except := ""
+	if exceptCurrent {
+		except = r.Context().Value(middleware.KeySessionID).(string)
+	}
+	err := sm.DeleteUserSessions(uID, except)
+	if err != nil {
+		logger.Errorf("error while deleting sessions of user %v: %v", uID, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	logger.Infof("user %v logged out on all devices, except current: %v", uID, exceptCurrent)
+
+	if !exceptCurrent {
+		deleteSessionCookie(w)
+	}
+}
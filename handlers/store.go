@@ -5,23 +5,23 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
-	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
 
 	"api/database"
 )
 
-func SkinHandler(dm *db.DatabaseManager) http.HandlerFunc {
+func SkinHandler(store database.StoreRepository) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
-			getSkin(w, r, dm)
+			getSkin(w, r, store)
 		case http.MethodPost:
-			buySkin(w, r, dm)
+			buySkin(w, r, store)
 		case http.MethodPut:
-			changeSkin(w, r, dm)
+			changeSkin(w, r, store)
 		default:
 			w.WriteHeader(http.StatusMethodNotAllowed)
 		}
@@ -29,7 +29,7 @@ func SkinHandler(dm *db.DatabaseManager) http.HandlerFunc {
 }
 
 // @Summary Получить информацию об одном скине или обо всех
-// @Description Получить информацию о скине: ID, название и стоимость
+// @Description Получить информацию о скине: ID, название, стоимость, цену со скидкой и конец распродажи, редкость и доступность. Возвращаются скины, которые можно купить сейчас, и купленные игроком
 // @ID get-skin
 // @Produce json
 // @Param id query uint false "ID"
@@ -38,7 +38,7 @@ func SkinHandler(dm *db.DatabaseManager) http.HandlerFunc {
 // @Failure 404 "Не найдено"
 // @Failure 500 "Ошибка в бд"
 // @Router /profile/skin [GET]
-func getSkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
+func getSkin(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
 	query := r.URL.Query()
 	rawID := query.Get("id")
 	var id uint64
@@ -50,15 +50,19 @@ func getSkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
 			return
 		}
 	}
+	var uID uint
+	if r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		uID = r.Context().Value(middleware.KeyUserID).(uint)
+	}
 	if id != 0 {
-		skin, err := database.GetSkin(dm, uint(id))
+		skin, err := store.GetSkin(r.Context(), uint(id), uID, false)
 		if err != nil {
 			if err == database.ErrNotFound {
 				w.WriteHeader(http.StatusNotFound)
 				return
 			}
 			logger.Errorf("database error while getting skin with id %v: %v", id, err)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(dbErrorStatus(r, err))
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -70,10 +74,10 @@ func getSkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
 		}
 		fmt.Fprintln(w, string(json))
 	} else {
-		skins, err := database.GetAllSkins(dm)
+		skins, err := store.GetAllSkins(r.Context(), uID, false)
 		if err != nil {
 			logger.Errorf("database error while getting all skins: %v", err)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(dbErrorStatus(r, err))
 			return
 		}
 		skinsList := &models.AllSkins{
@@ -91,7 +95,7 @@ func getSkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
 }
 
 // @Summary Купить новый скин
-// @Description Купить новый скин, монет должно быть достаточно для совершения покупки
+// @Description Купить новый скин по цене с текущей скидкой, монет должно быть достаточно для совершения покупки
 // @ID post-skin
 // @Accept json
 // @Param Profile body models.RequestSkin true "Скин для покупки"
@@ -102,7 +106,7 @@ func getSkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
 // @Failure 422 "Недостаточно средств"
 // @Failure 500 "Ошибка в бд"
 // @Router /profile/skin [POST]
-func buySkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
+func buySkin(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
 	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
 		w.WriteHeader(http.StatusUnauthorized)
 		return
@@ -116,45 +120,26 @@ func buySkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
 	}
 
 	uID := r.Context().Value(middleware.KeyUserID).(uint)
-	store, err := database.GetUserStore(dm, uID)
+	err = store.BuySkin(r.Context(), uID, skin.ID)
 	if err != nil {
-		switch err.(type) {
-		case database.UserNotFoundError:
-			w.WriteHeader(http.StatusUnauthorized)
-			return
-		default:
-			logger.Errorf("database error while getting user store with id %v: %v", uID, err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-	}
-	for _, v := range store.PurchasedSkins {
-		if skin.ID == v {
+		switch err {
+		case database.ErrAlreadyOwned:
 			// user already has this skin
 			return
-		}
-	}
-
-	skinInfo, err := database.GetSkin(dm, skin.ID)
-	if err != nil {
-		if err == database.ErrNotFound {
+		case database.ErrSkinNotFound:
 			w.WriteHeader(http.StatusNotFound)
 			return
+		case database.ErrInsufficientCoins:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		switch err.(type) {
+		case database.UserNotFoundError:
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			logger.Errorf("database error while buying skin %v by user %v: %v", skin.ID, uID, err)
+			w.WriteHeader(dbErrorStatus(r, err))
 		}
-		logger.Errorf("database error while getting skin with id %v: %v", skin.ID, err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	if store.Coins != nil && *store.Coins < skinInfo.Cost {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		return
-	}
-
-	err = database.BuySkin(dm, uID, skinInfo)
-	if err != nil {
-		logger.Errorf("database error while buying skin %v by user %v: %v", *skinInfo, uID, err)
-		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
 
@@ -169,7 +154,7 @@ func buySkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
 // @Failure 422 "Скин не куплен"
 // @Failure 500 "Ошибка в бд"
 // @Router /profile/skin [PUT]
-func changeSkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager) {
+func changeSkin(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
 	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
 		w.WriteHeader(http.StatusUnauthorized)
 		return
@@ -183,7 +168,7 @@ func changeSkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 	}
 
 	uID := r.Context().Value(middleware.KeyUserID).(uint)
-	store, err := database.GetUserStore(dm, uID)
+	userStore, err := store.GetUserStore(r.Context(), uID)
 	if err != nil {
 		switch err.(type) {
 		case database.UserNotFoundError:
@@ -191,19 +176,19 @@ func changeSkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 			return
 		default:
 			logger.Errorf("database error while getting user store with id %v: %v", uID, err)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(dbErrorStatus(r, err))
 			return
 		}
 	}
 
-	if store.Skin != nil && *store.Skin == skin.ID {
+	if userStore.Skin != nil && *userStore.Skin == skin.ID {
 		// equipped
 		return
 	}
 
 	hasSkin := false
 	if skin.ID != 0 {
-		for _, v := range store.PurchasedSkins {
+		for _, v := range userStore.PurchasedSkins {
 			if skin.ID == v {
 				hasSkin = true
 			}
@@ -214,10 +199,10 @@ func changeSkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 	}
 
 	if hasSkin {
-		err = database.ChangeSkin(dm, uID, skin.ID)
+		err = store.ChangeSkin(r.Context(), uID, skin.ID)
 		if err != nil {
 			logger.Errorf("database error while changing user %v skin to %v: %v", uID, skin.ID, err)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(dbErrorStatus(r, err))
 			return
 		}
 		return
@@ -225,3 +210,169 @@ func changeSkin(w http.ResponseWriter, r *http.Request, dm *db.DatabaseManager)
 
 	w.WriteHeader(http.StatusUnprocessableEntity)
 }
+
+func BundleHandler(store database.StoreRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getBundles(w, r, store)
+		case http.MethodPost:
+			buyBundle(w, r, store)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// @Summary Получить наборы скинов
+// @Description Получить наборы, которые можно купить сейчас: скины, цену набора и сумму цен скинов по отдельности
+// @ID get-store-bundles
+// @Produce json
+// @Success 200 {object} models.BundleList "Наборы"
+// @Failure 500 "Ошибка в бд"
+// @Router /store/bundles [GET]
+func getBundles(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	bundles, err := store.GetBundles(r.Context(), false)
+	if err != nil {
+		logger.Errorf("database error while getting bundles: %v", err)
+		w.WriteHeader(dbErrorStatus(r, err))
+		return
+	}
+	bundleList := &models.BundleList{
+		Bundles: *bundles,
+	}
+	json, err := bundleList.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
+
+// @Summary Купить набор скинов
+// @Description Купить все скины набора за цену набора, монет должно быть достаточно для совершения покупки. Если часть скинов уже куплена, цена уменьшается пропорционально: цена набора * некупленные скины / все скины, с округлением вверх
+// @ID post-store-bundle
+// @Accept json
+// @Param Bundle body models.RequestBundle true "Набор для покупки"
+// @Success 200 "Набор куплен (или все скины уже есть)"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 401 "Не залогинен, профиль не существует"
+// @Failure 404 "Набор не найден"
+// @Failure 422 "Недостаточно средств"
+// @Failure 500 "Ошибка в бд"
+// @Router /store/bundles [POST]
+func buyBundle(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bundle := &models.RequestBundle{}
+	err := unmarshalJSONBodyToStruct(r, bundle)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	err = store.BuyBundle(r.Context(), uID, bundle.ID)
+	if err != nil {
+		switch err {
+		case database.ErrAlreadyOwned:
+			// user already has all skins of the bundle
+			return
+		case database.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case database.ErrInsufficientCoins:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		switch err.(type) {
+		case database.UserNotFoundError:
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			logger.Errorf("database error while buying bundle %v by user %v: %v", bundle.ID, uID, err)
+			w.WriteHeader(dbErrorStatus(r, err))
+		}
+	}
+}
+
+func RedeemHandler(store database.StoreRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			redeemCode(w, r, store)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// @Summary Активировать промокод
+// @Description Получить монеты или скин по промокоду, каждый игрок может активировать код один раз
+// @ID post-store-redeem
+// @Accept json
+// @Produce json
+// @Param RedeemCode body models.RedeemCode true "Промокод"
+// @Success 200 {object} models.PromoReward "Награда получена"
+// @Failure 400 "Неверный формат JSON"
+// @Failure 401 "Не залогинен, профиль не существует"
+// @Failure 404 "Промокод не найден"
+// @Failure 409 "Промокод уже активирован игроком или скин уже есть"
+// @Failure 410 "Срок действия истек или промокод закончился"
+// @Failure 500 "Ошибка в бд"
+// @Router /store/redeem [POST]
+func redeemCode(w http.ResponseWriter, r *http.Request, store database.StoreRepository) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	c := &models.RedeemCode{}
+	err := unmarshalJSONBodyToStruct(r, c)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	code := strings.TrimSpace(c.Code)
+	if code == "" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	reward, err := store.RedeemPromoCode(r.Context(), uID, code)
+	if err != nil {
+		switch err {
+		case database.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case database.ErrPromoRedeemed, database.ErrAlreadyOwned:
+			w.WriteHeader(http.StatusConflict)
+			return
+		case database.ErrPromoExpired, database.ErrPromoExhausted:
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		switch err.(type) {
+		case database.UserNotFoundError:
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			logger.Errorf("database error while redeeming code %v by user %v: %v", code, uID, err)
+			w.WriteHeader(dbErrorStatus(r, err))
+		}
+		return
+	}
+
+	json, err := reward.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(json))
+}
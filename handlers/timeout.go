@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
+)
+
+// TimeoutMiddleware limits the time the request may spend in the database,
+// the queries are canceled as well when the client goes away
+func TimeoutMiddleware(next http.Handler, timeout time.Duration) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if timeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// UploadMiddleware reads the multipart form of the authenticated PUT request before next,
+// so the time of the upload isn't counted by TimeoutMiddleware. The handler gets the parsed form
+func UploadMiddleware(next http.Handler, maxMemory int64) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+			// the handler reports the error when it parses the form again
+			_ = r.ParseMultipartForm(maxMemory)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
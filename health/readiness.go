@@ -0,0 +1,168 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+
+	"api/models"
+)
+
+// Check probes the dependency, it should give up when ctx is done
+type Check func(ctx context.Context) error
+
+type Dependency struct {
+	Name string
+	// the instance is not ready without the hard dependency
+	Hard  bool
+	Check Check
+}
+
+// Readiness tells the load balancer whether the instance takes new requests,
+// it stops taking them when a hard dependency is down and while the server drains
+// the connections before the shutdown
+type Readiness struct {
+	draining int32
+
+	deps    []Dependency
+	timeout time.Duration
+	// the probes of the load balancer don't hammer the dependencies
+	cacheFor time.Duration
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	last      models.Health
+}
+
+// NewReadiness checks every dependency at most once per cacheFor,
+// a dependency is down if it doesn't answer in timeout
+func NewReadiness(timeout, cacheFor time.Duration, deps ...Dependency) *Readiness {
+	return &Readiness{
+		deps:     deps,
+		timeout:  timeout,
+		cacheFor: cacheFor,
+	}
+}
+
+// Drain marks the instance not ready, it is never ready again
+func (rd *Readiness) Drain() {
+	atomic.StoreInt32(&rd.draining, 1)
+}
+
+func (rd *Readiness) IsDraining() bool {
+	return atomic.LoadInt32(&rd.draining) == 1
+}
+
+// probe runs the check in its own goroutine so the ones ignoring ctx can't block the probe
+func probe(ctx context.Context, d Dependency) models.DependencyHealth {
+	start := time.Now()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- d.Check(ctx)
+	}()
+	var err error
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	res := models.DependencyHealth{
+		Name:      d.Name,
+		Hard:      d.Hard,
+		Status:    models.HealthOK,
+		LatencyMs: float64(time.Since(start)) / float64(time.Millisecond),
+	}
+	if err != nil {
+		res.Status = models.HealthDown
+		res.Error = err.Error()
+	}
+
+	return res
+}
+
+// Check returns the health of the dependencies checked in parallel
+func (rd *Readiness) Check(ctx context.Context) models.Health {
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+
+	if !rd.checkedAt.IsZero() && time.Since(rd.checkedAt) < rd.cacheFor {
+		return rd.last
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rd.timeout)
+	defer cancel()
+	res := models.Health{
+		Status:       models.HealthOK,
+		Dependencies: make([]models.DependencyHealth, len(rd.deps)),
+	}
+	wg := sync.WaitGroup{}
+	for i, d := range rd.deps {
+		wg.Add(1)
+		go func(i int, d Dependency) {
+			defer wg.Done()
+			res.Dependencies[i] = probe(ctx, d)
+		}(i, d)
+	}
+	wg.Wait()
+	for _, d := range res.Dependencies {
+		if d.Hard && d.Status != models.HealthOK {
+			res.Status = models.HealthDown
+		}
+	}
+
+	rd.checkedAt = time.Now()
+	rd.last = res
+
+	return res
+}
+
+// @Summary Готовность принимать запросы
+// @Description Проверить базу данных и сервис авторизации. Результат кешируется на пару секунд
+// @ID get-readyz
+// @Produce json
+// @Success 200 {object} models.Health "Готов"
+// @Failure 503 {object} models.Health "Недоступна обязательная зависимость или сервер останавливается"
+// @Router /readyz [GET]
+func (rd *Readiness) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status := http.StatusOK
+	res := models.Health{Status: models.HealthDraining}
+	if rd.IsDraining() {
+		status = http.StatusServiceUnavailable
+	} else {
+		res = rd.Check(r.Context())
+		if res.Status != models.HealthOK {
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	writeHealth(w, res, status)
+}
+
+// @Summary Процесс жив
+// @ID get-healthz
+// @Produce json
+// @Success 200 {object} models.Health "Жив"
+// @Router /healthz [GET]
+func LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, models.Health{Status: models.HealthOK}, http.StatusOK)
+}
+
+func writeHealth(w http.ResponseWriter, h models.Health, status int) {
+	json, err := h.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	fmt.Fprintln(w, string(json))
+}
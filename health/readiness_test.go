@@ -0,0 +1,64 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"api/models"
+)
+
+func TestReadinessCachesChecks(t *testing.T) {
+	var calls int32
+	rd := NewReadiness(time.Second, time.Hour, Dependency{
+		Name: "db",
+		Hard: true,
+		Check: func(context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		},
+	})
+	for i := 0; i < 3; i++ {
+		if h := rd.Check(context.Background()); h.Status != models.HealthOK {
+			t.Fatalf("unexpected health: %+v", h)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("dependency is checked %v times", calls)
+	}
+}
+
+func TestReadinessSoftAndSlowDependencies(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	rd := NewReadiness(20*time.Millisecond, 0,
+		Dependency{
+			Name: "cache",
+			Check: func(context.Context) error {
+				return errors.New("no cache")
+			},
+		},
+		Dependency{
+			Name: "db",
+			Hard: true,
+			// ignores the context
+			Check: func(context.Context) error {
+				<-block
+				return nil
+			},
+		},
+	)
+
+	h := rd.Check(context.Background())
+	if h.Status != models.HealthDown {
+		t.Fatalf("hanging hard dependency is ok: %+v", h)
+	}
+	if d := h.Dependencies[0]; d.Name != "cache" || d.Status != models.HealthDown || d.Error != "no cache" {
+		t.Fatalf("unexpected soft dependency: %+v", d)
+	}
+	if d := h.Dependencies[1]; d.Status != models.HealthDown || d.Error != context.DeadlineExceeded.Error() {
+		t.Fatalf("unexpected hard dependency: %+v", d)
+	}
+}
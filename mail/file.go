@@ -0,0 +1,55 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+)
+
+// FileMailer saves the letters to the directory instead of sending them,
+// it is used for the local development and the tests
+type FileMailer struct {
+	dir  string
+	from string
+
+	mu   sync.Mutex
+	sent int
+}
+
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{
+		dir:  dir,
+		from: from,
+	}
+}
+
+// Send saves the letter to a new .eml file, the files are sorted by the time they are sent
+func (m *FileMailer) Send(_ context.Context, msg *Message) error {
+	letter, err := msg.encode(m.from)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	err = os.MkdirAll(m.dir, 0755)
+	if err != nil {
+		return err
+	}
+	m.sent++
+	path := filepath.Join(m.dir, fmt.Sprintf("%v-%04d.eml", time.Now().UnixNano(), m.sent%10000))
+	err = ioutil.WriteFile(path, letter, 0600)
+	if err != nil {
+		return err
+	}
+	logger.Infof("letter %q to %v is saved to %v", msg.Subject, msg.To, path)
+
+	return nil
+}
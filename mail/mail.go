@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Mailer sends the letters to the players
+type Mailer interface {
+	Send(ctx context.Context, m *Message) error
+}
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// encode returns the plain text letter with the headers
+func (m *Message) encode(from string) ([]byte, error) {
+	for _, h := range []string{from, m.To, m.Subject} {
+		if strings.ContainsAny(h, "\r\n") {
+			return nil, fmt.Errorf("line break in the header %q", h)
+		}
+	}
+
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "From: %v\r\n", from)
+	fmt.Fprintf(b, "To: %v\r\n", m.To)
+	fmt.Fprintf(b, "Subject: %v\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(b, "Date: %v\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.Replace(m.Body, "\n", "\r\n", -1))
+
+	return b.Bytes(), nil
+}
@@ -1,28 +1,272 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger"
 
-	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/middleware"
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/session"
 
+	"api/auth"
+	"api/database"
 	_ "api/docs"
 	"api/filesystem"
 	"api/handlers"
+	"api/health"
+	"api/mail"
 	"api/metrics"
 )
 
+// repository is implemented by database.Postgres and database.Memory
+type repository interface {
+	database.UserRepository
+	database.StoreRepository
+	database.ScoreboardRepository
+	Ping(ctx context.Context) error
+}
+
+const (
+	readHeaderTimeout = 5 * time.Second
+	// avatars are up to 5 MB
+	readTimeout  = 30 * time.Second
+	writeTimeout = 30 * time.Second
+	idleTimeout  = 2 * time.Minute
+
+	healthCheckTimeout = time.Second
+	healthCacheFor     = 2 * time.Second
+
+	loginFreeAttempts   = 5
+	addressFreeAttempts = 20
+	loginBaseDelay      = time.Second
+	loginMaxDelay       = time.Minute
+
+	// the letters flood neither the queue nor the mailbox
+	resetFreeAttempts        = 3
+	resetAddressFreeAttempts = 10
+	resetBaseDelay           = time.Minute
+)
+
+// archiveSeasons saves the final standings of the seasons as soon as they end
+func archiveSeasons(ctx context.Context, scoreboard database.ScoreboardRepository, period time.Duration) {
+	t := time.NewTicker(period)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		archiveCtx, cancel := context.WithTimeout(ctx, period)
+		n, err := scoreboard.ArchiveFinishedSeasons(archiveCtx)
+		cancel()
+		if err != nil {
+			logger.Errorf("error while archiving seasons: %v", err)
+		} else if n != 0 {
+			logger.Infof("archived %v seasons", n)
+		}
+	}
+}
+
+// foldScoreboard keeps the changes of the counts of the players per record few
+func foldScoreboard(ctx context.Context, scoreboard database.ScoreboardRepository, period time.Duration) {
+	t := time.NewTicker(period)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		foldCtx, cancel := context.WithTimeout(ctx, period)
+		err := scoreboard.FoldScoreboardRecords(foldCtx)
+		cancel()
+		if err != nil {
+			logger.Errorf("error while folding scoreboard records: %v", err)
+		}
+	}
+}
+
+// forgetLoginFailures removes the failed logins which don't slow down anybody anymore
+func forgetLoginFailures(ctx context.Context, lt *auth.LoginThrottle, period time.Duration) {
+	t := time.NewTicker(period)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		forgetCtx, cancel := context.WithTimeout(ctx, period)
+		err := lt.Forget(forgetCtx)
+		cancel()
+		if err != nil {
+			logger.Errorf("error while forgetting login failures: %v", err)
+		}
+	}
+}
+
+// forgetSessions deletes the tracked sessions which have lived longer than ttl
+func forgetSessions(ctx context.Context, sm *auth.TrackedSessionManager, ttl, period time.Duration) {
+	t := time.NewTicker(period)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		forgetCtx, cancel := context.WithTimeout(ctx, period)
+		err := sm.Forget(forgetCtx, ttl)
+		cancel()
+		if err != nil {
+			logger.Errorf("error while forgetting sessions: %v", err)
+		}
+	}
+}
+
+// touchSessions saves when the tracked sessions were seen, the requests only remember them
+func touchSessions(ctx context.Context, sm *auth.TrackedSessionManager, period time.Duration) {
+	t := time.NewTicker(period)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		touchCtx, cancel := context.WithTimeout(ctx, period)
+		err := sm.Flush(touchCtx)
+		cancel()
+		if err != nil {
+			logger.Errorf("error while touching sessions: %v", err)
+		}
+	}
+}
+
+// newLoginThrottle locks out the account after lockoutAfter failures, the address is
+// only slowed down as many players may share it
+func newLoginThrottle(store auth.LoginAttemptStore, lockoutAfter int, lockoutFor time.Duration) *auth.LoginThrottle {
+	return auth.NewLoginThrottle(store,
+		auth.ThrottlePolicy{
+			FreeAttempts: loginFreeAttempts,
+			BaseDelay:    loginBaseDelay,
+			MaxDelay:     loginMaxDelay,
+			LockoutAfter: lockoutAfter,
+			ForgetAfter:  lockoutFor,
+		},
+		auth.ThrottlePolicy{
+			FreeAttempts: addressFreeAttempts,
+			BaseDelay:    loginBaseDelay,
+			MaxDelay:     loginMaxDelay,
+			ForgetAfter:  lockoutFor,
+		},
+	)
+}
+
+// newPasswordResetThrottle shares the store and the lifetime of the failures with the logins,
+// so the resets are forgotten together with them
+func newPasswordResetThrottle(store auth.LoginAttemptStore, forgetAfter time.Duration) *auth.LoginThrottle {
+	return auth.NewPasswordResetThrottle(store,
+		auth.ThrottlePolicy{
+			FreeAttempts: resetFreeAttempts,
+			BaseDelay:    resetBaseDelay,
+			MaxDelay:     forgetAfter,
+			ForgetAfter:  forgetAfter,
+		},
+		auth.ThrottlePolicy{
+			FreeAttempts: resetAddressFreeAttempts,
+			BaseDelay:    resetBaseDelay,
+			MaxDelay:     forgetAfter,
+			ForgetAfter:  forgetAfter,
+		},
+	)
+}
+
+// serve runs the server until SIGINT or SIGTERM, then it reports not ready for the drain period
+// so the load balancer stops sending requests and waits for the running ones to finish
+func serve(srv *http.Server, ready *health.Readiness, drainPeriod, shutdownTimeout time.Duration) {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	select {
+	case err := <-errs:
+		logger.Panic(err)
+	case sig := <-stop:
+		logger.Infof("got %v, draining connections for %v", sig, drainPeriod)
+	}
+
+	ready.Drain()
+	srv.SetKeepAlivesEnabled(false)
+	time.Sleep(drainPeriod)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := srv.Shutdown(ctx)
+	if err != nil {
+		logger.Errorf("error while shutting down server: %v", err)
+	}
+	logger.Info("server stopped")
+}
+
 func main() {
 	dbConnStr := flag.String("db_connstr", "postgres@localhost:5432", "postgresql connection string")
 	dbName := flag.String("db_name", "postgres", "database name")
 	authConnStr := flag.String("auth_connstr", "localhost:8081", "auth-service connection string")
+	sessionTTL := flag.Duration("session_ttl", 30*24*time.Hour,
+		"lifetime of the sessions, the older ones are deleted in auth-service and removed from the list of the sessions")
+	serviceToken := flag.String("service_token", "", "token of the trusted services (game server)")
+	queryTimeout := flag.Duration("query_timeout", 5*time.Second,
+		"time the request may spend in the database, 0 is unlimited")
+	drainPeriod := flag.Duration("drain_period", 5*time.Second,
+		"time to report not ready before the shutdown so the load balancer stops sending requests")
+	shutdownTimeout := flag.Duration("shutdown_timeout", 20*time.Second,
+		"time to wait for the running requests on shutdown")
+	appURL := flag.String("app_url", "https://dmstudio.now.sh", "frontend URL for the links in the letters")
+	smtpAddr := flag.String("smtp_addr", "", "SMTP server host:port, the letters are saved to mail_dir if it is not set")
+	smtpUser := flag.String("smtp_user", "", "SMTP username")
+	smtpPassword := flag.String("smtp_password", "", "SMTP password")
+	mailFrom := flag.String("mail_from", "noreply@dmstudio.now.sh", "sender of the letters")
+	mailDir := flag.String("mail_dir", "letters", "directory for the letters when SMTP server is not set")
+	passwordHash := flag.String("password_hash", auth.HashArgon2id, "password hashing algorithm: argon2id or bcrypt, "+
+		"the hashes made by the other one or with the weaker parameters are upgraded on login")
+	bcryptCost := flag.Int("bcrypt_cost", 12, "bcrypt cost")
+	argon2Time := flag.Uint("argon2_time", 3, "argon2id number of passes")
+	argon2Memory := flag.Uint("argon2_memory", 64*1024, "argon2id memory in KiB")
+	argon2Threads := flag.Uint("argon2_threads", 4, "argon2id parallelism")
+	hashConcurrency := flag.Int("hash_concurrency", runtime.NumCPU(),
+		"passwords hashed at the same time, each argon2id hash takes argon2_memory, 0 is unlimited")
+	loginAttempts := flag.String("login_attempts", "postgres",
+		"postgres or memory: count the failed logins in the database shared by the instances or in each of them")
+	loginLockoutAfter := flag.Int("login_lockout_after", 10, "failed logins in a row to lock out the account")
+	loginLockout := flag.Duration("login_lockout", 15*time.Minute,
+		"time to lock out the account for, the failed logins are forgotten after it")
+	realIPHeader := flag.String("real_ip_header", "",
+		"header with the client address set by the trusted proxy, e.g. X-Real-IP, the peer address is used if empty")
+	storage := flag.String("storage", "postgres",
+		"postgres or memory: keep everything in memory without the database and auth-service")
 	flag.Parse()
 
 	l := logger.InitLogger()
@@ -33,58 +277,280 @@ func main() {
 		}
 	}()
 
-	prometheus.MustRegister(metrics.AccessHits)
+	prometheus.MustRegister(metrics.AccessHits, metrics.LoginFailures, metrics.LoginsThrottled,
+		metrics.PasswordResetsThrottled)
+
+	// deferred in reverse: the database pool is closed after the session client
+	var pg repository
+	var sm auth.SessionManager
+	var tracked *auth.TrackedSessionManager
+	if *loginAttempts != "postgres" && *loginAttempts != "memory" {
+		logger.Panicf("unknown login attempts storage: %v", *loginAttempts)
+	}
+	var attempts auth.LoginAttemptStore = auth.NewMemoryLoginAttemptStore()
+	switch *storage {
+	case "postgres":
+		dm := db.InitDatabaseManager(*dbConnStr, *dbName)
+		defer func() {
+			err := dm.Close()
+			if err != nil {
+				logger.Errorf("error while closing database: %v", err)
+			}
+		}()
+		postgres := database.NewPostgres(dm)
+		pg = postgres
+
+		tracked = auth.NewTrackedSessionManager(session.ConnectSessionManager(*authConnStr), postgres)
+		sm = tracked
+		if *loginAttempts == "postgres" {
+			attempts = postgres
+		}
+	case "memory":
+		logger.Info("storing everything in memory, the data is lost on exit")
+		pg = database.NewMemory()
+		sm = auth.NewMemorySessionManager()
+	default:
+		logger.Panicf("unknown storage: %v", *storage)
+	}
+	defer func() {
+		err := sm.Close()
+		if err != nil {
+			logger.Errorf("error while closing session manager: %v", err)
+		}
+	}()
+
+	var mailer mail.Mailer
+	if *smtpAddr != "" {
+		var err error
+		mailer, err = mail.NewSMTPMailer(*smtpAddr, *smtpUser, *smtpPassword, *mailFrom)
+		if err != nil {
+			logger.Panicf("invalid SMTP server: %v", err)
+		}
+	} else {
+		logger.Infof("saving the letters to %v instead of sending them", *mailDir)
+		mailer = mail.NewFileMailer(*mailDir, *mailFrom)
+	}
+	letters := handlers.NewLetters(mailer, *appURL)
+	// the queued letters are sent after the server is shut down
+	defer letters.Close()
+
+	hasher, err := auth.NewPasswordHasher(*passwordHash, *bcryptCost,
+		uint32(*argon2Time), uint32(*argon2Memory), uint8(*argon2Threads), *hashConcurrency)
+	if err != nil {
+		logger.Panicf("invalid password hashing policy: %v", err)
+	}
+
+	throttle := newLoginThrottle(attempts, *loginLockoutAfter, *loginLockout)
+	resets := newPasswordResetThrottle(attempts, *loginLockout)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go archiveSeasons(ctx, pg, time.Minute)
+	go foldScoreboard(ctx, pg, 10*time.Second)
+	go forgetLoginFailures(ctx, throttle, time.Minute)
+	if tracked != nil {
+		go forgetSessions(ctx, tracked, *sessionTTL, time.Hour)
+		go touchSessions(ctx, tracked, time.Minute)
+	}
+
+	ready := newReadiness(pg, sm, healthCheckTimeout, healthCacheFor)
+	router := newRouter(pg, sm, hasher, throttle, resets, letters, ready, *serviceToken, *queryTimeout, *realIPHeader)
+	srv := &http.Server{
+		Addr:              ":8080",
+		Handler:           router,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+
+	logger.Info("starting server at: ", 8080)
+	serve(srv, ready, *drainPeriod, *shutdownTimeout)
+}
+
+// newReadiness checks the hard dependencies of the service
+func newReadiness(pg repository, sm auth.SessionManager, timeout, cacheFor time.Duration) *health.Readiness {
+	return health.NewReadiness(timeout, cacheFor,
+		health.Dependency{
+			Name:  "database",
+			Hard:  true,
+			Check: pg.Ping,
+		},
+		health.Dependency{
+			Name: "auth-service",
+			Hard: true,
+			Check: func(context.Context) error {
+				return auth.PingSessionManager(sm)
+			},
+		},
+	)
+}
 
-	dm := database.InitDatabaseManager(*dbConnStr, *dbName)
-	defer dm.Close()
+// newRouter wires the handlers with their middlewares
+func newRouter(pg repository, sm auth.SessionManager, hasher *auth.PasswordHasher, throttle *auth.LoginThrottle,
+	resets *auth.LoginThrottle, letters *handlers.Letters, ready *health.Readiness, serviceToken string, queryTimeout time.Duration,
+	realIPHeader string) http.Handler {
+	mux := http.NewServeMux()
 
-	sm := session.ConnectSessionManager(*authConnStr)
-	defer sm.Close()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", health.LivenessHandler)
+	mux.Handle("/readyz", ready)
 
-	http.Handle("/metrics", promhttp.Handler())
+	// the API routes limit the time of their queries, the rest don't query the database
+	api := func(next http.HandlerFunc) http.HandlerFunc {
+		return handlers.TimeoutMiddleware(next, queryTimeout)
+	}
 
-	http.HandleFunc(
+	mux.HandleFunc(
 		"/session",
-		middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
-			middleware.CORSMiddleware(middleware.SessionMiddleware(handlers.SessionHandler(dm, sm), sm))))),
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.SessionHandler(pg, sm, hasher, throttle), pg), sm)))))),
 	)
-	http.HandleFunc(
+	mux.HandleFunc(
+		"/session/all",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.SessionHandler(pg, sm, hasher, throttle), pg), sm)))))),
+	)
+	mux.HandleFunc(
 		"/profile",
-		middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
-			middleware.CORSMiddleware(middleware.SessionMiddleware(handlers.ProfileHandler(dm, sm), sm))))),
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.ProfileHandler(pg, sm, hasher, throttle, letters), pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/profile/email/verify",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.EmailVerificationHandler(pg, letters), pg), sm)))))),
 	)
-	http.HandleFunc(
+	mux.HandleFunc(
+		"/profile/password/reset",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(handlers.PasswordResetHandler(pg, sm, hasher, letters, resets)))))),
+	)
+	// the deadline starts when the avatar is uploaded, a slow client isn't cut off by it
+	mux.HandleFunc(
 		"/profile/avatar",
 		middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
-			middleware.CORSMiddleware(middleware.SessionMiddleware(handlers.AvatarHandler(dm), sm))))),
+			middleware.CORSMiddleware(auth.SessionMiddleware(handlers.UploadMiddleware(
+				api(handlers.DailyRewardMiddleware(handlers.AvatarHandler(pg), pg)), handlers.MaxAvatarMemory), sm))))),
 	)
-	http.HandleFunc(
+	mux.HandleFunc(
 		"/profile/skin",
-		middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
-			middleware.CORSMiddleware(middleware.SessionMiddleware(handlers.SkinHandler(dm), sm))))),
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.SkinHandler(pg), pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/profile/matches",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.MatchHistoryHandler(pg), pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/profile/friends",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.FriendsHandler(pg), pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/profile/daily",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(handlers.DailyRewardHandler(pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/profile/gifts",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.GiftHandler(pg, pg), pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/profile/achievements",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.AchievementHandler(pg), pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/profile/coins/history",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.CoinHistoryHandler(pg), pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/admin/skins",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.SkinAdminHandler(pg, pg), pg), sm)))))),
 	)
-	http.HandleFunc(
+	mux.HandleFunc(
+		"/admin/sales",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.SaleAdminHandler(pg, pg), pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/admin/bundles",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.BundleAdminHandler(pg, pg), pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/admin/promo",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.PromoAdminHandler(pg, pg), pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/store/redeem",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.RedeemHandler(pg), pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/store/bundles",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.BundleHandler(pg), pg), sm)))))),
+	)
+	mux.HandleFunc(
 		"/profile/check",
-		middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
-			middleware.CORSMiddleware(handlers.CheckAvailabilityHandler(dm))))),
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(handlers.CheckAvailabilityHandler(pg)))))),
 	)
-	http.HandleFunc(
+	mux.HandleFunc(
 		"/scoreboard",
-		middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
-			middleware.CORSMiddleware(handlers.ScoreboardHandler(dm))))),
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(auth.SessionMiddleware(
+				handlers.DailyRewardMiddleware(handlers.ScoreboardHandler(pg), pg), sm)))))),
+	)
+	mux.HandleFunc(
+		"/scoreboard/seasons",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			middleware.CORSMiddleware(handlers.SeasonHandler(pg, serviceToken)))))),
+	)
+	mux.HandleFunc(
+		"/matches",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			handlers.MatchHandler(pg, serviceToken))))),
+	)
+	mux.HandleFunc(
+		"/coins/check",
+		api(middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
+			handlers.CoinLedgerHandler(pg, serviceToken))))),
 	)
 
 	// swag init -g handlers/api.go
-	http.HandleFunc("/docs/", httpSwagger.WrapHandler)
+	mux.HandleFunc("/docs/", httpSwagger.WrapHandler)
 
 	stm := filesystem.NewStaticManager("/static/", "static")
 
-	http.HandleFunc(
+	mux.HandleFunc(
 		"/static/",
 		middleware.RecoverMiddleware(metrics.CountHitsMiddleware(middleware.AccessLogMiddleware(
 			middleware.CORSMiddleware(stm)))),
 	)
 
-	logger.Info("starting server at: ", 8080)
-	logger.Panic(http.ListenAndServe(":8080", nil))
+	return handlers.RealIPMiddleware(mux, realIPHeader)
 }
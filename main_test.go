@@ -0,0 +1,2082 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+
+	"api/auth"
+	"api/database"
+	"api/handlers"
+	"api/health"
+	"api/mail"
+	"api/models"
+)
+
+const (
+	testServiceToken = "test-service-token"
+	testPassword     = "password"
+	testRealIPHeader = "X-Real-Ip"
+	testAppURL       = "https://game.test"
+
+	// the logs and the uploaded avatars are written to the temporary working directory
+	testLoggerConfig = `{
+		"level": "info",
+		"encoding": "json",
+		"outputPaths": ["server.log"],
+		"errorOutputPaths": ["server.log"],
+		"encoderConfig": {
+			"messageKey": "message",
+			"levelKey": "level",
+			"levelEncoder": "lowercase"
+		}
+	}`
+)
+
+// the end-to-end tests run against PostgreSQL if it is set, e.g. postgres@localhost:5432,
+// every test gets its own database with the migrations applied
+var testDBConnStr = os.Getenv("TEST_DB_CONNSTR")
+
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+func runTests(m *testing.M) int {
+	wd, err := os.Getwd()
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	dir, err := os.MkdirTemp("", "api-test")
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer os.RemoveAll(dir)
+
+	err = os.MkdirAll(filepath.Join(dir, "logger"), 0755)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, "logger", "logger-config.json"), []byte(testLoggerConfig), 0644)
+	}
+	// the migrations are applied from the working directory
+	if err == nil {
+		err = os.Symlink(filepath.Join(wd, "migrations"), filepath.Join(dir, "migrations"))
+	}
+	if err == nil {
+		err = os.Chdir(dir)
+	}
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	logger.InitLogger()
+
+	return m.Run()
+}
+
+// fakeSessionManager is auth-service which can go down
+type fakeSessionManager struct {
+	*auth.MemorySessionManager
+	down int32
+}
+
+var errAuthServiceDown = fmt.Errorf("auth-service is down")
+
+func (sm *fakeSessionManager) setDown(down bool) {
+	var v int32
+	if down {
+		v = 1
+	}
+	atomic.StoreInt32(&sm.down, v)
+}
+
+func (sm *fakeSessionManager) isDown() bool {
+	return atomic.LoadInt32(&sm.down) == 1
+}
+
+func (sm *fakeSessionManager) Create(uID uint) (string, error) {
+	if sm.isDown() {
+		return "", errAuthServiceDown
+	}
+	return sm.MemorySessionManager.Create(uID)
+}
+
+func (sm *fakeSessionManager) CreateSession(info *models.SessionInfo) (string, error) {
+	if sm.isDown() {
+		return "", errAuthServiceDown
+	}
+	return sm.MemorySessionManager.CreateSession(info)
+}
+
+func (sm *fakeSessionManager) GetUserSessions(uID uint) ([]models.SessionInfo, error) {
+	if sm.isDown() {
+		return nil, errAuthServiceDown
+	}
+	return sm.MemorySessionManager.GetUserSessions(uID)
+}
+
+func (sm *fakeSessionManager) Get(sID string) (uint, error) {
+	if sm.isDown() {
+		return 0, errAuthServiceDown
+	}
+	return sm.MemorySessionManager.Get(sID)
+}
+
+func (sm *fakeSessionManager) Delete(sID string) error {
+	if sm.isDown() {
+		return errAuthServiceDown
+	}
+	return sm.MemorySessionManager.Delete(sID)
+}
+
+func (sm *fakeSessionManager) DeleteUserSessions(uID uint, except string) error {
+	if sm.isDown() {
+		return errAuthServiceDown
+	}
+	return sm.MemorySessionManager.DeleteUserSessions(uID, except)
+}
+
+var testDBCount int32
+
+// newTestRepository returns the new database if TEST_DB_CONNSTR is set, the memory otherwise
+func newTestRepository(t *testing.T) repository {
+	if testDBConnStr == "" {
+		return database.NewMemory()
+	}
+
+	admin, err := sql.Open("postgres", "postgres://"+testDBConnStr+"/postgres?sslmode=disable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := fmt.Sprintf("api_test_%v_%v", os.Getpid(), atomic.AddInt32(&testDBCount, 1))
+	_, err = admin.Exec("CREATE DATABASE " + name)
+	if err != nil {
+		admin.Close()
+		t.Fatal(err)
+	}
+	dm := db.InitDatabaseManager(testDBConnStr, name)
+	t.Cleanup(func() {
+		defer admin.Close()
+		_ = dm.Close()
+		if _, err := admin.Exec("DROP DATABASE " + name); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return database.NewPostgres(dm)
+}
+
+// testEnv is the whole service with the handler chain of main
+type testEnv struct {
+	t     *testing.T
+	srv   *httptest.Server
+	repo  repository
+	sm    *fakeSessionManager
+	ready *health.Readiness
+	// cheap argon2id, the hashes of the older policies are upgraded on login
+	hasher *auth.PasswordHasher
+	// the letters are saved here
+	mailDir string
+	mail    *handlers.Letters
+	// IDs of the admins, they are appointed in the database by hand
+	admins sync.Map
+}
+
+// adminRepository makes the users of admins the admins
+type adminRepository struct {
+	repository
+	admins *sync.Map
+}
+
+func (r adminRepository) IsAdmin(ctx context.Context, uID uint) (bool, error) {
+	if _, ok := r.admins.Load(uID); ok {
+		return true, nil
+	}
+	return r.repository.IsAdmin(ctx, uID)
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	e := &testEnv{
+		t:    t,
+		repo: newTestRepository(t),
+		sm:   &fakeSessionManager{MemorySessionManager: auth.NewMemorySessionManager()},
+
+		mailDir: t.TempDir(),
+	}
+	var err error
+	e.hasher, err = auth.NewPasswordHasher(auth.HashArgon2id, 0, 1, 64, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.ready = newReadiness(e.repo, e.sm, time.Second, 0)
+	e.mail = handlers.NewLetters(mail.NewFileMailer(e.mailDir, "noreply@test.ru"), testAppURL)
+	t.Cleanup(e.mail.Close)
+	// the 4th login after 3 failures of the account and the 7th from the address wait for an hour,
+	// so do the password resets
+	var attempts auth.LoginAttemptStore = auth.NewMemoryLoginAttemptStore()
+	if store, ok := e.repo.(auth.LoginAttemptStore); ok {
+		attempts = store
+	}
+	account := auth.ThrottlePolicy{FreeAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour, ForgetAfter: 2 * time.Hour}
+	address := auth.ThrottlePolicy{FreeAttempts: 6, BaseDelay: time.Hour, MaxDelay: time.Hour, ForgetAfter: 2 * time.Hour}
+	throttle := auth.NewLoginThrottle(attempts, account, address)
+	resets := auth.NewPasswordResetThrottle(attempts, account, address)
+	// the session cookie is secure
+	e.srv = httptest.NewTLSServer(newRouter(adminRepository{e.repo, &e.admins}, e.sm, e.hasher, throttle, resets,
+		e.mail, e.ready, testServiceToken, time.Second, testRealIPHeader))
+	t.Cleanup(e.srv.Close)
+
+	return e
+}
+
+// testClient is a browser of a player with its own cookies
+type testClient struct {
+	e *testEnv
+	c *http.Client
+}
+
+func (e *testEnv) client() *testClient {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		e.t.Fatal(err)
+	}
+
+	return &testClient{
+		e: e,
+		c: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // nolint: gosec
+			},
+			Jar: jar,
+		},
+	}
+}
+
+type testResponse struct {
+	t      *testing.T
+	req    string
+	status int
+	header http.Header
+	body   []byte
+}
+
+func (c *testClient) send(method, path, contentType string, body io.Reader, header http.Header) *testResponse {
+	c.e.t.Helper()
+
+	req, err := http.NewRequest(method, c.e.srv.URL+path, body)
+	if err != nil {
+		c.e.t.Fatal(err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := c.c.Do(req)
+	if err != nil {
+		c.e.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.e.t.Fatal(err)
+	}
+
+	return &testResponse{
+		t:      c.e.t,
+		req:    method + " " + path,
+		status: resp.StatusCode,
+		header: resp.Header,
+		body:   respBody,
+	}
+}
+
+func (c *testClient) do(method, path, body string) *testResponse {
+	c.e.t.Helper()
+	return c.send(method, path, "application/json", strings.NewReader(body), nil)
+}
+
+func (c *testClient) service(method, path, body string) *testResponse {
+	c.e.t.Helper()
+	return c.send(method, path, "application/json", strings.NewReader(body),
+		http.Header{"Authorization": {"Bearer " + testServiceToken}})
+}
+
+// login comes from the address behind the proxy
+func (c *testClient) login(email, password, ip string) *testResponse {
+	c.e.t.Helper()
+	return c.send(http.MethodPost, "/session", "application/json",
+		strings.NewReader(fmt.Sprintf(`{"email":%q,"password":%q}`, email, password)),
+		http.Header{testRealIPHeader: {ip}})
+}
+
+func (r *testResponse) expect(status int) *testResponse {
+	r.t.Helper()
+	if r.status != status {
+		r.t.Fatalf("%v: expected status %v, got %v: %s", r.req, status, r.status, r.body)
+	}
+
+	return r
+}
+
+func (r *testResponse) decode(v interface{ UnmarshalJSON([]byte) error }) {
+	r.t.Helper()
+	err := v.UnmarshalJSON(r.body)
+	if err != nil {
+		r.t.Fatalf("%v: invalid response %s: %v", r.req, r.body, err)
+	}
+}
+
+var letterLink = regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(testAppURL) + `(/[a-z-]+)\?token=(\S+)\r?$`)
+
+// letters returns the letters sent to the address, the first one is the oldest
+func (e *testEnv) letters(to string) []string {
+	e.t.Helper()
+	e.mail.Wait()
+	// sorted by the name
+	files, err := os.ReadDir(e.mailDir)
+	if err != nil {
+		e.t.Fatal(err)
+	}
+	res := []string{}
+	for _, f := range files {
+		letter, err := os.ReadFile(filepath.Join(e.mailDir, f.Name()))
+		if err != nil {
+			e.t.Fatal(err)
+		}
+		if strings.Contains(string(letter), "\r\nTo: "+to+"\r\n") {
+			res = append(res, string(letter))
+		}
+	}
+
+	return res
+}
+
+// token returns the token of the last letter to the address, the link must lead to the path
+func (e *testEnv) token(to, path string) string {
+	e.t.Helper()
+	letters := e.letters(to)
+	if len(letters) == 0 {
+		e.t.Fatalf("no letters to %v", to)
+	}
+	link := letterLink.FindStringSubmatch(letters[len(letters)-1])
+	if link == nil || link[1] != path {
+		e.t.Fatalf("no link to %v in the letter: %s", path, letters[len(letters)-1])
+	}
+
+	return link[2]
+}
+
+func registerBody(nickname, email, password string) string {
+	return fmt.Sprintf(`{"nickname":%q,"email":%q,"password":%q}`, nickname, email, password)
+}
+
+// register creates a new player and logs in the client
+func (c *testClient) register(nickname string) *models.Profile {
+	c.e.t.Helper()
+	c.do(http.MethodPost, "/profile", registerBody(nickname, nickname+"@test.ru", testPassword)).
+		expect(http.StatusOK)
+
+	return c.profile()
+}
+
+func (c *testClient) profile() *models.Profile {
+	c.e.t.Helper()
+	p := &models.Profile{}
+	c.do(http.MethodGet, "/profile", "").expect(http.StatusOK).decode(p)
+
+	return p
+}
+
+func (c *testClient) sessionID() string {
+	c.e.t.Helper()
+	s := &models.Session{}
+	c.do(http.MethodGet, "/session", "").expect(http.StatusOK).decode(s)
+
+	return s.SessionID
+}
+
+func TestRegistration(t *testing.T) {
+	e := newTestEnv(t)
+	e.client().register("taken")
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		// fields with errors for 403
+		fields []string
+	}{
+		{"ok", registerBody("newbie", "newbie@test.ru", testPassword), http.StatusOK, nil},
+		{"invalid json", `{"nickname":`, http.StatusBadRequest, nil},
+		{"no password", `{"nickname":"nopass","email":"nopass@test.ru"}`, http.StatusUnprocessableEntity, nil},
+		{"taken nickname", registerBody("taken", "other@test.ru", testPassword),
+			http.StatusForbidden, []string{"nickname"}},
+		{"taken email", registerBody("other", "TAKEN@test.ru", testPassword),
+			http.StatusForbidden, []string{"email"}},
+		{"invalid email", registerBody("other", "not an email", testPassword),
+			http.StatusForbidden, []string{"email"}},
+		{"short nickname", registerBody("abc", "abc@test.ru", testPassword),
+			http.StatusForbidden, []string{"nickname"}},
+		{"short password", registerBody("other", "other@test.ru", "abc"),
+			http.StatusForbidden, []string{"password"}},
+		{"everything wrong", registerBody("taken", "taken@test.ru", "abc"),
+			http.StatusForbidden, []string{"nickname", "email", "password"}},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := e.client()
+			resp := c.do(http.MethodPost, "/profile", tc.body).expect(tc.status)
+			switch tc.status {
+			case http.StatusOK:
+				// logged in right away
+				if c.sessionID() == "" {
+					t.Fatal("empty session ID")
+				}
+			case http.StatusForbidden:
+				errs := &models.ProfileErrorList{}
+				resp.decode(errs)
+				got := []string{}
+				for _, pe := range errs.Errors {
+					got = append(got, pe.Field)
+				}
+				if strings.Join(got, ",") != strings.Join(tc.fields, ",") {
+					t.Fatalf("expected errors in %v, got %s", tc.fields, resp.body)
+				}
+			default:
+				c.do(http.MethodGet, "/session", "").expect(http.StatusUnauthorized)
+			}
+		})
+	}
+}
+
+func TestLoginLogout(t *testing.T) {
+	e := newTestEnv(t)
+	alice := e.client().register("alice")
+
+	c := e.client()
+	c.do(http.MethodGet, "/session", "").expect(http.StatusUnauthorized)
+	c.do(http.MethodPost, "/session", `{"email":`).expect(http.StatusBadRequest)
+	c.do(http.MethodPost, "/session", `{"email":"alice","password":"password"}`).expect(http.StatusBadRequest)
+	c.do(http.MethodPost, "/session", `{"email":"nobody@test.ru","password":"password"}`).
+		expect(http.StatusUnprocessableEntity)
+	c.do(http.MethodPost, "/session", `{"email":"alice@test.ru","password":"wrong"}`).
+		expect(http.StatusUnprocessableEntity)
+	c.do(http.MethodGet, "/session", "").expect(http.StatusUnauthorized)
+
+	c.do(http.MethodPost, "/session", `{"email":"alice@test.ru","password":"password"}`).expect(http.StatusOK)
+	sID := c.sessionID()
+	if p := c.profile(); p.UserID != alice.UserID {
+		t.Fatalf("logged in as %v instead of %v", p.UserID, alice.UserID)
+	}
+	// already logged in
+	c.do(http.MethodPost, "/session", `{"email":"alice@test.ru","password":"wrong"}`).expect(http.StatusOK)
+	if c.sessionID() != sID {
+		t.Fatal("session changed on the second login")
+	}
+
+	c.do(http.MethodDelete, "/session", "").expect(http.StatusOK)
+	c.do(http.MethodGet, "/session", "").expect(http.StatusUnauthorized)
+	c.do(http.MethodGet, "/profile", "").expect(http.StatusUnauthorized)
+	// already logged out
+	c.do(http.MethodDelete, "/session", "").expect(http.StatusOK)
+	if _, err := e.sm.Get(sID); err == nil {
+		t.Fatal("session was not deleted")
+	}
+
+	c.do(http.MethodPut, "/session", "").expect(http.StatusMethodNotAllowed)
+}
+
+func TestLoginThrottle(t *testing.T) {
+	e := newTestEnv(t)
+	for _, nickname := range []string{"alice", "bobby", "carol"} {
+		e.client().register(nickname)
+	}
+
+	// the login resets the failures of the account
+	for i := 0; i < 2; i++ {
+		e.client().login("alice@test.ru", "wrong", "10.0.0.1").expect(http.StatusUnprocessableEntity)
+	}
+	e.client().login("alice@test.ru", testPassword, "10.0.0.1").expect(http.StatusOK)
+
+	for i := 0; i < 3; i++ {
+		e.client().login("ALICE@test.ru", "wrong", "10.0.0.2").expect(http.StatusUnprocessableEntity)
+	}
+	// the right password doesn't help from any address
+	resp := e.client().login("alice@test.ru", testPassword, "10.0.0.3").expect(http.StatusTooManyRequests)
+	if ra := resp.header.Get("Retry-After"); ra != "3600" {
+		t.Fatalf("expected Retry-After 3600, got %q", ra)
+	}
+
+	// the address is throttled for the other accounts too
+	for i := 0; i < 3; i++ {
+		e.client().login("bobby@test.ru", "wrong", "10.0.0.2").expect(http.StatusUnprocessableEntity)
+	}
+	e.client().login("carol@test.ru", testPassword, "10.0.0.2").expect(http.StatusTooManyRequests)
+	e.client().login("carol@test.ru", testPassword, "10.0.0.4").expect(http.StatusOK)
+	// the unknown accounts are counted as well
+	for i := 0; i < 3; i++ {
+		e.client().login("nobody@test.ru", "wrong", "10.0.0.5").expect(http.StatusUnprocessableEntity)
+	}
+	e.client().login("nobody@test.ru", "wrong", "10.0.0.5").expect(http.StatusTooManyRequests)
+}
+
+func TestCurrentPasswordThrottle(t *testing.T) {
+	e := newTestEnv(t)
+	alice := e.client()
+	alice.register("alice")
+
+	for i := 0; i < 3; i++ {
+		alice.do(http.MethodPut, "/profile", `{"password":"new password","current_password":"wrong"}`).
+			expect(http.StatusForbidden)
+	}
+	// the guesses of the current password are the failures of the account
+	resp := alice.do(http.MethodPut, "/profile", `{"password":"new password","current_password":"password"}`).
+		expect(http.StatusTooManyRequests)
+	if ra := resp.header.Get("Retry-After"); ra != "3600" {
+		t.Fatalf("expected Retry-After 3600, got %q", ra)
+	}
+	e.client().login("alice@test.ru", testPassword, "10.0.0.1").expect(http.StatusTooManyRequests)
+	// the other changes don't need the password
+	alice.do(http.MethodPut, "/profile", `{"nickname":"alice2"}`).expect(http.StatusOK)
+}
+
+// countingRepository counts the logins which reach the password compare
+type countingRepository struct {
+	*database.Memory
+	compared int32
+}
+
+func (r *countingRepository) GetUserPassword(ctx context.Context, email string) (*models.User, error) {
+	atomic.AddInt32(&r.compared, 1)
+	return r.Memory.GetUserPassword(ctx, email)
+}
+
+func TestLoginThrottleConcurrent(t *testing.T) {
+	hasher, err := auth.NewPasswordHasher(auth.HashArgon2id, 0, 1, 64, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := hasher.Hash(testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &countingRepository{Memory: database.NewMemory()}
+	_, err = repo.CreateNewUser(context.Background(), &models.RegisterProfile{
+		Nickname:     "alice",
+		UserPassword: models.UserPassword{Email: "alice@test.ru", Password: hash},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	throttle := auth.NewLoginThrottle(auth.NewMemoryLoginAttemptStore(),
+		auth.ThrottlePolicy{FreeAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour, ForgetAfter: 2 * time.Hour},
+		auth.ThrottlePolicy{FreeAttempts: 100, BaseDelay: time.Hour, MaxDelay: time.Hour, ForgetAfter: 2 * time.Hour})
+	srv := httptest.NewTLSServer(newRouter(repo, auth.NewMemorySessionManager(), hasher, throttle, nil, nil,
+		health.NewReadiness(time.Second, 0), testServiceToken, time.Second, ""))
+	defer srv.Close()
+
+	codes := make(chan int, 20)
+	for i := 0; i < cap(codes); i++ {
+		go func() {
+			resp, err := srv.Client().Post(srv.URL+"/session", "application/json",
+				strings.NewReader(`{"email":"alice@test.ru","password":"wrong"}`))
+			if err != nil {
+				t.Error(err)
+				codes <- 0
+				return
+			}
+			resp.Body.Close()
+			codes <- resp.StatusCode
+		}()
+	}
+	throttled := 0
+	for i := 0; i < cap(codes); i++ {
+		if <-codes == http.StatusTooManyRequests {
+			throttled++
+		}
+	}
+	if n := atomic.LoadInt32(&repo.compared); n > 3 || throttled != cap(codes)-int(n) {
+		t.Fatalf("%v logins reached the compare, %v were throttled", n, throttled)
+	}
+}
+
+func TestAllSessions(t *testing.T) {
+	e := newTestEnv(t)
+	alice := e.client()
+	alice.register("alice")
+	bobby := e.client()
+	bobby.register("bobby")
+
+	loginFrom := func(userAgent, ip string) *testClient {
+		c := e.client()
+		c.send(http.MethodPost, "/session", "application/json",
+			strings.NewReader(`{"email":"alice@test.ru","password":"password"}`),
+			http.Header{"User-Agent": {userAgent}, testRealIPHeader: {ip}}).expect(http.StatusOK)
+		return c
+	}
+	laptop := loginFrom("Mozilla/5.0 (Windows NT 10.0; Win64; x64)", "192.0.2.7")
+	phone := loginFrom("Mozilla/5.0 (Linux; Android 9; SM-G960F)", "192.0.2.8")
+
+	e.client().do(http.MethodGet, "/session/all", "").expect(http.StatusUnauthorized)
+	laptop.do(http.MethodPost, "/session/all", "").expect(http.StatusMethodNotAllowed)
+	resp := laptop.do(http.MethodGet, "/session/all", "").expect(http.StatusOK)
+	if strings.Contains(string(resp.body), phone.sessionID()) {
+		t.Fatalf("session IDs are sent: %s", resp.body)
+	}
+	list := &models.SessionList{}
+	resp.decode(list)
+	devices := map[string]string{}
+	current := 0
+	for _, s := range list.Sessions {
+		devices[s.Device] = s.IP
+		if s.Current {
+			current++
+			if s.Device != "Windows" {
+				t.Fatalf("wrong current session: %+v", s)
+			}
+		}
+	}
+	if len(list.Sessions) != 3 || current != 1 || devices["Windows"] != "192.0.2.7" ||
+		devices["Android"] != "192.0.2.8" {
+		t.Fatalf("unexpected sessions: %s", resp.body)
+	}
+
+	// the session used last is the first, the laptop has been used after the phone
+	list = &models.SessionList{}
+	phone.do(http.MethodGet, "/session/all", "").expect(http.StatusOK).decode(list)
+	if !list.Sessions[0].Current || list.Sessions[1].Device != "Windows" {
+		t.Fatalf("sessions are not sorted by the last use: %+v", list.Sessions)
+	}
+
+	laptop.do(http.MethodDelete, "/session/all?except_current=maybe", "").expect(http.StatusBadRequest)
+	laptop.do(http.MethodDelete, "/session/all?except_current=true", "").expect(http.StatusOK)
+	laptop.do(http.MethodGet, "/session", "").expect(http.StatusOK)
+	alice.do(http.MethodGet, "/session", "").expect(http.StatusUnauthorized)
+	phone.do(http.MethodGet, "/session", "").expect(http.StatusUnauthorized)
+	list = &models.SessionList{}
+	laptop.do(http.MethodGet, "/session/all", "").expect(http.StatusOK).decode(list)
+	if len(list.Sessions) != 1 || !list.Sessions[0].Current {
+		t.Fatalf("unexpected sessions: %+v", list.Sessions)
+	}
+
+	phone = loginFrom("Mozilla/5.0 (iPhone; CPU iPhone OS 12_1 like Mac OS X)", "192.0.2.8")
+	laptop.do(http.MethodDelete, "/session/all", "").expect(http.StatusOK)
+	laptop.do(http.MethodGet, "/session", "").expect(http.StatusUnauthorized)
+	phone.do(http.MethodGet, "/session", "").expect(http.StatusUnauthorized)
+	// the sessions of the others are not affected
+	bobby.do(http.MethodGet, "/session", "").expect(http.StatusOK)
+}
+
+func TestExpiredSession(t *testing.T) {
+	e := newTestEnv(t)
+	c := e.client()
+	c.register("alice")
+
+	err := e.sm.Delete(c.sessionID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := c.do(http.MethodGet, "/profile", "").expect(http.StatusUnauthorized)
+	if !strings.HasPrefix(resp.header.Get("Set-Cookie"), "session_id=") {
+		t.Fatalf("invalid cookie is not deleted: %v", resp.header)
+	}
+	c.do(http.MethodGet, "/session", "").expect(http.StatusUnauthorized)
+}
+
+func TestAuthServiceDown(t *testing.T) {
+	e := newTestEnv(t)
+	c := e.client()
+	c.register("alice")
+
+	e.sm.setDown(true)
+	c.do(http.MethodGet, "/profile", "").expect(http.StatusInternalServerError)
+	e.client().do(http.MethodPost, "/session", `{"email":"alice@test.ru","password":"password"}`).
+		expect(http.StatusInternalServerError)
+	e.client().do(http.MethodPost, "/profile", registerBody("bobby", "bobby@test.ru", testPassword)).
+		expect(http.StatusInternalServerError)
+	// the handlers without sessions still work
+	c.do(http.MethodGet, "/profile/check?nickname=alice", "").expect(http.StatusForbidden)
+
+	e.sm.setDown(false)
+	c.profile()
+}
+
+func TestProfile(t *testing.T) {
+	e := newTestEnv(t)
+	alice := e.client()
+	me := alice.register("alice")
+	bob := e.client()
+	bob.register("bobby")
+
+	if me.Email != "alice@test.ru" || me.Nickname != "alice" || me.Coins == nil ||
+		me.Skin == nil || *me.Skin != 1 || len(me.PurchasedSkins) != 1 || me.Daily == nil {
+		t.Fatalf("invalid own profile: %+v", me)
+	}
+
+	public := &models.Profile{}
+	bob.do(http.MethodGet, fmt.Sprintf("/profile?id=%v", me.UserID), "").expect(http.StatusOK).decode(public)
+	if public.UserID != me.UserID || public.Nickname != "alice" || public.Email != "" || public.Coins != nil {
+		t.Fatalf("invalid public profile: %+v", public)
+	}
+	public = &models.Profile{}
+	e.client().do(http.MethodGet, "/profile?nickname=alice", "").expect(http.StatusOK).decode(public)
+	if public.UserID != me.UserID || public.Email != "" {
+		t.Fatalf("invalid public profile: %+v", public)
+	}
+
+	anon := e.client()
+	anon.do(http.MethodGet, "/profile", "").expect(http.StatusUnauthorized)
+	anon.do(http.MethodGet, "/profile?id=abc", "").expect(http.StatusBadRequest)
+	anon.do(http.MethodGet, "/profile?id=1&matches=abc", "").expect(http.StatusBadRequest)
+	anon.do(http.MethodGet, "/profile?id=100500", "").expect(http.StatusNotFound)
+	anon.do(http.MethodGet, "/profile?nickname=nobody", "").expect(http.StatusNotFound)
+	anon.do(http.MethodPatch, "/profile", "").expect(http.StatusMethodNotAllowed)
+
+	anon.do(http.MethodGet, "/profile/check?nickname=alice", "").expect(http.StatusForbidden)
+	anon.do(http.MethodGet, "/profile/check?email=alice@test.ru", "").expect(http.StatusForbidden)
+	anon.do(http.MethodGet, "/profile/check?nickname=carol", "").expect(http.StatusOK)
+	anon.do(http.MethodGet, "/profile/check", "").expect(http.StatusBadRequest)
+}
+
+func TestUpdateProfile(t *testing.T) {
+	e := newTestEnv(t)
+	alice := e.client()
+	alice.register("alice")
+	e.client().register("bobby")
+
+	e.client().do(http.MethodPut, "/profile", `{"nickname":"alice2"}`).expect(http.StatusUnauthorized)
+	alice.do(http.MethodPut, "/profile", `{"nickname":`).expect(http.StatusBadRequest)
+	tests := []struct {
+		name   string
+		body   string
+		fields []string
+	}{
+		{"taken nickname", `{"nickname":"bobby"}`, []string{"nickname"}},
+		{"taken email", `{"email":"bobby@test.ru","current_password":"password"}`, []string{"email"}},
+		{"invalid email", `{"email":"not an email","current_password":"password"}`, []string{"email"}},
+		{"short password", `{"password":"abc","current_password":"password"}`, []string{"password"}},
+		{"email without current password", `{"email":"alice2@test.ru"}`, []string{"current_password"}},
+		{"password without current password", `{"password":"new password"}`, []string{"current_password"}},
+		{"wrong current password", `{"password":"new password","current_password":"wrong"}`,
+			[]string{"current_password"}},
+		{"all wrong", `{"nickname":"bobby","email":"bobby@test.ru","current_password":"wrong"}`,
+			[]string{"current_password", "nickname", "email"}},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			resp := alice.do(http.MethodPut, "/profile", tc.body).expect(http.StatusForbidden)
+			errs := &models.ProfileErrorList{}
+			resp.decode(errs)
+			got := []string{}
+			for _, pe := range errs.Errors {
+				got = append(got, pe.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tc.fields, ",") {
+				t.Fatalf("expected errors in %v, got %s", tc.fields, resp.body)
+			}
+		})
+	}
+	alice.do(http.MethodPut, "/profile", `{}`).expect(http.StatusOK)
+	if p := alice.profile(); p.Nickname != "alice" || p.Email != "alice@test.ru" {
+		t.Fatalf("profile changed by invalid updates: %+v", p)
+	}
+
+	// the nickname is not sensitive
+	alice.do(http.MethodPut, "/profile", `{"nickname":"alice2"}`).expect(http.StatusOK)
+	alice.do(http.MethodPut, "/profile", `{"email":"alice2@test.ru","current_password":"password"}`).
+		expect(http.StatusOK)
+	if p := alice.profile(); p.Nickname != "alice2" || p.Email != "alice2@test.ru" {
+		t.Fatalf("profile is not updated: %+v", p)
+	}
+
+	// the other clients are logged out by the password change
+	other := e.client()
+	other.do(http.MethodPost, "/session", `{"email":"alice2@test.ru","password":"password"}`).
+		expect(http.StatusOK)
+	alice.do(http.MethodPut, "/profile", `{"password":"new password","current_password":"password"}`).
+		expect(http.StatusOK)
+	alice.do(http.MethodGet, "/session", "").expect(http.StatusOK)
+	other.do(http.MethodGet, "/session", "").expect(http.StatusUnauthorized)
+
+	c := e.client()
+	c.do(http.MethodPost, "/session", `{"email":"alice2@test.ru","password":"password"}`).
+		expect(http.StatusUnprocessableEntity)
+	c.do(http.MethodPost, "/session", `{"email":"alice2@test.ru","password":"new password"}`).
+		expect(http.StatusOK)
+}
+
+func TestPasswordRehash(t *testing.T) {
+	e := newTestEnv(t)
+	weak, err := auth.NewPasswordHasher(auth.HashBcrypt, 4, 0, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := weak.Hash(testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = e.repo.CreateNewUser(context.Background(), &models.RegisterProfile{
+		Nickname:     "alice",
+		UserPassword: models.UserPassword{Email: "alice@test.ru", Password: hash},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := func() string {
+		u, err := e.repo.GetUserPassword(context.Background(), "alice@test.ru")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u.Password
+	}
+
+	// the wrong password doesn't touch the hash
+	e.client().do(http.MethodPost, "/session", `{"email":"alice@test.ru","password":"wrong"}`).
+		expect(http.StatusUnprocessableEntity)
+	if stored() != hash {
+		t.Fatal("hash is changed by the wrong password")
+	}
+
+	e.client().do(http.MethodPost, "/session", `{"email":"alice@test.ru","password":"password"}`).
+		expect(http.StatusOK)
+	upgraded := stored()
+	if !strings.HasPrefix(upgraded, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Fatalf("hash is not upgraded: %v", upgraded)
+	}
+	e.client().do(http.MethodPost, "/session", `{"email":"alice@test.ru","password":"password"}`).
+		expect(http.StatusOK)
+	if stored() != upgraded {
+		t.Fatal("up-to-date hash is changed")
+	}
+}
+
+func avatarForm(field string, content []byte) (string, io.Reader) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	fw, _ := w.CreateFormFile(field, "avatar.png")
+	_, _ = fw.Write(content)
+	_ = w.Close()
+
+	return w.FormDataContentType(), body
+}
+
+func TestAvatar(t *testing.T) {
+	e := newTestEnv(t)
+	c := e.client()
+	c.register("alice")
+	avatar := []byte("\x89PNG not really")
+
+	contentType, body := avatarForm("avatar", avatar)
+	e.client().send(http.MethodPut, "/profile/avatar", contentType, body, nil).expect(http.StatusUnauthorized)
+	c.do(http.MethodPut, "/profile/avatar", `{"avatar":"no"}`).expect(http.StatusBadRequest)
+	contentType, body = avatarForm("picture", avatar)
+	c.send(http.MethodPut, "/profile/avatar", contentType, body, nil).expect(http.StatusBadRequest)
+
+	contentType, body = avatarForm("avatar", avatar)
+	c.send(http.MethodPut, "/profile/avatar", contentType, body, nil).expect(http.StatusOK)
+	p := c.profile()
+	if p.Avatar == nil || !strings.HasPrefix(*p.Avatar, "/static/img/") {
+		t.Fatalf("avatar is not saved: %+v", p)
+	}
+	resp := e.client().do(http.MethodGet, *p.Avatar, "").expect(http.StatusOK)
+	if !bytes.Equal(resp.body, avatar) {
+		t.Fatalf("avatar is served with other content: %q", resp.body)
+	}
+
+	e.client().do(http.MethodDelete, "/profile/avatar", "").expect(http.StatusUnauthorized)
+	c.do(http.MethodDelete, "/profile/avatar", "").expect(http.StatusOK)
+	if p := c.profile(); p.Avatar != nil {
+		t.Fatalf("avatar is not deleted: %v", *p.Avatar)
+	}
+	c.do(http.MethodGet, "/profile/avatar", "").expect(http.StatusMethodNotAllowed)
+}
+
+func TestSkins(t *testing.T) {
+	e := newTestEnv(t)
+	c := e.client()
+	me := c.register("alice")
+
+	skins := &models.AllSkins{}
+	e.client().do(http.MethodGet, "/profile/skin", "").expect(http.StatusOK).decode(skins)
+	names := []string{}
+	for _, s := range skins.Skins {
+		names = append(names, s.Name)
+	}
+	if strings.Join(names, ",") != "Classic,Nature,Home,Pumpkin,Freak,Christmas" {
+		t.Fatalf("unexpected skins: %v", names)
+	}
+	skin := &models.Skin{}
+	c.do(http.MethodGet, "/profile/skin?id=2", "").expect(http.StatusOK).decode(skin)
+	if skin.Name != "Nature" || skin.Price != 50 {
+		t.Fatalf("unexpected skin: %+v", skin)
+	}
+	c.do(http.MethodGet, "/profile/skin?id=100", "").expect(http.StatusNotFound)
+	c.do(http.MethodGet, "/profile/skin?id=abc", "").expect(http.StatusBadRequest)
+
+	// buy
+	e.client().do(http.MethodPost, "/profile/skin", `{"skin":2}`).expect(http.StatusUnauthorized)
+	c.do(http.MethodPost, "/profile/skin", `{"skin":`).expect(http.StatusBadRequest)
+	c.do(http.MethodPost, "/profile/skin", `{"skin":100}`).expect(http.StatusNotFound)
+	c.do(http.MethodPost, "/profile/skin", `{"skin":5}`).expect(http.StatusUnprocessableEntity)
+
+	err := e.repo.ChangeUserCoinAmount(context.Background(), me.UserID, 100, models.CoinReasonAdjustment, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := *c.profile().Coins
+	c.do(http.MethodPost, "/profile/skin", `{"skin":2}`).expect(http.StatusOK)
+	p := c.profile()
+	if *p.Coins != before-50 || len(p.PurchasedSkins) != 2 {
+		t.Fatalf("skin is not bought: %v coins before, %+v", before, p)
+	}
+	// already owned, nothing is charged
+	c.do(http.MethodPost, "/profile/skin", `{"skin":2}`).expect(http.StatusOK)
+	if got := *c.profile().Coins; got != before-50 {
+		t.Fatalf("expected %v coins, got %v", before-50, got)
+	}
+	// still owned after it is retired
+	retired, err := e.repo.GetSkin(context.Background(), 2, me.UserID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	retired.Retired = true
+	if err := e.repo.UpdateSkin(context.Background(), retired); err != nil {
+		t.Fatal(err)
+	}
+	c.do(http.MethodPost, "/profile/skin", `{"skin":2}`).expect(http.StatusOK)
+	// free skin, the third one is rewarded by the achievement
+	c.do(http.MethodPost, "/profile/skin", `{"skin":6}`).expect(http.StatusOK)
+	if p := c.profile(); len(p.PurchasedSkins) != 3 || *p.Coins != before-50+30 {
+		t.Fatalf("free skin is not bought: %+v", p)
+	}
+
+	// equip
+	e.client().do(http.MethodPut, "/profile/skin", `{"skin":2}`).expect(http.StatusUnauthorized)
+	c.do(http.MethodPut, "/profile/skin", `{"skin":`).expect(http.StatusBadRequest)
+	c.do(http.MethodPut, "/profile/skin", `{"skin":3}`).expect(http.StatusUnprocessableEntity)
+	c.do(http.MethodPut, "/profile/skin", `{"skin":0}`).expect(http.StatusUnprocessableEntity)
+	c.do(http.MethodPut, "/profile/skin", `{"skin":2}`).expect(http.StatusOK)
+	if p := c.profile(); p.Skin == nil || *p.Skin != 2 {
+		t.Fatalf("skin is not equipped: %+v", p)
+	}
+	// already equipped
+	c.do(http.MethodPut, "/profile/skin", `{"skin":2}`).expect(http.StatusOK)
+	c.do(http.MethodDelete, "/profile/skin", "").expect(http.StatusMethodNotAllowed)
+}
+
+func TestBundlePartialOwnership(t *testing.T) {
+	e := newTestEnv(t)
+	c := e.client()
+	me := c.register("alice")
+	bundle := &models.Bundle{Name: "Autumn", Cost: 100, Skins: []uint{2, 3, 4}}
+	if err := e.repo.CreateBundle(context.Background(), bundle); err != nil {
+		t.Fatal(err)
+	}
+	err := e.repo.ChangeUserCoinAmount(context.Background(), me.UserID, 200, models.CoinReasonAdjustment, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.do(http.MethodPost, "/profile/skin", `{"skin":2}`).expect(http.StatusOK)
+
+	// 2 of 3 skins are missing: 100 * 2 / 3 rounded up, the third skin is rewarded by the achievement
+	before := *c.profile().Coins
+	c.do(http.MethodPost, "/store/bundles", fmt.Sprintf(`{"bundle":%v}`, bundle.ID)).expect(http.StatusOK)
+	p := c.profile()
+	if *p.Coins != before-67+30 || len(p.PurchasedSkins) != 4 {
+		t.Fatalf("expected %v coins and 4 skins, got %+v", before-67+30, p)
+	}
+	// everything is owned, nothing is charged
+	c.do(http.MethodPost, "/store/bundles", fmt.Sprintf(`{"bundle":%v}`, bundle.ID)).expect(http.StatusOK)
+	if got := *c.profile().Coins; got != before-67+30 {
+		t.Fatalf("expected %v coins, got %v", before-67+30, got)
+	}
+}
+
+func TestGifts(t *testing.T) {
+	e := newTestEnv(t)
+	alice := e.client()
+	me := alice.register("alice")
+	bob := e.client()
+	him := bob.register("bobby")
+	for _, uID := range []uint{me.UserID, him.UserID} {
+		err := e.repo.ChangeUserCoinAmount(context.Background(), uID, 200, models.CoinReasonAdjustment, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	coins := func(c *testClient) int {
+		t.Helper()
+		return *c.profile().Coins
+	}
+	send := func(body string) *models.Gift {
+		t.Helper()
+		g := &models.Gift{}
+		alice.do(http.MethodPost, "/profile/gifts", body).expect(http.StatusCreated).decode(g)
+		return g
+	}
+	act := func(c *testClient, g *models.Gift, action string) *testResponse {
+		t.Helper()
+		return c.do(http.MethodPut, "/profile/gifts", fmt.Sprintf(`{"id":%v,"action":%q}`, g.ID, action))
+	}
+	aliceCoins, bobCoins := coins(alice), coins(bob)
+
+	e.client().do(http.MethodPost, "/profile/gifts", `{"nickname":"bobby","coins":30}`).expect(http.StatusUnauthorized)
+	alice.do(http.MethodPost, "/profile/gifts", `{"nickname":"bobby"}`).expect(http.StatusUnprocessableEntity)
+	alice.do(http.MethodPost, "/profile/gifts", `{"nickname":"alice","coins":30}`).expect(http.StatusUnprocessableEntity)
+	alice.do(http.MethodPost, "/profile/gifts", `{"nickname":"nobody","coins":30}`).expect(http.StatusNotFound)
+	alice.do(http.MethodPost, "/profile/gifts", `{"nickname":"bobby","coins":100000}`).
+		expect(http.StatusTooManyRequests)
+
+	// the coins are kept in the gift until it is resolved, the sender can't spend them twice
+	declined := send(`{"nickname":"bobby","coins":30,"message":"hi"}`)
+	if got := coins(alice); got != aliceCoins-30 {
+		t.Fatalf("expected %v coins kept in the gift, got %v", aliceCoins-30, got)
+	}
+	list := &models.GiftList{}
+	bob.do(http.MethodGet, "/profile/gifts", "").expect(http.StatusOK).decode(list)
+	if len(list.Incoming) != 1 || list.Incoming[0].ID != declined.ID || list.Incoming[0].FromNickname != "alice" {
+		t.Fatalf("unexpected inbox: %+v", list)
+	}
+	act(alice, declined, models.GiftActionDecline).expect(http.StatusNotFound)
+	act(bob, declined, "keep").expect(http.StatusBadRequest)
+	act(bob, declined, models.GiftActionDecline).expect(http.StatusOK)
+	act(bob, declined, models.GiftActionAccept).expect(http.StatusNotFound)
+	if a, b := coins(alice), coins(bob); a != aliceCoins || b != bobCoins {
+		t.Fatalf("declined gift is not refunded: alice %v, bob %v", a, b)
+	}
+
+	accepted := send(fmt.Sprintf(`{"id":%v,"coins":40}`, him.UserID))
+	act(bob, accepted, models.GiftActionAccept).expect(http.StatusOK)
+	if a, b := coins(alice), coins(bob); a != aliceCoins-40 || b != bobCoins+40 {
+		t.Fatalf("coins are not given: alice %v, bob %v", a, b)
+	}
+
+	// the skin bought after the gift was sent is refunded on accept
+	skin := send(`{"nickname":"bobby","skin":2}`)
+	alice.do(http.MethodPost, "/profile/gifts", `{"nickname":"bobby","skin":2}`).expect(http.StatusConflict)
+	if got := coins(alice); got != aliceCoins-40-50 {
+		t.Fatalf("expected the price of the skin kept in the gift, got %v", got)
+	}
+	bob.do(http.MethodPost, "/profile/skin", `{"skin":2}`).expect(http.StatusOK)
+	act(bob, skin, models.GiftActionAccept).expect(http.StatusConflict)
+	if got := coins(alice); got != aliceCoins-40 {
+		t.Fatalf("gift of the owned skin is not refunded, got %v", got)
+	}
+	list = &models.GiftList{}
+	alice.do(http.MethodGet, "/profile/gifts", "").expect(http.StatusOK).decode(list)
+	statuses := []string{}
+	for _, g := range list.Sent {
+		statuses = append(statuses, g.Status)
+	}
+	if strings.Join(statuses, ",") != "declined,accepted,declined" {
+		t.Fatalf("unexpected sent gifts: %v", statuses)
+	}
+}
+
+func TestCoinHistory(t *testing.T) {
+	e := newTestEnv(t)
+	c := e.client()
+	me := c.register("alice")
+	for _, sum := range []int{100, -30, 50} {
+		err := e.repo.ChangeUserCoinAmount(context.Background(), me.UserID, sum, models.CoinReasonAdjustment, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.do(http.MethodPost, "/profile/skin", `{"skin":2}`).expect(http.StatusOK)
+	coins := *c.profile().Coins
+
+	e.client().do(http.MethodGet, "/profile/coins/history", "").expect(http.StatusUnauthorized)
+	for _, query := range []string{"?limit=abc", "?limit=-1", "?before=abc"} {
+		c.do(http.MethodGet, "/profile/coins/history"+query, "").expect(http.StatusBadRequest)
+	}
+	c.do(http.MethodPost, "/profile/coins/history", "").expect(http.StatusMethodNotAllowed)
+
+	// the newest first, the balance is the one after the transaction
+	first := &models.CoinHistory{}
+	c.do(http.MethodGet, "/profile/coins/history?limit=2", "").expect(http.StatusOK).decode(first)
+	if len(first.Transactions) != 2 || first.Next == "" {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+	purchase := first.Transactions[0]
+	if purchase.Amount != -50 || purchase.Reason != models.CoinReasonSkinPurchase || purchase.Balance != coins {
+		t.Fatalf("unexpected purchase: %+v, %v coins", purchase, coins)
+	}
+	if first.Transactions[1].Amount != 50 || first.Transactions[1].Balance != coins+50 {
+		t.Fatalf("unexpected adjustment: %+v", first.Transactions[1])
+	}
+	all := &models.CoinHistory{}
+	c.do(http.MethodGet, "/profile/coins/history?limit=100", "").expect(http.StatusOK).decode(all)
+	next := &models.CoinHistory{}
+	c.do(http.MethodGet, "/profile/coins/history?limit=2&before="+first.Next, "").expect(http.StatusOK).decode(next)
+	if len(next.Transactions) == 0 || next.Transactions[0].ID != all.Transactions[2].ID {
+		t.Fatalf("cursor page %+v doesn't follow the first one in %+v", next, all)
+	}
+	// the other players don't see the transactions
+	bob := e.client()
+	bob.register("bobby")
+	other := &models.CoinHistory{}
+	bob.do(http.MethodGet, "/profile/coins/history?limit=100", "").expect(http.StatusOK).decode(other)
+	for _, tr := range other.Transactions {
+		for _, mine := range all.Transactions {
+			if tr.ID == mine.ID {
+				t.Fatalf("transaction %+v of alice is in the history of bobby", tr)
+			}
+		}
+	}
+
+	// the ledger matches the balances
+	e.client().do(http.MethodGet, "/coins/check", "").expect(http.StatusUnauthorized)
+	report := &models.CoinLedgerReport{}
+	e.client().service(http.MethodGet, "/coins/check", "").expect(http.StatusOK).decode(report)
+	if report.CheckedUsers != 2 || len(report.Drifts) != 0 {
+		t.Fatalf("unexpected ledger report: %+v", report)
+	}
+}
+
+func TestPromoCodes(t *testing.T) {
+	e := newTestEnv(t)
+	admin := e.client()
+	me := admin.register("alice")
+	c := e.client()
+	c.register("bobby")
+	create := func(body string) []models.PromoCode {
+		t.Helper()
+		list := &models.PromoCodeList{}
+		admin.do(http.MethodPost, "/admin/promo", body).expect(http.StatusCreated).decode(list)
+		return list.Codes
+	}
+	redeem := func(c *testClient, code string) *testResponse {
+		t.Helper()
+		return c.do(http.MethodPost, "/store/redeem", fmt.Sprintf(`{"code":%q}`, code))
+	}
+
+	admin.do(http.MethodPost, "/admin/promo", `{"code":"GIFT","coins":100}`).expect(http.StatusForbidden)
+	e.admins.Store(me.UserID, true)
+	for _, body := range []string{`{"code":"GIFT"}`, `{"code":"GIFT","coins":100,"skin":2}`,
+		`{"code":"GIFT!","coins":100}`, `{"code":"GIFT","coins":100,"max_redemptions":0}`,
+		`{"coins":100}`, `{"coins":100,"count":1001}`, `{"coins":100,"count":1,"prefix":"N Y"}`} {
+		admin.do(http.MethodPost, "/admin/promo", body).expect(http.StatusUnprocessableEntity)
+	}
+	admin.do(http.MethodPost, "/admin/promo", `{"code":`).expect(http.StatusBadRequest)
+	admin.do(http.MethodPost, "/admin/promo", `{"code":"NOSKIN","skin":100}`).expect(http.StatusNotFound)
+	admin.do(http.MethodGet, "/admin/promo", "").expect(http.StatusMethodNotAllowed)
+
+	// the codes are case-insensitive
+	if codes := create(`{"code":"gift","coins":100}`); len(codes) != 1 || codes[0].Code != "GIFT" {
+		t.Fatalf("unexpected codes: %+v", codes)
+	}
+	admin.do(http.MethodPost, "/admin/promo", `{"code":"GIFT","coins":10}`).expect(http.StatusConflict)
+	batch := create(`{"count":3,"prefix":"ny","skin":2}`)
+	if len(batch) != 3 || !strings.HasPrefix(batch[0].Code, "NY") || batch[0].Code == batch[1].Code {
+		t.Fatalf("unexpected batch: %+v", batch)
+	}
+	once := create(`{"code":"ONCE","coins":10,"max_redemptions":1}`)[0]
+	expired := create(fmt.Sprintf(`{"code":"OLD","coins":100,"expires_at":%q}`,
+		time.Now().Add(-time.Hour).Format(time.RFC3339)))[0]
+
+	e.client().do(http.MethodPost, "/store/redeem", `{"code":"GIFT"}`).expect(http.StatusUnauthorized)
+	c.do(http.MethodPost, "/store/redeem", `{"code":`).expect(http.StatusBadRequest)
+	redeem(c, "").expect(http.StatusNotFound)
+	redeem(c, "NOPE").expect(http.StatusNotFound)
+	redeem(c, expired.Code).expect(http.StatusGone)
+
+	before := *c.profile().Coins
+	reward := &models.PromoReward{}
+	redeem(c, " gift ").expect(http.StatusOK).decode(reward)
+	if reward.Coins != 100 || reward.SkinID != nil {
+		t.Fatalf("unexpected reward: %+v", reward)
+	}
+	if got := *c.profile().Coins; got != before+100 {
+		t.Fatalf("expected %v coins, got %v", before+100, got)
+	}
+	redeem(c, "GIFT").expect(http.StatusConflict)
+	redeem(admin, "GIFT").expect(http.StatusOK)
+	// the only redemption is spent
+	redeem(c, once.Code).expect(http.StatusOK)
+	redeem(admin, once.Code).expect(http.StatusGone)
+
+	reward = &models.PromoReward{}
+	redeem(c, batch[0].Code).expect(http.StatusOK).decode(reward)
+	if reward.SkinID == nil || *reward.SkinID != 2 {
+		t.Fatalf("unexpected reward: %+v", reward)
+	}
+	if p := c.profile(); len(p.PurchasedSkins) != 2 {
+		t.Fatalf("skin is not given: %+v", p)
+	}
+	// the skin is already owned, the code isn't spent
+	redeem(c, batch[1].Code).expect(http.StatusConflict)
+	redeem(admin, batch[1].Code).expect(http.StatusOK)
+}
+
+func TestAdmin(t *testing.T) {
+	e := newTestEnv(t)
+	admin := e.client()
+	me := admin.register("alice")
+	c := e.client()
+	c.register("bobby")
+
+	for _, path := range []string{"/admin/skins", "/admin/sales", "/admin/bundles", "/admin/promo"} {
+		e.client().do(http.MethodGet, path, "").expect(http.StatusUnauthorized)
+		c.do(http.MethodGet, path, "").expect(http.StatusForbidden)
+	}
+	e.admins.Store(me.UserID, true)
+
+	// skins
+	for _, body := range []string{`{"name":"","cost":10}`, `{"name":"Ghost","cost":-1}`,
+		`{"name":"Ghost","cost":10,"rarity":"unique"}`,
+		`{"name":"Ghost","cost":10,"available_from":"2019-01-02T00:00:00Z","available_until":"2019-01-01T00:00:00Z"}`} {
+		admin.do(http.MethodPost, "/admin/skins", body).expect(http.StatusUnprocessableEntity)
+	}
+	admin.do(http.MethodPost, "/admin/skins", `{"name":`).expect(http.StatusBadRequest)
+	skin := &models.Skin{}
+	admin.do(http.MethodPost, "/admin/skins", `{"name":"Ghost","cost":80,"hidden":true}`).
+		expect(http.StatusCreated).decode(skin)
+	if skin.ID == 0 || skin.Rarity != models.RarityCommon {
+		t.Fatalf("unexpected skin: %+v", skin)
+	}
+	skinIDs := func(c *testClient, path string) string {
+		t.Helper()
+		skins := &models.AllSkins{}
+		c.do(http.MethodGet, path, "").expect(http.StatusOK).decode(skins)
+		ids := []string{}
+		for _, s := range skins.Skins {
+			ids = append(ids, fmt.Sprint(s.ID))
+		}
+		return strings.Join(ids, ",")
+	}
+	// the hidden skin is listed only for the admins
+	if got := skinIDs(admin, "/admin/skins"); !strings.HasSuffix(got, fmt.Sprintf(",%v", skin.ID)) {
+		t.Fatalf("new skin is not listed: %v", got)
+	}
+	if got := skinIDs(c, "/profile/skin"); got != "1,2,3,4,5,6" {
+		t.Fatalf("hidden skin is listed: %v", got)
+	}
+	admin.do(http.MethodPut, "/admin/skins", `{"id":100,"name":"Ghost","cost":80}`).expect(http.StatusNotFound)
+	admin.do(http.MethodPut, "/admin/skins", fmt.Sprintf(`{"id":%v,"name":"Ghost","cost":-1}`, skin.ID)).
+		expect(http.StatusUnprocessableEntity)
+	admin.do(http.MethodPut, "/admin/skins", fmt.Sprintf(`{"id":%v,"name":"Ghost","cost":80}`, skin.ID)).
+		expect(http.StatusOK)
+	if got := skinIDs(c, "/profile/skin"); got != fmt.Sprintf("1,2,3,4,5,6,%v", skin.ID) {
+		t.Fatalf("shown skin is not listed: %v", got)
+	}
+
+	// sales, the biggest discount wins
+	now := time.Now()
+	sale := func(discount string) string {
+		return fmt.Sprintf(`{"skin":%v,%v,"starts_at":%q,"ends_at":%q}`, skin.ID, discount,
+			now.Add(-time.Hour).Format(time.RFC3339), now.Add(time.Hour).Format(time.RFC3339))
+	}
+	for _, discount := range []string{`"percent_off":20,"amount_off":10`, `"percent_off":0`, `"percent_off":101`,
+		`"amount_off":0`} {
+		admin.do(http.MethodPost, "/admin/sales", sale(discount)).expect(http.StatusUnprocessableEntity)
+	}
+	admin.do(http.MethodPost, "/admin/sales", strings.Replace(sale(`"amount_off":10`),
+		fmt.Sprintf(`"skin":%v`, skin.ID), `"skin":100`, 1)).expect(http.StatusNotFound)
+	admin.do(http.MethodPost, "/admin/sales", sale(`"percent_off":25`)).expect(http.StatusCreated)
+	amount := &models.Sale{}
+	admin.do(http.MethodPost, "/admin/sales", sale(`"amount_off":10`)).expect(http.StatusCreated).decode(amount)
+	sales := &models.SaleList{}
+	admin.do(http.MethodGet, "/admin/sales", "").expect(http.StatusOK).decode(sales)
+	if len(sales.Sales) != 2 {
+		t.Fatalf("unexpected sales: %+v", sales)
+	}
+	price := func() int {
+		t.Helper()
+		s := &models.Skin{}
+		c.do(http.MethodGet, fmt.Sprintf("/profile/skin?id=%v", skin.ID), "").expect(http.StatusOK).decode(s)
+		return s.Price
+	}
+	if got := price(); got != 60 {
+		t.Fatalf("expected the price 60 with 25%% off, got %v", got)
+	}
+	admin.do(http.MethodDelete, "/admin/sales?id=abc", "").expect(http.StatusBadRequest)
+	admin.do(http.MethodDelete, "/admin/sales?id=100", "").expect(http.StatusNotFound)
+	for _, s := range sales.Sales {
+		if s.ID != amount.ID {
+			admin.do(http.MethodDelete, fmt.Sprintf("/admin/sales?id=%v", s.ID), "").expect(http.StatusOK)
+		}
+	}
+	if got := price(); got != 70 {
+		t.Fatalf("expected the price 70 with 10 coins off, got %v", got)
+	}
+
+	// bundles
+	admin.do(http.MethodPost, "/admin/bundles", `{"name":"Empty","cost":10,"skins":[]}`).
+		expect(http.StatusUnprocessableEntity)
+	admin.do(http.MethodPost, "/admin/bundles", `{"name":"Lost","cost":10,"skins":[100]}`).
+		expect(http.StatusNotFound)
+	bundle := &models.Bundle{}
+	admin.do(http.MethodPost, "/admin/bundles", fmt.Sprintf(`{"name":"Spooky","cost":100,"skins":[4,%v]}`, skin.ID)).
+		expect(http.StatusCreated).decode(bundle)
+	bundles := &models.BundleList{}
+	c.do(http.MethodGet, "/store/bundles", "").expect(http.StatusOK).decode(bundles)
+	if len(bundles.Bundles) != 1 || bundles.Bundles[0].FullPrice != 150+70 {
+		t.Fatalf("unexpected bundles: %+v", bundles)
+	}
+	admin.do(http.MethodDelete, "/admin/bundles?id=100", "").expect(http.StatusNotFound)
+	admin.do(http.MethodDelete, fmt.Sprintf("/admin/bundles?id=%v", bundle.ID), "").expect(http.StatusOK)
+	bundles = &models.BundleList{}
+	admin.do(http.MethodGet, "/admin/bundles", "").expect(http.StatusOK).decode(bundles)
+	if len(bundles.Bundles) != 0 {
+		t.Fatalf("bundle is not deleted: %+v", bundles)
+	}
+
+	// the bought skin can only be retired
+	err := e.repo.ChangeUserCoinAmount(context.Background(), me.UserID, 100, models.CoinReasonAdjustment, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin.do(http.MethodPost, "/profile/skin", fmt.Sprintf(`{"skin":%v}`, skin.ID)).expect(http.StatusOK)
+	admin.do(http.MethodDelete, "/admin/skins?id=abc", "").expect(http.StatusBadRequest)
+	admin.do(http.MethodDelete, "/admin/skins?id=100", "").expect(http.StatusNotFound)
+	admin.do(http.MethodDelete, fmt.Sprintf("/admin/skins?id=%v", skin.ID), "").expect(http.StatusConflict)
+	unsold := &models.Skin{}
+	admin.do(http.MethodPost, "/admin/skins", `{"name":"Unsold","cost":10}`).expect(http.StatusCreated).decode(unsold)
+	admin.do(http.MethodDelete, fmt.Sprintf("/admin/skins?id=%v", unsold.ID), "").expect(http.StatusOK)
+	c.do(http.MethodGet, fmt.Sprintf("/profile/skin?id=%v", unsold.ID), "").expect(http.StatusNotFound)
+}
+
+func TestFriends(t *testing.T) {
+	e := newTestEnv(t)
+	alice := e.client()
+	me := alice.register("alice")
+	bob := e.client()
+	him := bob.register("bobby")
+	friends := func(c *testClient) *models.FriendList {
+		t.Helper()
+		list := &models.FriendList{}
+		c.do(http.MethodGet, "/profile/friends", "").expect(http.StatusOK).decode(list)
+		return list
+	}
+	nicknames := func(friends []models.Friend) string {
+		res := []string{}
+		for _, f := range friends {
+			res = append(res, f.Nickname)
+		}
+		return strings.Join(res, ",")
+	}
+	request := func(c *testClient, nickname string) *testResponse {
+		t.Helper()
+		return c.do(http.MethodPost, "/profile/friends", fmt.Sprintf(`{"nickname":%q}`, nickname))
+	}
+	act := func(c *testClient, nickname, action string) *testResponse {
+		t.Helper()
+		return c.do(http.MethodPut, "/profile/friends", fmt.Sprintf(`{"nickname":%q,"action":%q}`, nickname, action))
+	}
+
+	e.client().do(http.MethodGet, "/profile/friends", "").expect(http.StatusUnauthorized)
+	request(e.client(), "bobby").expect(http.StatusUnauthorized)
+	alice.do(http.MethodPost, "/profile/friends", `{"nickname":`).expect(http.StatusBadRequest)
+	request(alice, "nobody").expect(http.StatusNotFound)
+	request(alice, "alice").expect(http.StatusUnprocessableEntity)
+	alice.do(http.MethodPost, "/profile/friends", `{}`).expect(http.StatusUnprocessableEntity)
+
+	request(alice, "bobby").expect(http.StatusOK)
+	if a, b := friends(alice), friends(bob); nicknames(a.Outgoing) != "bobby" || nicknames(b.Incoming) != "alice" {
+		t.Fatalf("unexpected requests: alice %+v, bobby %+v", a, b)
+	}
+	act(bob, "alice", "ignore").expect(http.StatusBadRequest)
+	act(bob, "bobby", models.FriendActionAccept).expect(http.StatusUnprocessableEntity)
+	act(alice, "bobby", models.FriendActionAccept).expect(http.StatusNotFound)
+	act(bob, "alice", models.FriendActionDecline).expect(http.StatusOK)
+	act(bob, "alice", models.FriendActionDecline).expect(http.StatusNotFound)
+	if a := friends(alice); len(a.Outgoing) != 0 || len(a.Friends) != 0 {
+		t.Fatalf("declined request is left: %+v", a)
+	}
+
+	// the counter request is accepted
+	bob.do(http.MethodPost, "/profile/friends", fmt.Sprintf(`{"id":%v}`, me.UserID)).expect(http.StatusOK)
+	alice.do(http.MethodPost, "/profile/friends", fmt.Sprintf(`{"id":%v}`, him.UserID)).expect(http.StatusOK)
+	if a, b := friends(alice), friends(bob); nicknames(a.Friends) != "bobby" || nicknames(b.Friends) != "alice" ||
+		len(a.Incoming)+len(b.Outgoing) != 0 {
+		t.Fatalf("not friends: alice %+v, bobby %+v", a, b)
+	}
+	request(alice, "bobby").expect(http.StatusOK)
+	scoreboard := &models.PositionList{}
+	alice.do(http.MethodGet, "/scoreboard?scope=friends", "").expect(http.StatusOK).decode(scoreboard)
+	if scoreboard.Total != 2 || len(scoreboard.List) != 2 {
+		t.Fatalf("unexpected scoreboard of the friends: %+v", scoreboard)
+	}
+
+	alice.do(http.MethodDelete, "/profile/friends?id=abc", "").expect(http.StatusBadRequest)
+	alice.do(http.MethodDelete, fmt.Sprintf("/profile/friends?id=%v", him.UserID), "").expect(http.StatusOK)
+	alice.do(http.MethodDelete, fmt.Sprintf("/profile/friends?id=%v", him.UserID), "").expect(http.StatusNotFound)
+	if b := friends(bob); len(b.Friends) != 0 {
+		t.Fatalf("friend is not removed: %+v", b)
+	}
+
+	// the blocked player can't send requests until unblocked
+	act(bob, "alice", models.FriendActionBlock).expect(http.StatusOK)
+	request(alice, "bobby").expect(http.StatusForbidden)
+	if b := friends(bob); nicknames(b.Blocked) != "alice" {
+		t.Fatalf("unexpected blocked players: %+v", b)
+	}
+	act(bob, "alice", models.FriendActionUnblock).expect(http.StatusOK)
+	act(bob, "alice", models.FriendActionUnblock).expect(http.StatusNotFound)
+	request(alice, "bobby").expect(http.StatusOK)
+	act(bob, "alice", models.FriendActionAccept).expect(http.StatusOK)
+	if a := friends(alice); nicknames(a.Friends) != "bobby" || len(a.Blocked) != 0 {
+		t.Fatalf("not friends after the unblock: %+v", a)
+	}
+	alice.do(http.MethodPatch, "/profile/friends", "").expect(http.StatusMethodNotAllowed)
+}
+
+func TestDailyReward(t *testing.T) {
+	e := newTestEnv(t)
+	c := e.client()
+	e.client().do(http.MethodPost, "/profile/daily", "").expect(http.StatusUnauthorized)
+
+	// any other request of the day would give the reward first
+	c.do(http.MethodPost, "/profile", registerBody("alice", "alice@test.ru", testPassword)).expect(http.StatusOK)
+	reward := &models.DailyReward{}
+	c.do(http.MethodPost, "/profile/daily", "").expect(http.StatusOK).decode(reward)
+	if reward.Streak != 1 || reward.Coins != 10 {
+		t.Fatalf("unexpected reward: %+v", reward)
+	}
+	c.do(http.MethodPost, "/profile/daily", "").expect(http.StatusConflict)
+	p := c.profile()
+	if p.Daily == nil || !p.Daily.ClaimedToday || p.Daily.Streak != 1 || p.Daily.NextReward != 15 {
+		t.Fatalf("unexpected streak: %+v", p.Daily)
+	}
+	history := &models.CoinHistory{}
+	c.do(http.MethodGet, "/profile/coins/history", "").expect(http.StatusOK).decode(history)
+	daily := 0
+	for _, tr := range history.Transactions {
+		if tr.Reason == models.CoinReasonDailyReward {
+			daily += tr.Amount
+		}
+	}
+	if daily != 10 {
+		t.Fatalf("expected one daily reward of 10 coins, got %v in %+v", daily, history)
+	}
+	c.do(http.MethodGet, "/profile/daily", "").expect(http.StatusMethodNotAllowed)
+}
+
+func TestAchievements(t *testing.T) {
+	e := newTestEnv(t)
+	c := e.client()
+	me := c.register("alice")
+	anon := e.client()
+	unlocked := func(c *testClient, query string) string {
+		t.Helper()
+		list := &models.AchievementList{}
+		c.do(http.MethodGet, "/profile/achievements"+query, "").expect(http.StatusOK).decode(list)
+		if len(list.Achievements) != 6 {
+			t.Fatalf("expected 6 achievements, got %+v", list)
+		}
+		codes := []string{}
+		for _, a := range list.Achievements {
+			if a.UnlockedAt != nil {
+				codes = append(codes, a.Code)
+			}
+		}
+		sort.Strings(codes)
+		return strings.Join(codes, ",")
+	}
+
+	anon.do(http.MethodGet, "/profile/achievements", "").expect(http.StatusUnauthorized)
+	anon.do(http.MethodGet, "/profile/achievements?id=abc", "").expect(http.StatusBadRequest)
+	anon.do(http.MethodGet, "/profile/achievements?id=100500", "").expect(http.StatusNotFound)
+	c.do(http.MethodPost, "/profile/achievements", "").expect(http.StatusMethodNotAllowed)
+	if got := unlocked(c, ""); got != "" {
+		t.Fatalf("unexpected achievements of the new player: %v", got)
+	}
+
+	before := *c.profile().Coins
+	e.client().service(http.MethodPost, "/matches",
+		fmt.Sprintf(`{"match_id":"m1","players":[{"id":%v,"score":1200,"result":"win","coins":5}]}`, me.UserID)).
+		expect(http.StatusOK)
+	if got := unlocked(c, ""); got != "first_game,record_1000" {
+		t.Fatalf("unexpected achievements after the match: %v", got)
+	}
+	if got := unlocked(anon, fmt.Sprintf("?id=%v", me.UserID)); got != "first_game,record_1000" {
+		t.Fatalf("unexpected achievements by ID: %v", got)
+	}
+	// the rewards are given once
+	e.client().service(http.MethodPost, "/matches",
+		fmt.Sprintf(`{"match_id":"m2","players":[{"id":%v,"score":1300,"result":"win","coins":5}]}`, me.UserID)).
+		expect(http.StatusOK)
+	if got := *c.profile().Coins; got != before+5+10+100+5 {
+		t.Fatalf("expected %v coins, got %v", before+5+10+100+5, got)
+	}
+}
+
+func TestMatchHistory(t *testing.T) {
+	e := newTestEnv(t)
+	alice := e.client()
+	me := alice.register("alice")
+	him := e.client().register("bobby")
+	report := func(id string, aliceScore int, aliceResult string, bobScore int, bobResult string) *testResponse {
+		t.Helper()
+		return e.client().service(http.MethodPost, "/matches", fmt.Sprintf(
+			`{"match_id":%q,"players":[{"id":%v,"score":%v,"result":%q,"coins":1},{"id":%v,"score":%v,"result":%q,"coins":0}]}`,
+			id, me.UserID, aliceScore, aliceResult, him.UserID, bobScore, bobResult))
+	}
+
+	e.client().service(http.MethodPost, "/matches", `{"match_id":`).expect(http.StatusBadRequest)
+	for _, match := range []string{`{"match_id":"","players":[{"id":1,"score":1,"result":"win"}]}`,
+		`{"match_id":"m0","players":[]}`,
+		`{"match_id":"m0","players":[{"id":1,"score":1,"result":"win"},{"id":1,"score":1,"result":"loss"}]}`,
+		`{"match_id":"m0","players":[{"id":1,"score":1,"result":"victory"}]}`,
+		`{"match_id":"m0","players":[{"id":1,"score":-1,"result":"win"}]}`,
+		`{"match_id":"` + strings.Repeat("m", 65) + `","players":[{"id":1,"score":1,"result":"win"}]}`} {
+		e.client().service(http.MethodPost, "/matches", match).expect(http.StatusUnprocessableEntity)
+	}
+	e.client().service(http.MethodPost, "/matches", `{"match_id":"m0","players":[{"id":100500,"score":1,"result":"win"}]}`).
+		expect(http.StatusNotFound)
+	e.client().service(http.MethodGet, "/matches", "").expect(http.StatusMethodNotAllowed)
+
+	report("m1", 100, models.OutcomeWin, 50, models.OutcomeLoss).expect(http.StatusOK)
+	report("m2", 200, models.OutcomeDraw, 200, models.OutcomeDraw).expect(http.StatusOK)
+	report("m3", 300, models.OutcomeLoss, 400, models.OutcomeWin).expect(http.StatusOK)
+	if p := alice.profile(); p.Win != 1 || p.Draws != 1 || p.Loss != 1 || p.Record != 300 {
+		t.Fatalf("unexpected stats: %+v", p.Stats)
+	}
+
+	matches := func(c *testClient, query string) *models.MatchHistory {
+		t.Helper()
+		history := &models.MatchHistory{}
+		c.do(http.MethodGet, "/profile/matches"+query, "").expect(http.StatusOK).decode(history)
+		return history
+	}
+	ids := func(history *models.MatchHistory) string {
+		res := []string{}
+		for _, m := range history.Matches {
+			res = append(res, m.MatchID)
+		}
+		return strings.Join(res, ",")
+	}
+	first := matches(alice, "?limit=2")
+	if ids(first) != "m3,m2" || first.Next == "" {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+	m3 := first.Matches[0]
+	if m3.Score != 300 || m3.Outcome != models.OutcomeLoss || m3.Coins != 1 || len(m3.Opponents) != 1 ||
+		m3.Opponents[0].Nickname != "bobby" || m3.Opponents[0].Score != 400 || m3.Opponents[0].Outcome != models.OutcomeWin {
+		t.Fatalf("unexpected match: %+v", m3)
+	}
+	last := matches(alice, "?limit=2&before="+url.QueryEscape(first.Next))
+	if ids(last) != "m1" || last.Next != "" {
+		t.Fatalf("unexpected last page: %+v", last)
+	}
+	// the history of the other player is public
+	anon := e.client()
+	if got := matches(anon, fmt.Sprintf("?id=%v", him.UserID)); ids(got) != "m3,m2,m1" ||
+		got.Matches[0].Outcome != models.OutcomeWin {
+		t.Fatalf("unexpected history of bobby: %+v", got)
+	}
+	p := &models.Profile{}
+	anon.do(http.MethodGet, fmt.Sprintf("/profile?id=%v&matches=2", me.UserID), "").expect(http.StatusOK).decode(p)
+	if len(p.LastMatches) != 2 || p.LastMatches[0].MatchID != "m3" {
+		t.Fatalf("unexpected last matches in the profile: %+v", p.LastMatches)
+	}
+
+	anon.do(http.MethodGet, "/profile/matches", "").expect(http.StatusUnauthorized)
+	for _, query := range []string{"?id=abc", "?limit=abc", "?before=abc", "?before=1", "?before=1,"} {
+		alice.do(http.MethodGet, "/profile/matches"+query, "").expect(http.StatusBadRequest)
+	}
+}
+
+func TestScoreboard(t *testing.T) {
+	e := newTestEnv(t)
+	players := []*testClient{}
+	ids := []uint{}
+	// records 600, 500, 500, 400, 300, 200, 100
+	scores := []int{600, 500, 500, 400, 300, 200, 100}
+	results := []string{}
+	for i, score := range scores {
+		c := e.client()
+		p := c.register(fmt.Sprintf("player%v", i))
+		players = append(players, c)
+		ids = append(ids, p.UserID)
+		results = append(results, fmt.Sprintf(`{"id":%v,"score":%v,"result":"draw","coins":0}`, p.UserID, score))
+	}
+	match := `{"match_id":"m1","players":[` + strings.Join(results, ",") + `]}`
+	e.client().do(http.MethodPost, "/matches", match).expect(http.StatusUnauthorized)
+	// the token is accepted only as the bearer one
+	e.client().send(http.MethodPost, "/matches", "application/json", strings.NewReader(match),
+		http.Header{"Authorization": {testServiceToken}}).expect(http.StatusUnauthorized)
+	e.client().service(http.MethodPost, "/matches", match).expect(http.StatusOK)
+	// the same match is not counted twice
+	e.client().service(http.MethodPost, "/matches", match).expect(http.StatusOK)
+
+	anon := e.client()
+	get := func(query string) *models.PositionList {
+		t.Helper()
+		list := &models.PositionList{}
+		anon.do(http.MethodGet, "/scoreboard"+query, "").expect(http.StatusOK).decode(list)
+		return list
+	}
+	positions := func(list *models.PositionList) string {
+		res := []string{}
+		for _, p := range list.List {
+			res = append(res, fmt.Sprintf("%v:%v:%v/%v", p.Nickname, p.Points, p.Rank, p.DenseRank))
+		}
+		return strings.Join(res, " ")
+	}
+
+	first := get("?limit=3")
+	if first.Total != len(scores) {
+		t.Fatalf("expected %v players, got %v", len(scores), first.Total)
+	}
+	// ties are ordered by ID descending
+	if got := positions(first); got != "player0:600:1/1 player2:500:2/2 player1:500:2/2" {
+		t.Fatalf("unexpected first page: %v", got)
+	}
+	second := get("?limit=3&page=1")
+	if got := positions(second); got != "player3:400:4/3 player4:300:5/4 player5:200:6/5" {
+		t.Fatalf("unexpected second page: %v", got)
+	}
+	if first.Next == "" {
+		t.Fatal("no cursor on the full page")
+	}
+	if got := positions(get("?limit=3&after=" + first.Next)); got != positions(second) {
+		t.Fatalf("cursor page %v differs from the second page %v", got, positions(second))
+	}
+	last := get("?limit=3&page=2")
+	if got := positions(last); got != "player6:100:7/6" || last.Next != "" {
+		t.Fatalf("unexpected last page: %v, next %q", got, last.Next)
+	}
+	if got := len(get("?limit=100&page=100").List); got != 0 {
+		t.Fatalf("expected empty deepest page, got %v players", got)
+	}
+	if got := len(get("?limit=3&page=10").List); got != 0 {
+		t.Fatalf("expected empty page, got %v players", got)
+	}
+	if got := len(get("").List); got != 5 {
+		t.Fatalf("expected 5 players by default, got %v", got)
+	}
+
+	around := &models.PositionList{}
+	players[3].do(http.MethodGet, "/scoreboard?around=me&k=1", "").expect(http.StatusOK).decode(around)
+	if got := positions(around); got != "player1:500:2/2 player3:400:4/3 player4:300:5/4" {
+		t.Fatalf("unexpected players around: %v", got)
+	}
+	if got := positions(get(fmt.Sprintf("?around=%v&k=1", ids[0]))); got != "player0:600:1/1 player2:500:2/2" {
+		t.Fatalf("unexpected players around the first one: %v", got)
+	}
+
+	for _, query := range []string{"?limit=abc", "?page=-1", "?after=abc", "?after=1,x", "?k=abc",
+		"?around=abc", "?period=yearly", "?period=daily&after=1,1", "?scope=enemies",
+		// out of the range of the database
+		"?page=18446744073709551615", "?limit=100&page=101", "?after=1,9223372036854775808",
+		"?after=9999999999,1", "?around=9223372036854775808"} {
+		anon.do(http.MethodGet, "/scoreboard"+query, "").expect(http.StatusBadRequest)
+	}
+	anon.do(http.MethodGet, "/scoreboard?around=me", "").expect(http.StatusUnauthorized)
+	anon.do(http.MethodGet, "/scoreboard?scope=friends", "").expect(http.StatusUnauthorized)
+	anon.do(http.MethodGet, "/scoreboard?around=100500", "").expect(http.StatusNotFound)
+	anon.do(http.MethodGet, "/scoreboard?period=season", "").expect(http.StatusNotFound)
+	anon.do(http.MethodPost, "/scoreboard", "").expect(http.StatusMethodNotAllowed)
+
+	daily := get("?period=daily&limit=2")
+	if got := positions(daily); daily.Total != len(scores) || got != "player0:600:1/1 player2:500:2/2" {
+		t.Fatalf("unexpected daily scoreboard: %v of %v", got, daily.Total)
+	}
+}
+
+func TestSeasons(t *testing.T) {
+	e := newTestEnv(t)
+	me := e.client().register("alice")
+	anon := e.client()
+	now := time.Now()
+	season := func(name string, from, to time.Time) string {
+		return fmt.Sprintf(`{"name":%q,"starts_at":%q,"ends_at":%q}`, name, from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	current := season("Current", now.Add(-time.Hour), now.Add(time.Hour))
+
+	anon.do(http.MethodPost, "/scoreboard/seasons", current).expect(http.StatusUnauthorized)
+	anon.service(http.MethodPost, "/scoreboard/seasons", `{"name":`).expect(http.StatusBadRequest)
+	anon.service(http.MethodPost, "/scoreboard/seasons", season("", now, now.Add(time.Hour))).
+		expect(http.StatusUnprocessableEntity)
+	anon.service(http.MethodPost, "/scoreboard/seasons", season("Backwards", now, now.Add(-time.Hour))).
+		expect(http.StatusUnprocessableEntity)
+	anon.do(http.MethodGet, "/scoreboard?period=season", "").expect(http.StatusNotFound)
+
+	created := &models.Season{}
+	anon.service(http.MethodPost, "/scoreboard/seasons", current).expect(http.StatusOK).decode(created)
+	if created.ID == 0 || created.Name != "Current" || created.Archived {
+		t.Fatalf("unexpected season: %+v", created)
+	}
+	anon.service(http.MethodPost, "/scoreboard/seasons", season("current", now, now.Add(time.Hour))).
+		expect(http.StatusConflict)
+	anon.service(http.MethodPost, "/scoreboard/seasons", season("Past", now.Add(-2*time.Hour), now.Add(-time.Hour))).
+		expect(http.StatusOK)
+
+	// the ended season is archived with its final standings
+	archived, err := e.repo.ArchiveFinishedSeasons(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archived != 1 {
+		t.Fatalf("expected 1 archived season, got %v", archived)
+	}
+	seasons := &models.SeasonList{}
+	anon.do(http.MethodGet, "/scoreboard/seasons", "").expect(http.StatusOK).decode(seasons)
+	if len(seasons.Seasons) != 2 || seasons.Seasons[0].Name != "Current" || seasons.Seasons[0].Archived ||
+		!seasons.Seasons[1].Archived {
+		t.Fatalf("unexpected seasons: %+v", seasons)
+	}
+
+	e.client().service(http.MethodPost, "/matches",
+		fmt.Sprintf(`{"match_id":"m1","players":[{"id":%v,"score":700,"result":"win","coins":0}]}`, me.UserID)).
+		expect(http.StatusOK)
+	get := func(query string) *models.PositionList {
+		t.Helper()
+		list := &models.PositionList{}
+		anon.do(http.MethodGet, "/scoreboard?period=season"+query, "").expect(http.StatusOK).decode(list)
+		return list
+	}
+	if list := get(""); list.Total != 1 || list.List[0].Nickname != "alice" || list.List[0].Points != 700 {
+		t.Fatalf("unexpected standings of the current season: %+v", list)
+	}
+	if list := get("&season=past"); list.Total != 0 || len(list.List) != 0 {
+		t.Fatalf("unexpected standings of the past season: %+v", list)
+	}
+	anon.do(http.MethodGet, "/scoreboard?period=season&season=Future", "").expect(http.StatusNotFound)
+	anon.do(http.MethodPut, "/scoreboard/seasons", "").expect(http.StatusMethodNotAllowed)
+}
+
+func TestCORS(t *testing.T) {
+	e := newTestEnv(t)
+	resp := e.client().do(http.MethodOptions, "/profile", "").expect(http.StatusOK)
+	if resp.header.Get("Access-Control-Allow-Credentials") != "true" ||
+		resp.header.Get("Access-Control-Allow-Origin") == "" {
+		t.Fatalf("no CORS headers: %v", resp.header)
+	}
+	if len(resp.body) != 0 {
+		t.Fatalf("preflight reached the handler: %s", resp.body)
+	}
+}
+
+// slowRepository answers only when the request runs out of time
+type slowRepository struct {
+	*database.Memory
+}
+
+func (slowRepository) GetAllSkins(ctx context.Context, uID uint, all bool) (*[]models.Skin, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestQueryTimeout(t *testing.T) {
+	srv := httptest.NewTLSServer(newRouter(slowRepository{database.NewMemory()},
+		auth.NewMemorySessionManager(), nil, nil, nil, nil, health.NewReadiness(time.Second, 0), testServiceToken,
+		50*time.Millisecond, ""))
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + "/profile/skin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("expected status %v, got %v", http.StatusGatewayTimeout, resp.StatusCode)
+	}
+}
+
+// slowReader reads r after the delay
+type slowReader struct {
+	r     io.Reader
+	delay time.Duration
+}
+
+func (s *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(s.delay)
+	s.delay = 0
+	return s.r.Read(p)
+}
+
+// deadlineRepository fails the avatar upload when the request is out of time
+type deadlineRepository struct {
+	*database.Memory
+}
+
+func (r deadlineRepository) UploadAvatar(ctx context.Context, uID uint, path string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.Memory.UploadAvatar(ctx, uID, path)
+}
+
+func TestQueryTimeoutSlowUpload(t *testing.T) {
+	repo := deadlineRepository{database.NewMemory()}
+	p, err := repo.CreateNewUser(context.Background(), &models.RegisterProfile{
+		Nickname:     "alice",
+		UserPassword: models.UserPassword{Email: "alice@test.ru", Password: "hash"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm := auth.NewMemorySessionManager()
+	sID, err := sm.Create(p.UserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewTLSServer(newRouter(repo, sm, nil, nil, nil, nil, health.NewReadiness(time.Second, 0),
+		testServiceToken, 50*time.Millisecond, ""))
+	defer srv.Close()
+
+	// the upload is slower than the deadline of the queries
+	contentType, body := avatarForm("avatar", []byte("\x89PNG not really"))
+	req, err := http.NewRequest(http.MethodPut, srv.URL+"/profile/avatar", &slowReader{body, 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: sID})
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %v, got %v", http.StatusOK, resp.StatusCode)
+	}
+}
+
+// blockedMailer sends the letters only after release is closed
+type blockedMailer struct {
+	release chan struct{}
+	sent    chan *mail.Message
+}
+
+func (m *blockedMailer) Send(ctx context.Context, msg *mail.Message) error {
+	select {
+	case <-m.release:
+		m.sent <- msg
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestSlowMailer(t *testing.T) {
+	repo := database.NewMemory()
+	_, err := repo.CreateNewUser(context.Background(), &models.RegisterProfile{
+		Nickname:     "alice",
+		UserPassword: models.UserPassword{Email: "alice@test.ru", Password: "hash"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mailer := &blockedMailer{release: make(chan struct{}), sent: make(chan *mail.Message, 1)}
+	letters := handlers.NewLetters(mailer, testAppURL)
+	defer letters.Close()
+	srv := httptest.NewTLSServer(newRouter(repo, auth.NewMemorySessionManager(), nil, nil,
+		newPasswordResetThrottle(auth.NewMemoryLoginAttemptStore(), time.Hour), letters,
+		health.NewReadiness(time.Second, 0), testServiceToken, 50*time.Millisecond, ""))
+	defer srv.Close()
+
+	// the mail server doesn't answer, the request doesn't wait for it
+	for _, email := range []string{"alice@test.ru", "nobody@test.ru"} {
+		resp, err := srv.Client().Post(srv.URL+"/profile/password/reset", "application/json",
+			strings.NewReader(fmt.Sprintf(`{"email":%q}`, email)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status %v for %v, got %v", http.StatusOK, email, resp.StatusCode)
+		}
+	}
+
+	close(mailer.release)
+	letters.Wait()
+	if m := <-mailer.sent; m.To != "alice@test.ru" {
+		t.Fatalf("letter is sent to %v", m.To)
+	}
+
+	// the requests during the shutdown are answered, their letters are dropped
+	letters.Close()
+	resp, err := srv.Client().Post(srv.URL+"/profile/password/reset", "application/json",
+		strings.NewReader(`{"email":"alice@test.ru"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %v after close, got %v", http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestReadiness(t *testing.T) {
+	e := newTestEnv(t)
+	c := e.client()
+	c.do(http.MethodGet, "/healthz", "").expect(http.StatusOK)
+	h := &models.Health{}
+	c.do(http.MethodGet, "/readyz", "").expect(http.StatusOK).decode(h)
+	if h.Status != models.HealthOK || len(h.Dependencies) != 2 {
+		t.Fatalf("unexpected health: %+v", h)
+	}
+
+	e.sm.setDown(true)
+	h = &models.Health{}
+	c.do(http.MethodGet, "/readyz", "").expect(http.StatusServiceUnavailable).decode(h)
+	if h.Status != models.HealthDown || h.Dependencies[0].Status != models.HealthOK ||
+		h.Dependencies[1].Status != models.HealthDown || h.Dependencies[1].Error == "" {
+		t.Fatalf("unexpected health with auth-service down: %+v", h)
+	}
+	c.do(http.MethodGet, "/healthz", "").expect(http.StatusOK)
+	e.sm.setDown(false)
+
+	e.ready.Drain()
+	h = &models.Health{}
+	c.do(http.MethodGet, "/readyz", "").expect(http.StatusServiceUnavailable).decode(h)
+	if h.Status != models.HealthDraining {
+		t.Fatalf("unexpected health while draining: %+v", h)
+	}
+	// the requests are served until the shutdown
+	c.do(http.MethodGet, "/profile/skin", "").expect(http.StatusOK)
+}
+
+func tokenBody(token string) string {
+	return fmt.Sprintf(`{"token":%q}`, token)
+}
+
+func TestEmailVerification(t *testing.T) {
+	e := newTestEnv(t)
+	c := e.client()
+	p := c.register("alice")
+	if p.EmailVerified == nil || *p.EmailVerified {
+		t.Fatalf("new email is verified: %+v", p)
+	}
+	token := e.token("alice@test.ru", "/verify-email")
+
+	anon := e.client()
+	anon.do(http.MethodPut, "/profile/email/verify", `{"token":`).expect(http.StatusBadRequest)
+	anon.do(http.MethodPut, "/profile/email/verify", tokenBody("wrong")).expect(http.StatusUnprocessableEntity)
+	anon.do(http.MethodPut, "/profile/email/verify", tokenBody(token)).expect(http.StatusOK)
+	if p := c.profile(); !*p.EmailVerified {
+		t.Fatalf("email is not verified: %+v", p)
+	}
+	// single-use
+	anon.do(http.MethodPut, "/profile/email/verify", tokenBody(token)).expect(http.StatusUnprocessableEntity)
+
+	anon.do(http.MethodPost, "/profile/email/verify", "").expect(http.StatusUnauthorized)
+	c.do(http.MethodPost, "/profile/email/verify", "").expect(http.StatusConflict)
+	c.do(http.MethodGet, "/profile/email/verify", "").expect(http.StatusMethodNotAllowed)
+
+	// the new address is not verified, the links sent to the old ones don't work
+	c.do(http.MethodPut, "/profile", `{"email":"alice2@test.ru","current_password":"password"}`).expect(http.StatusOK)
+	if p := c.profile(); *p.EmailVerified {
+		t.Fatalf("changed email is verified: %+v", p)
+	}
+	token = e.token("alice2@test.ru", "/verify-email")
+	c.do(http.MethodPut, "/profile", `{"email":"alice3@test.ru","current_password":"password"}`).expect(http.StatusOK)
+	anon.do(http.MethodPut, "/profile/email/verify", tokenBody(token)).expect(http.StatusUnprocessableEntity)
+
+	// 3 letters an hour
+	c.do(http.MethodPost, "/profile/email/verify", "").expect(http.StatusTooManyRequests)
+	if n := len(e.letters("alice3@test.ru")); n != 1 {
+		t.Fatalf("expected 1 letter, got %v", n)
+	}
+	anon.do(http.MethodPut, "/profile/email/verify", tokenBody(e.token("alice3@test.ru", "/verify-email"))).
+		expect(http.StatusOK)
+}
+
+func TestPasswordReset(t *testing.T) {
+	e := newTestEnv(t)
+	alice := e.client()
+	alice.register("alice")
+
+	anon := e.client()
+	anon.do(http.MethodPost, "/profile/password/reset", `{"email":`).expect(http.StatusBadRequest)
+	anon.do(http.MethodPost, "/profile/password/reset", `{"email":"alice"}`).expect(http.StatusBadRequest)
+	// nobody learns if the user exists
+	anon.do(http.MethodPost, "/profile/password/reset", `{"email":"nobody@test.ru"}`).expect(http.StatusOK)
+	if n := len(e.letters("nobody@test.ru")); n != 0 {
+		t.Fatalf("%v letters to unknown address", n)
+	}
+
+	anon.do(http.MethodPost, "/profile/password/reset", `{"email":"ALICE@test.ru"}`).expect(http.StatusOK)
+	first := e.token("alice@test.ru", "/reset-password")
+	anon.do(http.MethodPost, "/profile/password/reset", `{"email":"alice@test.ru"}`).expect(http.StatusOK)
+	token := e.token("alice@test.ru", "/reset-password")
+
+	reset := func(token, password string) *testResponse {
+		return anon.do(http.MethodPut, "/profile/password/reset",
+			fmt.Sprintf(`{"token":%q,"password":%q}`, token, password))
+	}
+	anon.do(http.MethodPut, "/profile/password/reset", `{"token":`).expect(http.StatusBadRequest)
+	reset(token, "abc").expect(http.StatusForbidden)
+	reset("wrong", "new password").expect(http.StatusUnprocessableEntity)
+	reset(token, "new password").expect(http.StatusOK)
+	reset(token, "other password").expect(http.StatusUnprocessableEntity)
+	// the other links are spent too
+	reset(first, "other password").expect(http.StatusUnprocessableEntity)
+	// whoever knew the old password is logged out
+	alice.do(http.MethodGet, "/session", "").expect(http.StatusUnauthorized)
+
+	c := e.client()
+	c.do(http.MethodPost, "/session", `{"email":"alice@test.ru","password":"password"}`).
+		expect(http.StatusUnprocessableEntity)
+	c.do(http.MethodPost, "/session", `{"email":"alice@test.ru","password":"new password"}`).
+		expect(http.StatusOK)
+	if p := c.profile(); !*p.EmailVerified {
+		t.Fatalf("email is not verified by the reset: %+v", p)
+	}
+}
+
+func TestPasswordResetThrottle(t *testing.T) {
+	e := newTestEnv(t)
+	e.client().register("alice")
+
+	anon := e.client()
+	reset := func(email, ip string) *testResponse {
+		t.Helper()
+		return anon.send(http.MethodPost, "/profile/password/reset", "application/json",
+			strings.NewReader(fmt.Sprintf(`{"email":%q}`, email)), http.Header{testRealIPHeader: {ip}})
+	}
+	// the address gets 3 letters however many addresses ask for them
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		reset("alice@test.ru", ip).expect(http.StatusOK)
+	}
+	resp := reset("ALICE@test.ru", "10.0.0.4").expect(http.StatusTooManyRequests)
+	if resp.header.Get("Retry-After") == "" {
+		t.Fatalf("no Retry-After header: %v", resp.header)
+	}
+	// and the verification one
+	if n := len(e.letters("alice@test.ru")); n != 4 {
+		t.Fatalf("expected 4 letters, got %v", n)
+	}
+	// the unknown addresses are limited the same
+	for i := 0; i < 3; i++ {
+		reset("nobody@test.ru", fmt.Sprintf("10.0.1.%v", i)).expect(http.StatusOK)
+	}
+	reset("nobody@test.ru", "10.0.1.3").expect(http.StatusTooManyRequests)
+
+	// the address asking for many emails is stopped after 6 requests
+	for i := 0; i < 6; i++ {
+		reset(fmt.Sprintf("user%v@test.ru", i), "10.0.2.1").expect(http.StatusOK)
+	}
+	reset("user6@test.ru", "10.0.2.1").expect(http.StatusTooManyRequests)
+	reset("user6@test.ru", "10.0.2.2").expect(http.StatusOK)
+	// the logins of the address are counted apart from its resets
+	anon.login("alice@test.ru", testPassword, "10.0.2.1").expect(http.StatusOK)
+}
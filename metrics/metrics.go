@@ -21,6 +21,25 @@ var (
 	},
 		[]string{"http_status", "path", "method"},
 	)
+	LoginFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "login_failures",
+		Help:      "Total failed logins with the wrong email or password",
+	})
+	LoginsThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "logins_throttled",
+		Help:      "Total logins refused before checking the password ordered by the throttled key",
+	},
+		[]string{"scope"},
+	)
+	PasswordResetsThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "password_resets_throttled",
+		Help:      "Total password reset requests refused before the letter ordered by the throttled key",
+	},
+		[]string{"scope"},
+	)
 )
 
 func CountHitsMiddleware(next http.Handler) http.HandlerFunc {
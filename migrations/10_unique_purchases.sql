@@ -0,0 +1,12 @@
+-- +migrate Up
+-- concurrent purchases could save the same skin twice
+DELETE FROM user_purchased_skins a
+USING user_purchased_skins b
+WHERE a.ctid < b.ctid AND a.user_id = b.user_id AND a.skin_id = b.skin_id;
+
+ALTER TABLE user_purchased_skins
+    ADD CONSTRAINT user_purchased_skins_pkey PRIMARY KEY (user_id, skin_id);
+
+-- +migrate Down
+ALTER TABLE user_purchased_skins
+    DROP CONSTRAINT IF EXISTS user_purchased_skins_pkey;
@@ -0,0 +1,37 @@
+-- +migrate Up
+UPDATE skin
+SET cost = 0
+WHERE cost IS NULL;
+
+ALTER TABLE skin
+    ALTER cost SET NOT NULL,
+    ADD CONSTRAINT nonnegative_cost CHECK (cost >= 0),
+    ADD description text NOT NULL DEFAULT '',
+    ADD rarity varchar(16) NOT NULL DEFAULT 'common'
+        CONSTRAINT known_rarity CHECK (rarity IN ('common', 'rare', 'epic', 'legendary')),
+    ADD asset_url text,
+    ADD available_from timestamptz,
+    ADD available_until timestamptz,
+    ADD hidden boolean NOT NULL DEFAULT false,
+    ADD retired boolean NOT NULL DEFAULT false,
+    ADD CONSTRAINT availability_window CHECK (available_from < available_until);
+
+-- admins manage the skin catalog, granted manually
+ALTER TABLE user_profile
+    ADD is_admin boolean NOT NULL DEFAULT false;
+
+-- +migrate Down
+ALTER TABLE user_profile
+    DROP is_admin;
+
+ALTER TABLE skin
+    DROP CONSTRAINT IF EXISTS availability_window,
+    DROP retired,
+    DROP hidden,
+    DROP available_until,
+    DROP available_from,
+    DROP asset_url,
+    DROP rarity,
+    DROP description,
+    DROP CONSTRAINT IF EXISTS nonnegative_cost,
+    ALTER cost DROP NOT NULL;
@@ -0,0 +1,36 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS skin_sale (
+    sale_id serial PRIMARY KEY,
+    skin_id integer REFERENCES skin ON DELETE CASCADE NOT NULL,
+    percent_off integer CONSTRAINT valid_percent_off CHECK (percent_off > 0 AND percent_off <= 100),
+    amount_off integer CONSTRAINT positive_amount_off CHECK (amount_off > 0),
+    starts_at timestamptz NOT NULL,
+    ends_at timestamptz NOT NULL,
+    CONSTRAINT one_discount CHECK ((percent_off IS NULL) <> (amount_off IS NULL)),
+    CONSTRAINT sale_period CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS skin_sale_skin_idx ON skin_sale (skin_id, ends_at);
+
+CREATE TABLE IF NOT EXISTS bundle (
+    bundle_id serial PRIMARY KEY,
+    bundle_name varchar(32) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    cost integer NOT NULL CONSTRAINT nonnegative_cost CHECK (cost >= 0),
+    available_from timestamptz,
+    available_until timestamptz,
+    hidden boolean NOT NULL DEFAULT false,
+    retired boolean NOT NULL DEFAULT false,
+    CONSTRAINT availability_window CHECK (available_from < available_until)
+);
+
+CREATE TABLE IF NOT EXISTS bundle_skin (
+    bundle_id integer REFERENCES bundle ON DELETE CASCADE NOT NULL,
+    skin_id integer REFERENCES skin NOT NULL,
+    PRIMARY KEY (bundle_id, skin_id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS bundle_skin;
+DROP TABLE IF EXISTS bundle;
+DROP TABLE IF EXISTS skin_sale;
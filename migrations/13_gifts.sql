@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS gift (
+    gift_id serial PRIMARY KEY,
+    from_id integer REFERENCES user_profile NOT NULL,
+    to_id integer REFERENCES user_profile NOT NULL,
+    skin_id integer REFERENCES skin,
+    coins integer NOT NULL DEFAULT 0 CONSTRAINT nonnegative_coins CHECK (coins >= 0),
+    price integer NOT NULL DEFAULT 0 CONSTRAINT nonnegative_price CHECK (price >= 0), -- paid by the sender, kept until accepted
+    message varchar(140) NOT NULL DEFAULT '',
+    status varchar(16) NOT NULL DEFAULT 'pending'
+        CONSTRAINT known_status CHECK (status IN ('pending', 'accepted', 'declined')),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    resolved_at timestamptz,
+    CONSTRAINT not_self CHECK (from_id <> to_id),
+    CONSTRAINT skin_or_coins CHECK ((skin_id IS NULL) <> (coins = 0))
+);
+
+CREATE INDEX IF NOT EXISTS gift_to_idx ON gift (to_id, created_at DESC) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS gift_from_idx ON gift (from_id, created_at DESC);
+-- the same skin can't wait in the inbox twice
+CREATE UNIQUE INDEX IF NOT EXISTS gift_pending_skin_idx ON gift (to_id, skin_id) WHERE status = 'pending';
+
+-- +migrate Down
+DROP TABLE IF EXISTS gift;
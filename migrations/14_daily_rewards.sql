@@ -0,0 +1,25 @@
+-- +migrate Up
+-- coins for the n-th day of the streak, the last day repeats for longer streaks
+CREATE TABLE IF NOT EXISTS daily_reward (
+    day integer PRIMARY KEY CONSTRAINT positive_day CHECK (day > 0),
+    coins integer NOT NULL CONSTRAINT nonnegative_coins CHECK (coins >= 0)
+);
+
+INSERT INTO daily_reward (day, coins) VALUES
+    (1, 10),
+    (2, 15),
+    (3, 20),
+    (4, 30),
+    (5, 40),
+    (6, 50),
+    (7, 100);
+
+CREATE TABLE IF NOT EXISTS daily_streak (
+    user_id integer PRIMARY KEY REFERENCES user_profile,
+    streak integer NOT NULL CONSTRAINT positive_streak CHECK (streak > 0),
+    claimed_on date NOT NULL -- UTC
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS daily_streak;
+DROP TABLE IF EXISTS daily_reward;
@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS promo_code (
+    promo_id serial PRIMARY KEY,
+    code citext UNIQUE NOT NULL,
+    coins integer NOT NULL DEFAULT 0 CONSTRAINT nonnegative_coins CHECK (coins >= 0),
+    skin_id integer REFERENCES skin,
+    max_redemptions integer CONSTRAINT positive_max_redemptions CHECK (max_redemptions > 0), -- NULL is unlimited
+    redeemed integer NOT NULL DEFAULT 0,
+    expires_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT skin_or_coins CHECK ((skin_id IS NULL) <> (coins = 0)),
+    CONSTRAINT redemption_limit CHECK (redeemed <= max_redemptions)
+);
+
+-- every player can redeem a code once
+CREATE TABLE IF NOT EXISTS promo_redemption (
+    promo_id integer REFERENCES promo_code ON DELETE CASCADE NOT NULL,
+    user_id integer REFERENCES user_profile NOT NULL,
+    redeemed_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (promo_id, user_id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS promo_redemption;
+DROP TABLE IF EXISTS promo_code;
@@ -0,0 +1,23 @@
+-- +migrate Up
+ALTER TABLE user_profile
+    ADD email_verified boolean NOT NULL DEFAULT false;
+
+-- single-use links sent by email, only the SHA-256 of the token is stored
+CREATE TABLE IF NOT EXISTS email_token (
+    token_hash char(64) PRIMARY KEY,
+    user_id integer REFERENCES user_profile ON DELETE CASCADE NOT NULL,
+    purpose varchar(16) NOT NULL
+        CONSTRAINT known_purpose CHECK (purpose IN ('verify_email', 'reset_password')),
+    email citext NOT NULL, -- the address the link was sent to
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS email_token_user_idx ON email_token (user_id, purpose, created_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_token;
+
+ALTER TABLE user_profile
+    DROP email_verified;
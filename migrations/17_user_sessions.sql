@@ -0,0 +1,12 @@
+-- +migrate Up
+-- sessions created by auth-service, it can't list the sessions of a user
+CREATE TABLE IF NOT EXISTS user_session (
+    session_id varchar(64) PRIMARY KEY,
+    user_id integer REFERENCES user_profile ON DELETE CASCADE NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS user_session_user_idx ON user_session (user_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS user_session;
@@ -0,0 +1,9 @@
+-- +migrate Up
+-- PHC strings of argon2id are longer than bcrypt hashes
+ALTER TABLE user_profile
+    ALTER password TYPE varchar(255);
+
+-- +migrate Down
+-- fails while argon2id hashes are stored
+ALTER TABLE user_profile
+    ALTER password TYPE varchar(64);
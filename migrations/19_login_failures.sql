@@ -0,0 +1,12 @@
+-- +migrate Up
+-- failed logins in a row, the key is email:<address> or ip:<address>
+CREATE TABLE IF NOT EXISTS login_failure (
+    key varchar(320) PRIMARY KEY,
+    failures integer NOT NULL,
+    last_failure_at timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS login_failure_last_idx ON login_failure (last_failure_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS login_failure;
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- where the player logged in, the sessions tracked before are shown without the device
+ALTER TABLE user_session
+    ADD device varchar(32) NOT NULL DEFAULT '',
+    ADD user_agent varchar(512) NOT NULL DEFAULT '',
+    ADD ip varchar(45) NOT NULL DEFAULT '',
+    ADD last_seen_at timestamptz NOT NULL DEFAULT now();
+
+-- +migrate Down
+ALTER TABLE user_session
+    DROP device,
+    DROP user_agent,
+    DROP ip,
+    DROP last_seen_at;
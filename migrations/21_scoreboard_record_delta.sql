@@ -0,0 +1,65 @@
+-- +migrate Up
+-- the triggers only append the changes of the counts, so the signups and the matches
+-- don't lock the rows of scoreboard_record and can't wait for each other on them.
+-- The changes are folded into scoreboard_record in the background
+CREATE TABLE IF NOT EXISTS scoreboard_record_delta (
+    delta_id bigserial PRIMARY KEY,
+    record integer NOT NULL,
+    players integer NOT NULL
+);
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION update_scoreboard_record() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        INSERT INTO scoreboard_record_delta (record, players)
+        VALUES (OLD.record, -1);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        INSERT INTO scoreboard_record_delta (record, players)
+        VALUES (NEW.record, 1);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+-- count of players per record with the changes not folded yet
+CREATE OR REPLACE VIEW scoreboard_players AS
+    SELECT record, SUM(players)::integer AS players FROM (
+        SELECT record, players FROM scoreboard_record
+        UNION ALL
+        SELECT record, players FROM scoreboard_record_delta
+    ) AS r
+    GROUP BY record
+    HAVING SUM(players) > 0;
+
+-- +migrate Down
+DROP VIEW IF EXISTS scoreboard_players;
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION update_scoreboard_record() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE scoreboard_record SET players = players - 1
+        WHERE record = OLD.record;
+        DELETE FROM scoreboard_record
+        WHERE record = OLD.record AND players = 0;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        INSERT INTO scoreboard_record (record, players)
+        VALUES (NEW.record, 1)
+        ON CONFLICT (record) DO UPDATE SET players = scoreboard_record.players + 1;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+INSERT INTO scoreboard_record (record, players)
+    SELECT record, SUM(players) FROM scoreboard_record_delta
+    GROUP BY record
+    ORDER BY record
+ON CONFLICT (record) DO UPDATE SET players = scoreboard_record.players + EXCLUDED.players;
+DELETE FROM scoreboard_record WHERE players = 0;
+DROP TABLE IF EXISTS scoreboard_record_delta;
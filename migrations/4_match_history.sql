@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS match_participant (
+    match_id varchar(64) REFERENCES match NOT NULL,
+    user_id integer REFERENCES user_profile NOT NULL,
+    played_at timestamptz NOT NULL, -- copy of match.reported_at for the history index
+    score integer NOT NULL DEFAULT 0,
+    outcome varchar(4) NOT NULL CONSTRAINT valid_outcome CHECK (outcome IN ('win', 'draw', 'loss')),
+    coins integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (match_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS match_participant_history_idx
+    ON match_participant (user_id, played_at DESC, match_id DESC);
+
+-- +migrate Down
+DROP TABLE IF EXISTS match_participant;
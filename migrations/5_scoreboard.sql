@@ -0,0 +1,53 @@
+-- +migrate Up
+UPDATE user_profile SET record = 0 WHERE record IS NULL;
+ALTER TABLE user_profile ALTER COLUMN record SET NOT NULL;
+
+-- scanned backwards by the scoreboard: ORDER BY record DESC, user_id DESC
+CREATE INDEX IF NOT EXISTS user_profile_record_idx ON user_profile (record, user_id);
+
+-- count of players per record, keeps ranks and the total cheap on any page
+CREATE TABLE IF NOT EXISTS scoreboard_record (
+    record integer PRIMARY KEY,
+    players integer NOT NULL CONSTRAINT nonnegative_players CHECK (players >= 0)
+);
+
+INSERT INTO scoreboard_record (record, players)
+    SELECT record, COUNT(*) FROM user_profile
+    GROUP BY record;
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION update_scoreboard_record() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE scoreboard_record SET players = players - 1
+        WHERE record = OLD.record;
+        DELETE FROM scoreboard_record
+        WHERE record = OLD.record AND players = 0;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        INSERT INTO scoreboard_record (record, players)
+        VALUES (NEW.record, 1)
+        ON CONFLICT (record) DO UPDATE SET players = scoreboard_record.players + 1;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER scoreboard_record_insert_delete
+    AFTER INSERT OR DELETE ON user_profile
+    FOR EACH ROW EXECUTE PROCEDURE update_scoreboard_record();
+
+CREATE TRIGGER scoreboard_record_update
+    AFTER UPDATE OF record ON user_profile
+    FOR EACH ROW WHEN (OLD.record IS DISTINCT FROM NEW.record)
+    EXECUTE PROCEDURE update_scoreboard_record();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS scoreboard_record_update ON user_profile;
+DROP TRIGGER IF EXISTS scoreboard_record_insert_delete ON user_profile;
+DROP FUNCTION IF EXISTS update_scoreboard_record();
+DROP TABLE IF EXISTS scoreboard_record;
+DROP INDEX IF EXISTS user_profile_record_idx;
+
+ALTER TABLE user_profile ALTER COLUMN record DROP NOT NULL;
@@ -0,0 +1,30 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS season (
+    season_id serial PRIMARY KEY,
+    season_name citext UNIQUE NOT NULL,
+    starts_at timestamptz NOT NULL,
+    ends_at timestamptz NOT NULL,
+    archived boolean NOT NULL DEFAULT false,
+    CONSTRAINT valid_season_period CHECK (starts_at < ends_at)
+);
+
+-- final standings of the archived seasons
+CREATE TABLE IF NOT EXISTS season_standing (
+    season_id integer REFERENCES season NOT NULL,
+    user_id integer REFERENCES user_profile NOT NULL,
+    record integer NOT NULL,
+    rank integer NOT NULL,
+    dense_rank integer NOT NULL,
+    PRIMARY KEY (season_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS season_standing_rank_idx
+    ON season_standing (season_id, rank, user_id);
+
+CREATE INDEX IF NOT EXISTS match_participant_played_at_idx
+    ON match_participant (played_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS match_participant_played_at_idx;
+DROP TABLE IF EXISTS season_standing;
+DROP TABLE IF EXISTS season;
@@ -0,0 +1,30 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS friend_request (
+    from_id integer REFERENCES user_profile NOT NULL,
+    to_id integer REFERENCES user_profile NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (from_id, to_id),
+    CONSTRAINT not_self_request CHECK (from_id <> to_id)
+);
+
+CREATE INDEX IF NOT EXISTS friend_request_to_idx ON friend_request (to_id);
+
+-- every friendship is stored in both directions
+CREATE TABLE IF NOT EXISTS friendship (
+    user_id integer REFERENCES user_profile NOT NULL,
+    friend_id integer REFERENCES user_profile NOT NULL,
+    since timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, friend_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_block (
+    user_id integer REFERENCES user_profile NOT NULL,
+    blocked_id integer REFERENCES user_profile NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, blocked_id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS user_block;
+DROP TABLE IF EXISTS friendship;
+DROP TABLE IF EXISTS friend_request;
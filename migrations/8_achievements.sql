@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS achievement (
+    achievement_id serial PRIMARY KEY,
+    code varchar(32) UNIQUE NOT NULL,
+    achievement_name varchar(64) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    rule varchar(16) NOT NULL, -- evaluated by the service: games, wins, record, skins, all_skins
+    threshold integer NOT NULL DEFAULT 0,
+    reward integer NOT NULL DEFAULT 0 CONSTRAINT nonnegative_reward CHECK (reward >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS user_achievement (
+    user_id integer REFERENCES user_profile NOT NULL,
+    achievement_id integer REFERENCES achievement NOT NULL,
+    unlocked_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, achievement_id)
+);
+
+INSERT INTO achievement (code, achievement_name, description, rule, threshold, reward) VALUES
+    ('first_game', 'First game', 'Play your first game', 'games', 1, 10),
+    ('win_10', 'Winner', 'Win 10 games', 'wins', 10, 50),
+    ('win_100', 'Champion', 'Win 100 games', 'wins', 100, 200),
+    ('record_1000', 'High score', 'Reach record 1000', 'record', 1000, 100),
+    ('skins_3', 'Collector', 'Own 3 skins', 'skins', 3, 30),
+    ('all_skins', 'Fashionista', 'Own every skin', 'all_skins', 0, 150);
+
+-- +migrate Down
+DROP TABLE IF EXISTS user_achievement;
+DROP TABLE IF EXISTS achievement;
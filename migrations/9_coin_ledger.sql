@@ -0,0 +1,31 @@
+-- +migrate Up
+UPDATE user_profile
+SET coins = 0
+WHERE coins IS NULL;
+
+ALTER TABLE user_profile
+    ALTER coins SET NOT NULL;
+
+CREATE TABLE IF NOT EXISTS coin_transaction (
+    transaction_id bigserial PRIMARY KEY,
+    user_id integer REFERENCES user_profile NOT NULL,
+    amount integer NOT NULL,
+    reason varchar(32) NOT NULL,
+    reference varchar(64),
+    balance integer NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS coin_transaction_user_idx ON coin_transaction (user_id, transaction_id DESC);
+
+-- balances before the ledger
+INSERT INTO coin_transaction (user_id, amount, reason, balance)
+SELECT user_id, coins, 'opening_balance', coins FROM user_profile
+WHERE coins <> 0
+ORDER BY user_id;
+
+-- +migrate Down
+DROP TABLE IF EXISTS coin_transaction;
+
+ALTER TABLE user_profile
+    ALTER coins DROP NOT NULL;
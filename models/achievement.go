@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	RuleGames    = "games"
+	RuleWins     = "wins"
+	RuleRecord   = "record"
+	RuleSkins    = "skins"
+	RuleAllSkins = "all_skins"
+)
+
+//easyjson:json
+type Achievement struct {
+	ID          uint       `json:"id" example:"2" db:"achievement_id"`
+	Code        string     `json:"code" example:"win_10"`
+	Name        string     `json:"name" example:"Winner" db:"achievement_name"`
+	Description string     `json:"description" example:"Win 10 games"`
+	Rule        string     `json:"-"`
+	Threshold   int        `json:"-"`
+	Reward      int        `json:"reward" example:"50"`
+	UnlockedAt  *time.Time `json:"unlocked_at,omitempty" db:"unlocked_at"`
+}
+
+//easyjson:json
+type AchievementList struct {
+	Achievements []Achievement `json:"achievements"`
+}
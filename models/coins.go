@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+)
+
+// reasons of the coin transactions
+const (
+	CoinReasonOpeningBalance = "opening_balance"
+	CoinReasonMatch          = "match"
+	CoinReasonSkinPurchase   = "skin_purchase"
+	CoinReasonBundlePurchase = "bundle_purchase"
+	CoinReasonAchievement    = "achievement"
+	CoinReasonDailyReward    = "daily_reward"
+	CoinReasonPromoCode      = "promo_code"
+	CoinReasonGiftSent       = "gift_sent"
+	CoinReasonGiftReceived   = "gift_received"
+	CoinReasonGiftRefund     = "gift_refund"
+	CoinReasonAdjustment     = "adjustment"
+)
+
+//easyjson:json
+type CoinTransaction struct {
+	ID        uint64    `json:"id" example:"1024" db:"transaction_id"`
+	Amount    int       `json:"amount" example:"-100"`
+	Reason    string    `json:"reason" example:"skin_purchase"`
+	Reference *string   `json:"reference,omitempty" example:"3"`
+	Balance   int       `json:"balance" example:"50"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+//easyjson:json
+type CoinHistory struct {
+	Transactions []CoinTransaction `json:"transactions"`
+	Next         string            `json:"next,omitempty" example:"1000"`
+}
+
+//easyjson:json
+type CoinDrift struct {
+	UserID      uint `json:"id" example:"42" db:"user_id"`
+	Coins       int  `json:"coins" example:"150"`
+	LedgerSum   int  `json:"ledger_sum" example:"100" db:"ledger_sum"`
+	LastBalance *int `json:"last_balance" example:"100" db:"last_balance"`
+}
+
+//easyjson:json
+type CoinLedgerReport struct {
+	CheckedUsers int         `json:"checked_users" example:"1000"`
+	Drifts       []CoinDrift `json:"drifts"`
+}
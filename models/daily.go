@@ -0,0 +1,14 @@
+package models
+
+//easyjson:json
+type DailyStreak struct {
+	Streak       int  `json:"streak" example:"3"`
+	ClaimedToday bool `json:"claimed_today" db:"claimed_today"`
+	NextReward   int  `json:"next_reward" example:"30" db:"next_reward"`
+}
+
+//easyjson:json
+type DailyReward struct {
+	Streak int `json:"streak" example:"4"`
+	Coins  int `json:"coins" example:"30"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	TokenVerifyEmail   = "verify_email"
+	TokenResetPassword = "reset_password"
+)
+
+// EmailToken is a single-use link sent by email, only the hash of the token is stored
+type EmailToken struct {
+	Hash      string    `db:"token_hash"`
+	UserID    uint      `db:"user_id"`
+	Purpose   string    `db:"purpose"`
+	Email     string    `db:"email"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+//easyjson:json
+type EmailTokenConfirmation struct {
+	Token string `json:"token" example:"Nc9rKj1Uq0S0n8mJ2Tz4bA"`
+}
+
+//easyjson:json
+type PasswordResetRequest struct {
+	Email string `json:"email" example:"email@email.com"`
+}
+
+//easyjson:json
+type PasswordReset struct {
+	Token    string `json:"token" example:"Nc9rKj1Uq0S0n8mJ2Tz4bA"`
+	Password string `json:"password" example:"password"`
+}
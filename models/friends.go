@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	FriendActionAccept  = "accept"
+	FriendActionDecline = "decline"
+	FriendActionBlock   = "block"
+	FriendActionUnblock = "unblock"
+)
+
+//easyjson:json
+type Friend struct {
+	ID       uint      `json:"id" example:"42" db:"user_id"`
+	Nickname string    `json:"nickname" example:"Nick"`
+	Avatar   *string   `json:"avatar,omitempty"`
+	Record   int       `json:"record" example:"100500"`
+	Since    time.Time `json:"since"`
+}
+
+//easyjson:json
+type FriendList struct {
+	Friends  []Friend `json:"friends"`
+	Incoming []Friend `json:"incoming"`
+	Outgoing []Friend `json:"outgoing"`
+	Blocked  []Friend `json:"blocked"`
+}
+
+//easyjson:json
+type FriendAction struct {
+	ID       uint   `json:"id,omitempty" example:"42"`
+	Nickname string `json:"nickname,omitempty" example:"Nick"`
+	Action   string `json:"action,omitempty" example:"accept"`
+}
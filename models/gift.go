@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	GiftStatusPending  = "pending"
+	GiftStatusAccepted = "accepted"
+	GiftStatusDeclined = "declined"
+
+	GiftActionAccept  = "accept"
+	GiftActionDecline = "decline"
+)
+
+//easyjson:json
+type Gift struct {
+	ID           uint      `json:"id" example:"7" db:"gift_id"`
+	FromID       uint      `json:"from" example:"42" db:"from_id"`
+	FromNickname string    `json:"from_nickname" example:"Nick" db:"from_nickname"`
+	ToID         uint      `json:"to" example:"43" db:"to_id"`
+	ToNickname   string    `json:"to_nickname" example:"Kate" db:"to_nickname"`
+	SkinID       *uint     `json:"skin,omitempty" example:"3" db:"skin_id"`
+	Coins        int       `json:"coins,omitempty" example:"100"`
+	Price        int       `json:"-"`
+	Message      string    `json:"message,omitempty" example:"Happy birthday!"`
+	Status       string    `json:"status" example:"pending"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+//easyjson:json
+type GiftList struct {
+	Incoming []Gift `json:"incoming"`
+	Sent     []Gift `json:"sent"`
+}
+
+//easyjson:json
+type SendGift struct {
+	ID       uint   `json:"id,omitempty" example:"43"`
+	Nickname string `json:"nickname,omitempty" example:"Kate"`
+	SkinID   uint   `json:"skin,omitempty" example:"3"`
+	Coins    int    `json:"coins,omitempty" example:"100"`
+	Message  string `json:"message,omitempty" example:"Happy birthday!"`
+}
+
+//easyjson:json
+type GiftAction struct {
+	ID     uint   `json:"id" example:"7"`
+	Action string `json:"action" example:"accept"`
+}
@@ -0,0 +1,23 @@
+package models
+
+const (
+	HealthOK       = "ok"
+	HealthDown     = "down"
+	HealthDraining = "draining"
+)
+
+//easyjson:json
+type DependencyHealth struct {
+	Name string `json:"name" example:"postgres"`
+	// the instance is not ready without the hard dependency
+	Hard      bool    `json:"hard" example:"true"`
+	Status    string  `json:"status" example:"ok"`
+	LatencyMs float64 `json:"latency_ms" example:"1.25"`
+	Error     string  `json:"error,omitempty"`
+}
+
+//easyjson:json
+type Health struct {
+	Status       string             `json:"status" example:"ok"`
+	Dependencies []DependencyHealth `json:"dependencies,omitempty"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import (
+	"time"
+)
+
+// LoginFailures are the failed logins in a row for the account or the address
+type LoginFailures struct {
+	Key           string    `db:"key"`
+	Failures      int       `db:"failures"`
+	LastFailureAt time.Time `db:"last_failure_at"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	OutcomeWin  = "win"
+	OutcomeDraw = "draw"
+	OutcomeLoss = "loss"
+)
+
+//easyjson:json
+type MatchResult struct {
+	MatchID string         `json:"match_id" example:"3f2a9c0e-game-42"`
+	Players []PlayerResult `json:"players"`
+}
+
+//easyjson:json
+type PlayerResult struct {
+	UserID  uint   `json:"id" example:"42"`
+	Score   int    `json:"score" example:"1500"`
+	Outcome string `json:"result" example:"win"`
+	Coins   int    `json:"coins" example:"10"`
+}
+
+//easyjson:json
+type MatchHistoryEntry struct {
+	MatchID   string     `json:"match_id" example:"3f2a9c0e-game-42" db:"match_id"`
+	PlayedAt  time.Time  `json:"played_at" db:"played_at"`
+	Score     int        `json:"score" example:"1500"`
+	Outcome   string     `json:"result" example:"win" db:"outcome"`
+	Coins     int        `json:"coins" example:"10"`
+	Opponents []Opponent `json:"opponents"`
+}
+
+//easyjson:json
+type Opponent struct {
+	ID       uint   `json:"id" example:"43" db:"user_id"`
+	Nickname string `json:"nickname" example:"Nick"`
+	Score    int    `json:"score" example:"1200"`
+	Outcome  string `json:"result" example:"loss" db:"outcome"`
+}
+
+//easyjson:json
+type MatchHistory struct {
+	Matches []MatchHistoryEntry `json:"matches"`
+	Next    string              `json:"next,omitempty" example:"1545343402611769000,3f2a9c0e-game-42"`
+}
+
+type MatchCursor struct {
+	PlayedAt time.Time
+	MatchID  string
+}
@@ -7,6 +7,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -472,6 +473,62 @@ func easyjsonD2b7633eDecodeApiModels4(in *jlexer.Lexer, out *Skin) {
 			out.Name = string(in.String())
 		case "cost":
 			out.Cost = int(in.Int())
+		case "description":
+			out.Description = string(in.String())
+		case "rarity":
+			out.Rarity = string(in.String())
+		case "asset_url":
+			if in.IsNull() {
+				in.Skip()
+				out.AssetURL = nil
+			} else {
+				if out.AssetURL == nil {
+					out.AssetURL = new(string)
+				}
+				*out.AssetURL = string(in.String())
+			}
+		case "available_from":
+			if in.IsNull() {
+				in.Skip()
+				out.AvailableFrom = nil
+			} else {
+				if out.AvailableFrom == nil {
+					out.AvailableFrom = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.AvailableFrom).UnmarshalJSON(data))
+				}
+			}
+		case "available_until":
+			if in.IsNull() {
+				in.Skip()
+				out.AvailableUntil = nil
+			} else {
+				if out.AvailableUntil == nil {
+					out.AvailableUntil = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.AvailableUntil).UnmarshalJSON(data))
+				}
+			}
+		case "hidden":
+			out.Hidden = bool(in.Bool())
+		case "retired":
+			out.Retired = bool(in.Bool())
+		case "price":
+			out.Price = int(in.Int())
+		case "sale_ends_at":
+			if in.IsNull() {
+				in.Skip()
+				out.SaleEndsAt = nil
+			} else {
+				if out.SaleEndsAt == nil {
+					out.SaleEndsAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.SaleEndsAt).UnmarshalJSON(data))
+				}
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -516,6 +573,96 @@ func easyjsonD2b7633eEncodeApiModels4(out *jwriter.Writer, in Skin) {
 		}
 		out.Int(int(in.Cost))
 	}
+	{
+		const prefix string = ",\"description\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"rarity\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Rarity))
+	}
+	if in.AssetURL != nil {
+		const prefix string = ",\"asset_url\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.AssetURL))
+	}
+	if in.AvailableFrom != nil {
+		const prefix string = ",\"available_from\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.AvailableFrom).MarshalJSON())
+	}
+	if in.AvailableUntil != nil {
+		const prefix string = ",\"available_until\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.AvailableUntil).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"hidden\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Hidden))
+	}
+	{
+		const prefix string = ",\"retired\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Retired))
+	}
+	{
+		const prefix string = ",\"price\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Price))
+	}
+	if in.SaleEndsAt != nil {
+		const prefix string = ",\"sale_ends_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.SaleEndsAt).MarshalJSON())
+	}
 	out.RawByte('}')
 }
 
@@ -542,7 +689,7 @@ func (v *Skin) UnmarshalJSON(data []byte) error {
 func (v *Skin) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeApiModels4(l, v)
 }
-func easyjsonD2b7633eDecodeApiModels5(in *jlexer.Lexer, out *Session) {
+func easyjsonD2b7633eDecodeApiModels5(in *jlexer.Lexer, out *SessionList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -561,8 +708,29 @@ func easyjsonD2b7633eDecodeApiModels5(in *jlexer.Lexer, out *Session) {
 			continue
 		}
 		switch key {
-		case "session_id":
-			out.SessionID = string(in.String())
+		case "sessions":
+			if in.IsNull() {
+				in.Skip()
+				out.Sessions = nil
+			} else {
+				in.Delim('[')
+				if out.Sessions == nil {
+					if !in.IsDelim(']') {
+						out.Sessions = make([]SessionInfo, 0, 1)
+					} else {
+						out.Sessions = []SessionInfo{}
+					}
+				} else {
+					out.Sessions = (out.Sessions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 SessionInfo
+					(v4).UnmarshalEasyJSON(in)
+					out.Sessions = append(out.Sessions, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -573,47 +741,58 @@ func easyjsonD2b7633eDecodeApiModels5(in *jlexer.Lexer, out *Session) {
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeApiModels5(out *jwriter.Writer, in Session) {
+func easyjsonD2b7633eEncodeApiModels5(out *jwriter.Writer, in SessionList) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"session_id\":"
+		const prefix string = ",\"sessions\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.SessionID))
+		if in.Sessions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Sessions {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Session) MarshalJSON() ([]byte, error) {
+func (v SessionList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeApiModels5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Session) MarshalEasyJSON(w *jwriter.Writer) {
+func (v SessionList) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeApiModels5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Session) UnmarshalJSON(data []byte) error {
+func (v *SessionList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeApiModels5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Session) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *SessionList) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeApiModels5(l, v)
 }
-func easyjsonD2b7633eDecodeApiModels6(in *jlexer.Lexer, out *RequestSkin) {
+func easyjsonD2b7633eDecodeApiModels6(in *jlexer.Lexer, out *SessionInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -632,8 +811,22 @@ func easyjsonD2b7633eDecodeApiModels6(in *jlexer.Lexer, out *RequestSkin) {
 			continue
 		}
 		switch key {
-		case "skin":
-			out.ID = uint(in.Uint())
+		case "device":
+			out.Device = string(in.String())
+		case "user_agent":
+			out.UserAgent = string(in.String())
+		case "ip":
+			out.IP = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		case "last_seen_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.LastSeenAt).UnmarshalJSON(data))
+			}
+		case "current":
+			out.Current = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -644,47 +837,97 @@ func easyjsonD2b7633eDecodeApiModels6(in *jlexer.Lexer, out *RequestSkin) {
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeApiModels6(out *jwriter.Writer, in RequestSkin) {
+func easyjsonD2b7633eEncodeApiModels6(out *jwriter.Writer, in SessionInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"skin\":"
+		const prefix string = ",\"device\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Uint(uint(in.ID))
+		out.String(string(in.Device))
+	}
+	{
+		const prefix string = ",\"user_agent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.UserAgent))
+	}
+	{
+		const prefix string = ",\"ip\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.IP))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"last_seen_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.LastSeenAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"current\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Current))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RequestSkin) MarshalJSON() ([]byte, error) {
+func (v SessionInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeApiModels6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RequestSkin) MarshalEasyJSON(w *jwriter.Writer) {
+func (v SessionInfo) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeApiModels6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RequestSkin) UnmarshalJSON(data []byte) error {
+func (v *SessionInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeApiModels6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RequestSkin) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *SessionInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeApiModels6(l, v)
 }
-func easyjsonD2b7633eDecodeApiModels7(in *jlexer.Lexer, out *RegisterProfile) {
+func easyjsonD2b7633eDecodeApiModels7(in *jlexer.Lexer, out *Session) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -703,12 +946,8 @@ func easyjsonD2b7633eDecodeApiModels7(in *jlexer.Lexer, out *RegisterProfile) {
 			continue
 		}
 		switch key {
-		case "nickname":
-			out.Nickname = string(in.String())
-		case "email":
-			out.Email = string(in.String())
-		case "password":
-			out.Password = string(in.String())
+		case "session_id":
+			out.SessionID = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -719,67 +958,47 @@ func easyjsonD2b7633eDecodeApiModels7(in *jlexer.Lexer, out *RegisterProfile) {
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeApiModels7(out *jwriter.Writer, in RegisterProfile) {
+func easyjsonD2b7633eEncodeApiModels7(out *jwriter.Writer, in Session) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"nickname\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.String(string(in.Nickname))
-	}
-	if in.Email != "" {
-		const prefix string = ",\"email\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.String(string(in.Email))
-	}
-	if in.Password != "" {
-		const prefix string = ",\"password\":"
+		const prefix string = ",\"session_id\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Password))
+		out.String(string(in.SessionID))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RegisterProfile) MarshalJSON() ([]byte, error) {
+func (v Session) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeApiModels7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RegisterProfile) MarshalEasyJSON(w *jwriter.Writer) {
+func (v Session) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeApiModels7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RegisterProfile) UnmarshalJSON(data []byte) error {
+func (v *Session) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeApiModels7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RegisterProfile) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *Session) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeApiModels7(l, v)
 }
-func easyjsonD2b7633eDecodeApiModels8(in *jlexer.Lexer, out *ProfileErrorList) {
+func easyjsonD2b7633eDecodeApiModels8(in *jlexer.Lexer, out *SendGift) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -798,29 +1017,16 @@ func easyjsonD2b7633eDecodeApiModels8(in *jlexer.Lexer, out *ProfileErrorList) {
 			continue
 		}
 		switch key {
-		case "error":
-			if in.IsNull() {
-				in.Skip()
-				out.Errors = nil
-			} else {
-				in.Delim('[')
-				if out.Errors == nil {
-					if !in.IsDelim(']') {
-						out.Errors = make([]ProfileError, 0, 2)
-					} else {
-						out.Errors = []ProfileError{}
-					}
-				} else {
-					out.Errors = (out.Errors)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v4 ProfileError
-					(v4).UnmarshalEasyJSON(in)
-					out.Errors = append(out.Errors, v4)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
+		case "id":
+			out.ID = uint(in.Uint())
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "skin":
+			out.SkinID = uint(in.Uint())
+		case "coins":
+			out.Coins = int(in.Int())
+		case "message":
+			out.Message = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -831,58 +1037,4877 @@ func easyjsonD2b7633eDecodeApiModels8(in *jlexer.Lexer, out *ProfileErrorList) {
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeApiModels8(out *jwriter.Writer, in ProfileErrorList) {
+func easyjsonD2b7633eEncodeApiModels8(out *jwriter.Writer, in SendGift) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"error\":"
+	if in.ID != 0 {
+		const prefix string = ",\"id\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.Errors == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v5, v6 := range in.Errors {
-				if v5 > 0 {
-					out.RawByte(',')
-				}
-				(v6).MarshalEasyJSON(out)
-			}
-			out.RawByte(']')
-		}
+		out.Uint(uint(in.ID))
+	}
+	if in.Nickname != "" {
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	if in.SkinID != 0 {
+		const prefix string = ",\"skin\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.SkinID))
+	}
+	if in.Coins != 0 {
+		const prefix string = ",\"coins\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Coins))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SendGift) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SendGift) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SendGift) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SendGift) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels8(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels9(in *jlexer.Lexer, out *SeasonList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "seasons":
+			if in.IsNull() {
+				in.Skip()
+				out.Seasons = nil
+			} else {
+				in.Delim('[')
+				if out.Seasons == nil {
+					if !in.IsDelim(']') {
+						out.Seasons = make([]Season, 0, 1)
+					} else {
+						out.Seasons = []Season{}
+					}
+				} else {
+					out.Seasons = (out.Seasons)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 Season
+					(v7).UnmarshalEasyJSON(in)
+					out.Seasons = append(out.Seasons, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels9(out *jwriter.Writer, in SeasonList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"seasons\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Seasons == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Seasons {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SeasonList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SeasonList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SeasonList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SeasonList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels9(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels10(in *jlexer.Lexer, out *Season) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint(in.Uint())
+		case "name":
+			out.Name = string(in.String())
+		case "starts_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.StartsAt).UnmarshalJSON(data))
+			}
+		case "ends_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.EndsAt).UnmarshalJSON(data))
+			}
+		case "archived":
+			out.Archived = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels10(out *jwriter.Writer, in Season) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"starts_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.StartsAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"ends_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.EndsAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"archived\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Archived))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Season) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Season) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Season) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Season) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels10(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels11(in *jlexer.Lexer, out *SaleList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "sales":
+			if in.IsNull() {
+				in.Skip()
+				out.Sales = nil
+			} else {
+				in.Delim('[')
+				if out.Sales == nil {
+					if !in.IsDelim(']') {
+						out.Sales = make([]Sale, 0, 1)
+					} else {
+						out.Sales = []Sale{}
+					}
+				} else {
+					out.Sales = (out.Sales)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 Sale
+					(v10).UnmarshalEasyJSON(in)
+					out.Sales = append(out.Sales, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels11(out *jwriter.Writer, in SaleList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"sales\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Sales == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Sales {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				(v12).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SaleList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SaleList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SaleList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SaleList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels11(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels12(in *jlexer.Lexer, out *Sale) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint(in.Uint())
+		case "skin":
+			out.SkinID = uint(in.Uint())
+		case "percent_off":
+			if in.IsNull() {
+				in.Skip()
+				out.PercentOff = nil
+			} else {
+				if out.PercentOff == nil {
+					out.PercentOff = new(int)
+				}
+				*out.PercentOff = int(in.Int())
+			}
+		case "amount_off":
+			if in.IsNull() {
+				in.Skip()
+				out.AmountOff = nil
+			} else {
+				if out.AmountOff == nil {
+					out.AmountOff = new(int)
+				}
+				*out.AmountOff = int(in.Int())
+			}
+		case "starts_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.StartsAt).UnmarshalJSON(data))
+			}
+		case "ends_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.EndsAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels12(out *jwriter.Writer, in Sale) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ID))
+	}
+	{
+		const prefix string = ",\"skin\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.SkinID))
+	}
+	if in.PercentOff != nil {
+		const prefix string = ",\"percent_off\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(*in.PercentOff))
+	}
+	if in.AmountOff != nil {
+		const prefix string = ",\"amount_off\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(*in.AmountOff))
+	}
+	{
+		const prefix string = ",\"starts_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.StartsAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"ends_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.EndsAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Sale) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Sale) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Sale) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Sale) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels12(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels13(in *jlexer.Lexer, out *RequestSkin) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "skin":
+			out.ID = uint(in.Uint())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels13(out *jwriter.Writer, in RequestSkin) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"skin\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RequestSkin) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RequestSkin) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RequestSkin) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RequestSkin) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels13(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels14(in *jlexer.Lexer, out *RequestBundle) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "bundle":
+			out.ID = uint(in.Uint())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels14(out *jwriter.Writer, in RequestBundle) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"bundle\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RequestBundle) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RequestBundle) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RequestBundle) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RequestBundle) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels14(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels15(in *jlexer.Lexer, out *RegisterProfile) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		case "password":
+			out.Password = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels15(out *jwriter.Writer, in RegisterProfile) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	if in.Email != "" {
+		const prefix string = ",\"email\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Email))
+	}
+	if in.Password != "" {
+		const prefix string = ",\"password\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Password))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegisterProfile) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels15(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegisterProfile) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels15(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegisterProfile) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels15(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegisterProfile) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels15(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels16(in *jlexer.Lexer, out *RedeemCode) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "code":
+			out.Code = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels16(out *jwriter.Writer, in RedeemCode) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"code\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Code))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RedeemCode) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RedeemCode) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RedeemCode) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RedeemCode) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels16(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels17(in *jlexer.Lexer, out *PromoReward) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "coins":
+			out.Coins = int(in.Int())
+		case "skin":
+			if in.IsNull() {
+				in.Skip()
+				out.SkinID = nil
+			} else {
+				if out.SkinID == nil {
+					out.SkinID = new(uint)
+				}
+				*out.SkinID = uint(in.Uint())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels17(out *jwriter.Writer, in PromoReward) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Coins != 0 {
+		const prefix string = ",\"coins\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Coins))
+	}
+	if in.SkinID != nil {
+		const prefix string = ",\"skin\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(*in.SkinID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PromoReward) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels17(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PromoReward) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels17(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PromoReward) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels17(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PromoReward) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels17(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels18(in *jlexer.Lexer, out *PromoCodeList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "codes":
+			if in.IsNull() {
+				in.Skip()
+				out.Codes = nil
+			} else {
+				in.Delim('[')
+				if out.Codes == nil {
+					if !in.IsDelim(']') {
+						out.Codes = make([]PromoCode, 0, 1)
+					} else {
+						out.Codes = []PromoCode{}
+					}
+				} else {
+					out.Codes = (out.Codes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 PromoCode
+					(v13).UnmarshalEasyJSON(in)
+					out.Codes = append(out.Codes, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels18(out *jwriter.Writer, in PromoCodeList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"codes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Codes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.Codes {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				(v15).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PromoCodeList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels18(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PromoCodeList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels18(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PromoCodeList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels18(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PromoCodeList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels18(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels19(in *jlexer.Lexer, out *PromoCode) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint(in.Uint())
+		case "code":
+			out.Code = string(in.String())
+		case "coins":
+			out.Coins = int(in.Int())
+		case "skin":
+			if in.IsNull() {
+				in.Skip()
+				out.SkinID = nil
+			} else {
+				if out.SkinID == nil {
+					out.SkinID = new(uint)
+				}
+				*out.SkinID = uint(in.Uint())
+			}
+		case "max_redemptions":
+			if in.IsNull() {
+				in.Skip()
+				out.MaxRedemptions = nil
+			} else {
+				if out.MaxRedemptions == nil {
+					out.MaxRedemptions = new(int)
+				}
+				*out.MaxRedemptions = int(in.Int())
+			}
+		case "redeemed":
+			out.Redeemed = int(in.Int())
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ExpiresAt = nil
+			} else {
+				if out.ExpiresAt == nil {
+					out.ExpiresAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels19(out *jwriter.Writer, in PromoCode) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ID))
+	}
+	{
+		const prefix string = ",\"code\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Code))
+	}
+	if in.Coins != 0 {
+		const prefix string = ",\"coins\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Coins))
+	}
+	if in.SkinID != nil {
+		const prefix string = ",\"skin\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(*in.SkinID))
+	}
+	if in.MaxRedemptions != nil {
+		const prefix string = ",\"max_redemptions\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(*in.MaxRedemptions))
+	}
+	{
+		const prefix string = ",\"redeemed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Redeemed))
+	}
+	if in.ExpiresAt != nil {
+		const prefix string = ",\"expires_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.ExpiresAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PromoCode) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels19(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PromoCode) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels19(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PromoCode) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels19(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PromoCode) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels19(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels20(in *jlexer.Lexer, out *PromoBatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "count":
+			out.Count = int(in.Int())
+		case "prefix":
+			out.Prefix = string(in.String())
+		case "id":
+			out.ID = uint(in.Uint())
+		case "code":
+			out.Code = string(in.String())
+		case "coins":
+			out.Coins = int(in.Int())
+		case "skin":
+			if in.IsNull() {
+				in.Skip()
+				out.SkinID = nil
+			} else {
+				if out.SkinID == nil {
+					out.SkinID = new(uint)
+				}
+				*out.SkinID = uint(in.Uint())
+			}
+		case "max_redemptions":
+			if in.IsNull() {
+				in.Skip()
+				out.MaxRedemptions = nil
+			} else {
+				if out.MaxRedemptions == nil {
+					out.MaxRedemptions = new(int)
+				}
+				*out.MaxRedemptions = int(in.Int())
+			}
+		case "redeemed":
+			out.Redeemed = int(in.Int())
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ExpiresAt = nil
+			} else {
+				if out.ExpiresAt == nil {
+					out.ExpiresAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels20(out *jwriter.Writer, in PromoBatch) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Count != 0 {
+		const prefix string = ",\"count\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Count))
+	}
+	if in.Prefix != "" {
+		const prefix string = ",\"prefix\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Prefix))
+	}
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ID))
+	}
+	{
+		const prefix string = ",\"code\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Code))
+	}
+	if in.Coins != 0 {
+		const prefix string = ",\"coins\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Coins))
+	}
+	if in.SkinID != nil {
+		const prefix string = ",\"skin\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(*in.SkinID))
+	}
+	if in.MaxRedemptions != nil {
+		const prefix string = ",\"max_redemptions\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(*in.MaxRedemptions))
+	}
+	{
+		const prefix string = ",\"redeemed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Redeemed))
+	}
+	if in.ExpiresAt != nil {
+		const prefix string = ",\"expires_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.ExpiresAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PromoBatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels20(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PromoBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels20(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PromoBatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels20(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PromoBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels20(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels21(in *jlexer.Lexer, out *ProfileUpdate) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "current_password":
+			out.CurrentPassword = string(in.String())
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		case "password":
+			out.Password = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels21(out *jwriter.Writer, in ProfileUpdate) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.CurrentPassword != "" {
+		const prefix string = ",\"current_password\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CurrentPassword))
+	}
+	{
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	if in.Email != "" {
+		const prefix string = ",\"email\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Email))
+	}
+	if in.Password != "" {
+		const prefix string = ",\"password\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Password))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ProfileUpdate) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels21(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ProfileUpdate) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels21(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ProfileUpdate) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels21(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ProfileUpdate) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels21(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels22(in *jlexer.Lexer, out *ProfileErrorList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "error":
+			if in.IsNull() {
+				in.Skip()
+				out.Errors = nil
+			} else {
+				in.Delim('[')
+				if out.Errors == nil {
+					if !in.IsDelim(']') {
+						out.Errors = make([]ProfileError, 0, 2)
+					} else {
+						out.Errors = []ProfileError{}
+					}
+				} else {
+					out.Errors = (out.Errors)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v16 ProfileError
+					(v16).UnmarshalEasyJSON(in)
+					out.Errors = append(out.Errors, v16)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels22(out *jwriter.Writer, in ProfileErrorList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"error\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Errors == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v17, v18 := range in.Errors {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				(v18).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ProfileErrorList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels22(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ProfileErrorList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels22(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ProfileErrorList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels22(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ProfileErrorList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels22(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels23(in *jlexer.Lexer, out *ProfileError) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "field":
+			out.Field = string(in.String())
+		case "text":
+			out.Text = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels23(out *jwriter.Writer, in ProfileError) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"field\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Field))
+	}
+	{
+		const prefix string = ",\"text\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Text))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ProfileError) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels23(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ProfileError) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels23(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ProfileError) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels23(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ProfileError) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels23(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels24(in *jlexer.Lexer, out *Profile) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "avatar":
+			if in.IsNull() {
+				in.Skip()
+				out.Avatar = nil
+			} else {
+				if out.Avatar == nil {
+					out.Avatar = new(string)
+				}
+				*out.Avatar = string(in.String())
+			}
+		case "email_verified":
+			if in.IsNull() {
+				in.Skip()
+				out.EmailVerified = nil
+			} else {
+				if out.EmailVerified == nil {
+					out.EmailVerified = new(bool)
+				}
+				*out.EmailVerified = bool(in.Bool())
+			}
+		case "last_matches":
+			if in.IsNull() {
+				in.Skip()
+				out.LastMatches = nil
+			} else {
+				in.Delim('[')
+				if out.LastMatches == nil {
+					if !in.IsDelim(']') {
+						out.LastMatches = make([]MatchHistoryEntry, 0, 1)
+					} else {
+						out.LastMatches = []MatchHistoryEntry{}
+					}
+				} else {
+					out.LastMatches = (out.LastMatches)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v19 MatchHistoryEntry
+					(v19).UnmarshalEasyJSON(in)
+					out.LastMatches = append(out.LastMatches, v19)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "daily":
+			if in.IsNull() {
+				in.Skip()
+				out.Daily = nil
+			} else {
+				if out.Daily == nil {
+					out.Daily = new(DailyStreak)
+				}
+				(*out.Daily).UnmarshalEasyJSON(in)
+			}
+		case "coins":
+			if in.IsNull() {
+				in.Skip()
+				out.Coins = nil
+			} else {
+				if out.Coins == nil {
+					out.Coins = new(int)
+				}
+				*out.Coins = int(in.Int())
+			}
+		case "skins":
+			if in.IsNull() {
+				in.Skip()
+				out.PurchasedSkins = nil
+			} else {
+				in.Delim('[')
+				if out.PurchasedSkins == nil {
+					if !in.IsDelim(']') {
+						out.PurchasedSkins = make([]uint, 0, 8)
+					} else {
+						out.PurchasedSkins = []uint{}
+					}
+				} else {
+					out.PurchasedSkins = (out.PurchasedSkins)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v20 uint
+					v20 = uint(in.Uint())
+					out.PurchasedSkins = append(out.PurchasedSkins, v20)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "current_skin":
+			if in.IsNull() {
+				in.Skip()
+				out.Skin = nil
+			} else {
+				if out.Skin == nil {
+					out.Skin = new(uint)
+				}
+				*out.Skin = uint(in.Uint())
+			}
+		case "record":
+			out.Record = int(in.Int())
+		case "win":
+			out.Win = int(in.Int())
+		case "draws":
+			out.Draws = int(in.Int())
+		case "loss":
+			out.Loss = int(in.Int())
+		case "id":
+			out.UserID = uint(in.Uint())
+		case "email":
+			out.Email = string(in.String())
+		case "password":
+			out.Password = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels24(out *jwriter.Writer, in Profile) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	if in.Avatar != nil {
+		const prefix string = ",\"avatar\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Avatar))
+	}
+	if in.EmailVerified != nil {
+		const prefix string = ",\"email_verified\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(*in.EmailVerified))
+	}
+	if len(in.LastMatches) != 0 {
+		const prefix string = ",\"last_matches\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v21, v22 := range in.LastMatches {
+				if v21 > 0 {
+					out.RawByte(',')
+				}
+				(v22).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Daily != nil {
+		const prefix string = ",\"daily\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(*in.Daily).MarshalEasyJSON(out)
+	}
+	if in.Coins != nil {
+		const prefix string = ",\"coins\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(*in.Coins))
+	}
+	if len(in.PurchasedSkins) != 0 {
+		const prefix string = ",\"skins\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v23, v24 := range in.PurchasedSkins {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				out.Uint(uint(v24))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"current_skin\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Skin == nil {
+			out.RawString("null")
+		} else {
+			out.Uint(uint(*in.Skin))
+		}
+	}
+	{
+		const prefix string = ",\"record\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Record))
+	}
+	{
+		const prefix string = ",\"win\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Win))
+	}
+	{
+		const prefix string = ",\"draws\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Draws))
+	}
+	{
+		const prefix string = ",\"loss\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Loss))
+	}
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.UserID))
+	}
+	if in.Email != "" {
+		const prefix string = ",\"email\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Email))
+	}
+	if in.Password != "" {
+		const prefix string = ",\"password\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Password))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Profile) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Profile) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels24(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Profile) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Profile) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels24(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels25(in *jlexer.Lexer, out *PositionList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "players":
+			if in.IsNull() {
+				in.Skip()
+				out.List = nil
+			} else {
+				in.Delim('[')
+				if out.List == nil {
+					if !in.IsDelim(']') {
+						out.List = make([]Position, 0, 1)
+					} else {
+						out.List = []Position{}
+					}
+				} else {
+					out.List = (out.List)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 Position
+					(v25).UnmarshalEasyJSON(in)
+					out.List = append(out.List, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "total":
+			out.Total = int(in.Int())
+		case "next":
+			out.Next = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels25(out *jwriter.Writer, in PositionList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"players\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.List == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v26, v27 := range in.List {
+				if v26 > 0 {
+					out.RawByte(',')
+				}
+				(v27).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"total\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Total))
+	}
+	if in.Next != "" {
+		const prefix string = ",\"next\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Next))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PositionList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels25(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PositionList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels25(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PositionList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels25(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PositionList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels25(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels26(in *jlexer.Lexer, out *Position) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint(in.Uint())
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "record":
+			out.Points = int(in.Int())
+		case "rank":
+			out.Rank = int(in.Int())
+		case "dense_rank":
+			out.DenseRank = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels26(out *jwriter.Writer, in Position) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ID))
+	}
+	{
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	{
+		const prefix string = ",\"record\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Points))
+	}
+	{
+		const prefix string = ",\"rank\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Rank))
+	}
+	{
+		const prefix string = ",\"dense_rank\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.DenseRank))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Position) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels26(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Position) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels26(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Position) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels26(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Position) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels26(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels27(in *jlexer.Lexer, out *PlayerResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.UserID = uint(in.Uint())
+		case "score":
+			out.Score = int(in.Int())
+		case "result":
+			out.Outcome = string(in.String())
+		case "coins":
+			out.Coins = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels27(out *jwriter.Writer, in PlayerResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.UserID))
+	}
+	{
+		const prefix string = ",\"score\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Score))
+	}
+	{
+		const prefix string = ",\"result\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Outcome))
+	}
+	{
+		const prefix string = ",\"coins\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Coins))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PlayerResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels27(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PlayerResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels27(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PlayerResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels27(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PlayerResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels27(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels28(in *jlexer.Lexer, out *PasswordResetRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "email":
+			out.Email = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels28(out *jwriter.Writer, in PasswordResetRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"email\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Email))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PasswordResetRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels28(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PasswordResetRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels28(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PasswordResetRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels28(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PasswordResetRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels28(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels29(in *jlexer.Lexer, out *PasswordReset) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "token":
+			out.Token = string(in.String())
+		case "password":
+			out.Password = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels29(out *jwriter.Writer, in PasswordReset) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"token\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Token))
+	}
+	{
+		const prefix string = ",\"password\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Password))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PasswordReset) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels29(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PasswordReset) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels29(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PasswordReset) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels29(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PasswordReset) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels29(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels30(in *jlexer.Lexer, out *Opponent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint(in.Uint())
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "score":
+			out.Score = int(in.Int())
+		case "result":
+			out.Outcome = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels30(out *jwriter.Writer, in Opponent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ID))
+	}
+	{
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	{
+		const prefix string = ",\"score\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Score))
+	}
+	{
+		const prefix string = ",\"result\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Outcome))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Opponent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels30(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Opponent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels30(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Opponent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels30(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Opponent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels30(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels31(in *jlexer.Lexer, out *MatchResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "match_id":
+			out.MatchID = string(in.String())
+		case "players":
+			if in.IsNull() {
+				in.Skip()
+				out.Players = nil
+			} else {
+				in.Delim('[')
+				if out.Players == nil {
+					if !in.IsDelim(']') {
+						out.Players = make([]PlayerResult, 0, 1)
+					} else {
+						out.Players = []PlayerResult{}
+					}
+				} else {
+					out.Players = (out.Players)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v28 PlayerResult
+					(v28).UnmarshalEasyJSON(in)
+					out.Players = append(out.Players, v28)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels31(out *jwriter.Writer, in MatchResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"match_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.MatchID))
+	}
+	{
+		const prefix string = ",\"players\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Players == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v29, v30 := range in.Players {
+				if v29 > 0 {
+					out.RawByte(',')
+				}
+				(v30).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MatchResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels31(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MatchResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels31(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MatchResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels31(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MatchResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels31(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels32(in *jlexer.Lexer, out *MatchHistoryEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "match_id":
+			out.MatchID = string(in.String())
+		case "played_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.PlayedAt).UnmarshalJSON(data))
+			}
+		case "score":
+			out.Score = int(in.Int())
+		case "result":
+			out.Outcome = string(in.String())
+		case "coins":
+			out.Coins = int(in.Int())
+		case "opponents":
+			if in.IsNull() {
+				in.Skip()
+				out.Opponents = nil
+			} else {
+				in.Delim('[')
+				if out.Opponents == nil {
+					if !in.IsDelim(']') {
+						out.Opponents = make([]Opponent, 0, 1)
+					} else {
+						out.Opponents = []Opponent{}
+					}
+				} else {
+					out.Opponents = (out.Opponents)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v31 Opponent
+					(v31).UnmarshalEasyJSON(in)
+					out.Opponents = append(out.Opponents, v31)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels32(out *jwriter.Writer, in MatchHistoryEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"match_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.MatchID))
+	}
+	{
+		const prefix string = ",\"played_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.PlayedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"score\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Score))
+	}
+	{
+		const prefix string = ",\"result\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Outcome))
+	}
+	{
+		const prefix string = ",\"coins\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Coins))
+	}
+	{
+		const prefix string = ",\"opponents\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Opponents == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v32, v33 := range in.Opponents {
+				if v32 > 0 {
+					out.RawByte(',')
+				}
+				(v33).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MatchHistoryEntry) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels32(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MatchHistoryEntry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels32(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MatchHistoryEntry) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels32(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MatchHistoryEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels32(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels33(in *jlexer.Lexer, out *MatchHistory) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "matches":
+			if in.IsNull() {
+				in.Skip()
+				out.Matches = nil
+			} else {
+				in.Delim('[')
+				if out.Matches == nil {
+					if !in.IsDelim(']') {
+						out.Matches = make([]MatchHistoryEntry, 0, 1)
+					} else {
+						out.Matches = []MatchHistoryEntry{}
+					}
+				} else {
+					out.Matches = (out.Matches)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v34 MatchHistoryEntry
+					(v34).UnmarshalEasyJSON(in)
+					out.Matches = append(out.Matches, v34)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "next":
+			out.Next = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels33(out *jwriter.Writer, in MatchHistory) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"matches\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Matches == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v35, v36 := range in.Matches {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				(v36).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Next != "" {
+		const prefix string = ",\"next\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Next))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MatchHistory) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels33(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MatchHistory) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels33(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MatchHistory) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels33(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MatchHistory) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels33(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels34(in *jlexer.Lexer, out *Health) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = string(in.String())
+		case "dependencies":
+			if in.IsNull() {
+				in.Skip()
+				out.Dependencies = nil
+			} else {
+				in.Delim('[')
+				if out.Dependencies == nil {
+					if !in.IsDelim(']') {
+						out.Dependencies = make([]DependencyHealth, 0, 1)
+					} else {
+						out.Dependencies = []DependencyHealth{}
+					}
+				} else {
+					out.Dependencies = (out.Dependencies)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v37 DependencyHealth
+					(v37).UnmarshalEasyJSON(in)
+					out.Dependencies = append(out.Dependencies, v37)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels34(out *jwriter.Writer, in Health) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Status))
+	}
+	if len(in.Dependencies) != 0 {
+		const prefix string = ",\"dependencies\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v38, v39 := range in.Dependencies {
+				if v38 > 0 {
+					out.RawByte(',')
+				}
+				(v39).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Health) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels34(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Health) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels34(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Health) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels34(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Health) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels34(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels35(in *jlexer.Lexer, out *GiftList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "incoming":
+			if in.IsNull() {
+				in.Skip()
+				out.Incoming = nil
+			} else {
+				in.Delim('[')
+				if out.Incoming == nil {
+					if !in.IsDelim(']') {
+						out.Incoming = make([]Gift, 0, 1)
+					} else {
+						out.Incoming = []Gift{}
+					}
+				} else {
+					out.Incoming = (out.Incoming)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v40 Gift
+					(v40).UnmarshalEasyJSON(in)
+					out.Incoming = append(out.Incoming, v40)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "sent":
+			if in.IsNull() {
+				in.Skip()
+				out.Sent = nil
+			} else {
+				in.Delim('[')
+				if out.Sent == nil {
+					if !in.IsDelim(']') {
+						out.Sent = make([]Gift, 0, 1)
+					} else {
+						out.Sent = []Gift{}
+					}
+				} else {
+					out.Sent = (out.Sent)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v41 Gift
+					(v41).UnmarshalEasyJSON(in)
+					out.Sent = append(out.Sent, v41)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels35(out *jwriter.Writer, in GiftList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"incoming\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Incoming == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v42, v43 := range in.Incoming {
+				if v42 > 0 {
+					out.RawByte(',')
+				}
+				(v43).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"sent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Sent == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v44, v45 := range in.Sent {
+				if v44 > 0 {
+					out.RawByte(',')
+				}
+				(v45).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GiftList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels35(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GiftList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels35(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GiftList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels35(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GiftList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels35(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels36(in *jlexer.Lexer, out *GiftAction) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint(in.Uint())
+		case "action":
+			out.Action = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels36(out *jwriter.Writer, in GiftAction) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ID))
+	}
+	{
+		const prefix string = ",\"action\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Action))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GiftAction) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels36(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GiftAction) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels36(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GiftAction) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels36(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GiftAction) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels36(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels37(in *jlexer.Lexer, out *Gift) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint(in.Uint())
+		case "from":
+			out.FromID = uint(in.Uint())
+		case "from_nickname":
+			out.FromNickname = string(in.String())
+		case "to":
+			out.ToID = uint(in.Uint())
+		case "to_nickname":
+			out.ToNickname = string(in.String())
+		case "skin":
+			if in.IsNull() {
+				in.Skip()
+				out.SkinID = nil
+			} else {
+				if out.SkinID == nil {
+					out.SkinID = new(uint)
+				}
+				*out.SkinID = uint(in.Uint())
+			}
+		case "coins":
+			out.Coins = int(in.Int())
+		case "message":
+			out.Message = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels37(out *jwriter.Writer, in Gift) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ID))
+	}
+	{
+		const prefix string = ",\"from\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.FromID))
+	}
+	{
+		const prefix string = ",\"from_nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.FromNickname))
+	}
+	{
+		const prefix string = ",\"to\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ToID))
+	}
+	{
+		const prefix string = ",\"to_nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ToNickname))
+	}
+	if in.SkinID != nil {
+		const prefix string = ",\"skin\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(*in.SkinID))
+	}
+	if in.Coins != 0 {
+		const prefix string = ",\"coins\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Coins))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Gift) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels37(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Gift) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels37(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Gift) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels37(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Gift) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels37(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels38(in *jlexer.Lexer, out *FriendList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "friends":
+			if in.IsNull() {
+				in.Skip()
+				out.Friends = nil
+			} else {
+				in.Delim('[')
+				if out.Friends == nil {
+					if !in.IsDelim(']') {
+						out.Friends = make([]Friend, 0, 1)
+					} else {
+						out.Friends = []Friend{}
+					}
+				} else {
+					out.Friends = (out.Friends)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v46 Friend
+					(v46).UnmarshalEasyJSON(in)
+					out.Friends = append(out.Friends, v46)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "incoming":
+			if in.IsNull() {
+				in.Skip()
+				out.Incoming = nil
+			} else {
+				in.Delim('[')
+				if out.Incoming == nil {
+					if !in.IsDelim(']') {
+						out.Incoming = make([]Friend, 0, 1)
+					} else {
+						out.Incoming = []Friend{}
+					}
+				} else {
+					out.Incoming = (out.Incoming)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v47 Friend
+					(v47).UnmarshalEasyJSON(in)
+					out.Incoming = append(out.Incoming, v47)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "outgoing":
+			if in.IsNull() {
+				in.Skip()
+				out.Outgoing = nil
+			} else {
+				in.Delim('[')
+				if out.Outgoing == nil {
+					if !in.IsDelim(']') {
+						out.Outgoing = make([]Friend, 0, 1)
+					} else {
+						out.Outgoing = []Friend{}
+					}
+				} else {
+					out.Outgoing = (out.Outgoing)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v48 Friend
+					(v48).UnmarshalEasyJSON(in)
+					out.Outgoing = append(out.Outgoing, v48)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "blocked":
+			if in.IsNull() {
+				in.Skip()
+				out.Blocked = nil
+			} else {
+				in.Delim('[')
+				if out.Blocked == nil {
+					if !in.IsDelim(']') {
+						out.Blocked = make([]Friend, 0, 1)
+					} else {
+						out.Blocked = []Friend{}
+					}
+				} else {
+					out.Blocked = (out.Blocked)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v49 Friend
+					(v49).UnmarshalEasyJSON(in)
+					out.Blocked = append(out.Blocked, v49)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels38(out *jwriter.Writer, in FriendList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"friends\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Friends == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v50, v51 := range in.Friends {
+				if v50 > 0 {
+					out.RawByte(',')
+				}
+				(v51).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"incoming\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Incoming == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v52, v53 := range in.Incoming {
+				if v52 > 0 {
+					out.RawByte(',')
+				}
+				(v53).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"outgoing\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Outgoing == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v54, v55 := range in.Outgoing {
+				if v54 > 0 {
+					out.RawByte(',')
+				}
+				(v55).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"blocked\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Blocked == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v56, v57 := range in.Blocked {
+				if v56 > 0 {
+					out.RawByte(',')
+				}
+				(v57).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FriendList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels38(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FriendList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels38(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FriendList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels38(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FriendList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels38(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels39(in *jlexer.Lexer, out *FriendAction) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint(in.Uint())
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "action":
+			out.Action = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels39(out *jwriter.Writer, in FriendAction) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != 0 {
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ID))
+	}
+	if in.Nickname != "" {
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	if in.Action != "" {
+		const prefix string = ",\"action\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Action))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FriendAction) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels39(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FriendAction) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels39(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FriendAction) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels39(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FriendAction) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels39(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels40(in *jlexer.Lexer, out *Friend) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint(in.Uint())
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "avatar":
+			if in.IsNull() {
+				in.Skip()
+				out.Avatar = nil
+			} else {
+				if out.Avatar == nil {
+					out.Avatar = new(string)
+				}
+				*out.Avatar = string(in.String())
+			}
+		case "record":
+			out.Record = int(in.Int())
+		case "since":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Since).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels40(out *jwriter.Writer, in Friend) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.ID))
+	}
+	{
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	if in.Avatar != nil {
+		const prefix string = ",\"avatar\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Avatar))
+	}
+	{
+		const prefix string = ",\"record\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Record))
+	}
+	{
+		const prefix string = ",\"since\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Since).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Friend) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels40(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Friend) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels40(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Friend) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels40(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Friend) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels40(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels41(in *jlexer.Lexer, out *Error) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "error":
+			out.What = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels41(out *jwriter.Writer, in Error) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"error\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.What))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Error) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels41(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Error) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels41(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Error) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels41(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Error) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels41(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels42(in *jlexer.Lexer, out *EmailTokenConfirmation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "token":
+			out.Token = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels42(out *jwriter.Writer, in EmailTokenConfirmation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"token\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Token))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EmailTokenConfirmation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels42(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EmailTokenConfirmation) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels42(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EmailTokenConfirmation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels42(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EmailTokenConfirmation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels42(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels43(in *jlexer.Lexer, out *DependencyHealth) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "hard":
+			out.Hard = bool(in.Bool())
+		case "status":
+			out.Status = string(in.String())
+		case "latency_ms":
+			out.LatencyMs = float64(in.Float64())
+		case "error":
+			out.Error = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels43(out *jwriter.Writer, in DependencyHealth) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"hard\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Hard))
+	}
+	{
+		const prefix string = ",\"status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"latency_ms\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.LatencyMs))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Error))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DependencyHealth) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels43(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DependencyHealth) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels43(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DependencyHealth) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels43(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DependencyHealth) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels43(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels44(in *jlexer.Lexer, out *DailyStreak) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "streak":
+			out.Streak = int(in.Int())
+		case "claimed_today":
+			out.ClaimedToday = bool(in.Bool())
+		case "next_reward":
+			out.NextReward = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels44(out *jwriter.Writer, in DailyStreak) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"streak\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Streak))
+	}
+	{
+		const prefix string = ",\"claimed_today\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.ClaimedToday))
+	}
+	{
+		const prefix string = ",\"next_reward\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.NextReward))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DailyStreak) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels44(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DailyStreak) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels44(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DailyStreak) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels44(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DailyStreak) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels44(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels45(in *jlexer.Lexer, out *DailyReward) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "streak":
+			out.Streak = int(in.Int())
+		case "coins":
+			out.Coins = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels45(out *jwriter.Writer, in DailyReward) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"streak\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Streak))
+	}
+	{
+		const prefix string = ",\"coins\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Coins))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DailyReward) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels45(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DailyReward) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels45(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DailyReward) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels45(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DailyReward) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels45(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels46(in *jlexer.Lexer, out *CoinTransaction) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "amount":
+			out.Amount = int(in.Int())
+		case "reason":
+			out.Reason = string(in.String())
+		case "reference":
+			if in.IsNull() {
+				in.Skip()
+				out.Reference = nil
+			} else {
+				if out.Reference == nil {
+					out.Reference = new(string)
+				}
+				*out.Reference = string(in.String())
+			}
+		case "balance":
+			out.Balance = int(in.Int())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels46(out *jwriter.Writer, in CoinTransaction) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"amount\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Amount))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Reason))
+	}
+	if in.Reference != nil {
+		const prefix string = ",\"reference\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Reference))
+	}
+	{
+		const prefix string = ",\"balance\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Balance))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CoinTransaction) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels46(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CoinTransaction) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels46(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CoinTransaction) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels46(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CoinTransaction) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels46(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels47(in *jlexer.Lexer, out *CoinLedgerReport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "checked_users":
+			out.CheckedUsers = int(in.Int())
+		case "drifts":
+			if in.IsNull() {
+				in.Skip()
+				out.Drifts = nil
+			} else {
+				in.Delim('[')
+				if out.Drifts == nil {
+					if !in.IsDelim(']') {
+						out.Drifts = make([]CoinDrift, 0, 2)
+					} else {
+						out.Drifts = []CoinDrift{}
+					}
+				} else {
+					out.Drifts = (out.Drifts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v58 CoinDrift
+					(v58).UnmarshalEasyJSON(in)
+					out.Drifts = append(out.Drifts, v58)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels47(out *jwriter.Writer, in CoinLedgerReport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"checked_users\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.CheckedUsers))
+	}
+	{
+		const prefix string = ",\"drifts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Drifts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v59, v60 := range in.Drifts {
+				if v59 > 0 {
+					out.RawByte(',')
+				}
+				(v60).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CoinLedgerReport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels47(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CoinLedgerReport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels47(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CoinLedgerReport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels47(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CoinLedgerReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels47(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels48(in *jlexer.Lexer, out *CoinHistory) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "transactions":
+			if in.IsNull() {
+				in.Skip()
+				out.Transactions = nil
+			} else {
+				in.Delim('[')
+				if out.Transactions == nil {
+					if !in.IsDelim(']') {
+						out.Transactions = make([]CoinTransaction, 0, 1)
+					} else {
+						out.Transactions = []CoinTransaction{}
+					}
+				} else {
+					out.Transactions = (out.Transactions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v61 CoinTransaction
+					(v61).UnmarshalEasyJSON(in)
+					out.Transactions = append(out.Transactions, v61)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "next":
+			out.Next = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels48(out *jwriter.Writer, in CoinHistory) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"transactions\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Transactions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v62, v63 := range in.Transactions {
+				if v62 > 0 {
+					out.RawByte(',')
+				}
+				(v63).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Next != "" {
+		const prefix string = ",\"next\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Next))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ProfileErrorList) MarshalJSON() ([]byte, error) {
+func (v CoinHistory) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeApiModels8(&w, v)
+	easyjsonD2b7633eEncodeApiModels48(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ProfileErrorList) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeApiModels8(w, v)
+func (v CoinHistory) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels48(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ProfileErrorList) UnmarshalJSON(data []byte) error {
+func (v *CoinHistory) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeApiModels8(&r, v)
+	easyjsonD2b7633eDecodeApiModels48(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ProfileErrorList) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeApiModels8(l, v)
+func (v *CoinHistory) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels48(l, v)
 }
-func easyjsonD2b7633eDecodeApiModels9(in *jlexer.Lexer, out *ProfileError) {
+func easyjsonD2b7633eDecodeApiModels49(in *jlexer.Lexer, out *CoinDrift) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -901,10 +5926,22 @@ func easyjsonD2b7633eDecodeApiModels9(in *jlexer.Lexer, out *ProfileError) {
 			continue
 		}
 		switch key {
-		case "field":
-			out.Field = string(in.String())
-		case "text":
-			out.Text = string(in.String())
+		case "id":
+			out.UserID = uint(in.Uint())
+		case "coins":
+			out.Coins = int(in.Int())
+		case "ledger_sum":
+			out.LedgerSum = int(in.Int())
+		case "last_balance":
+			if in.IsNull() {
+				in.Skip()
+				out.LastBalance = nil
+			} else {
+				if out.LastBalance == nil {
+					out.LastBalance = new(int)
+				}
+				*out.LastBalance = int(in.Int())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -915,57 +5952,81 @@ func easyjsonD2b7633eDecodeApiModels9(in *jlexer.Lexer, out *ProfileError) {
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeApiModels9(out *jwriter.Writer, in ProfileError) {
+func easyjsonD2b7633eEncodeApiModels49(out *jwriter.Writer, in CoinDrift) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"field\":"
+		const prefix string = ",\"id\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Field))
+		out.Uint(uint(in.UserID))
 	}
 	{
-		const prefix string = ",\"text\":"
+		const prefix string = ",\"coins\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Text))
+		out.Int(int(in.Coins))
+	}
+	{
+		const prefix string = ",\"ledger_sum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.LedgerSum))
+	}
+	{
+		const prefix string = ",\"last_balance\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.LastBalance == nil {
+			out.RawString("null")
+		} else {
+			out.Int(int(*in.LastBalance))
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ProfileError) MarshalJSON() ([]byte, error) {
+func (v CoinDrift) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeApiModels9(&w, v)
+	easyjsonD2b7633eEncodeApiModels49(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ProfileError) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeApiModels9(w, v)
+func (v CoinDrift) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels49(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ProfileError) UnmarshalJSON(data []byte) error {
+func (v *CoinDrift) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeApiModels9(&r, v)
+	easyjsonD2b7633eDecodeApiModels49(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ProfileError) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeApiModels9(l, v)
+func (v *CoinDrift) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels49(l, v)
 }
-func easyjsonD2b7633eDecodeApiModels10(in *jlexer.Lexer, out *Profile) {
+func easyjsonD2b7633eDecodeApiModels50(in *jlexer.Lexer, out *BundleList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -984,75 +6045,170 @@ func easyjsonD2b7633eDecodeApiModels10(in *jlexer.Lexer, out *Profile) {
 			continue
 		}
 		switch key {
-		case "nickname":
-			out.Nickname = string(in.String())
-		case "avatar":
+		case "bundles":
 			if in.IsNull() {
 				in.Skip()
-				out.Avatar = nil
+				out.Bundles = nil
 			} else {
-				if out.Avatar == nil {
-					out.Avatar = new(string)
+				in.Delim('[')
+				if out.Bundles == nil {
+					if !in.IsDelim(']') {
+						out.Bundles = make([]Bundle, 0, 1)
+					} else {
+						out.Bundles = []Bundle{}
+					}
+				} else {
+					out.Bundles = (out.Bundles)[:0]
 				}
-				*out.Avatar = string(in.String())
+				for !in.IsDelim(']') {
+					var v64 Bundle
+					(v64).UnmarshalEasyJSON(in)
+					out.Bundles = append(out.Bundles, v64)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
-		case "coins":
-			if in.IsNull() {
-				in.Skip()
-				out.Coins = nil
-			} else {
-				if out.Coins == nil {
-					out.Coins = new(int)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeApiModels50(out *jwriter.Writer, in BundleList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"bundles\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Bundles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v65, v66 := range in.Bundles {
+				if v65 > 0 {
+					out.RawByte(',')
 				}
-				*out.Coins = int(in.Int())
+				(v66).MarshalEasyJSON(out)
 			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BundleList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeApiModels50(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BundleList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels50(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BundleList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeApiModels50(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BundleList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels50(l, v)
+}
+func easyjsonD2b7633eDecodeApiModels51(in *jlexer.Lexer, out *Bundle) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint(in.Uint())
+		case "name":
+			out.Name = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "cost":
+			out.Cost = int(in.Int())
+		case "full_price":
+			out.FullPrice = int(in.Int())
 		case "skins":
 			if in.IsNull() {
 				in.Skip()
-				out.PurchasedSkins = nil
+				out.Skins = nil
 			} else {
 				in.Delim('[')
-				if out.PurchasedSkins == nil {
+				if out.Skins == nil {
 					if !in.IsDelim(']') {
-						out.PurchasedSkins = make([]uint, 0, 8)
+						out.Skins = make([]uint, 0, 8)
 					} else {
-						out.PurchasedSkins = []uint{}
+						out.Skins = []uint{}
 					}
 				} else {
-					out.PurchasedSkins = (out.PurchasedSkins)[:0]
+					out.Skins = (out.Skins)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v7 uint
-					v7 = uint(in.Uint())
-					out.PurchasedSkins = append(out.PurchasedSkins, v7)
+					var v67 uint
+					v67 = uint(in.Uint())
+					out.Skins = append(out.Skins, v67)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "current_skin":
+		case "available_from":
 			if in.IsNull() {
 				in.Skip()
-				out.Skin = nil
+				out.AvailableFrom = nil
 			} else {
-				if out.Skin == nil {
-					out.Skin = new(uint)
+				if out.AvailableFrom == nil {
+					out.AvailableFrom = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.AvailableFrom).UnmarshalJSON(data))
 				}
-				*out.Skin = uint(in.Uint())
 			}
-		case "record":
-			out.Record = int(in.Int())
-		case "win":
-			out.Win = int(in.Int())
-		case "draws":
-			out.Draws = int(in.Int())
-		case "loss":
-			out.Loss = int(in.Int())
-		case "id":
-			out.UserID = uint(in.Uint())
-		case "email":
-			out.Email = string(in.String())
-		case "password":
-			out.Password = string(in.String())
+		case "available_until":
+			if in.IsNull() {
+				in.Skip()
+				out.AvailableUntil = nil
+			} else {
+				if out.AvailableUntil == nil {
+					out.AvailableUntil = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.AvailableUntil).UnmarshalJSON(data))
+				}
+			}
+		case "hidden":
+			out.Hidden = bool(in.Bool())
+		case "retired":
+			out.Retired = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -1063,170 +6219,148 @@ func easyjsonD2b7633eDecodeApiModels10(in *jlexer.Lexer, out *Profile) {
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeApiModels10(out *jwriter.Writer, in Profile) {
+func easyjsonD2b7633eEncodeApiModels51(out *jwriter.Writer, in Bundle) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"nickname\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.String(string(in.Nickname))
-	}
-	if in.Avatar != nil {
-		const prefix string = ",\"avatar\":"
+		const prefix string = ",\"id\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(*in.Avatar))
+		out.Uint(uint(in.ID))
 	}
-	if in.Coins != nil {
-		const prefix string = ",\"coins\":"
+	{
+		const prefix string = ",\"name\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int(int(*in.Coins))
+		out.String(string(in.Name))
 	}
-	if len(in.PurchasedSkins) != 0 {
-		const prefix string = ",\"skins\":"
+	{
+		const prefix string = ",\"description\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v8, v9 := range in.PurchasedSkins {
-				if v8 > 0 {
-					out.RawByte(',')
-				}
-				out.Uint(uint(v9))
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.Description))
 	}
 	{
-		const prefix string = ",\"current_skin\":"
+		const prefix string = ",\"cost\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.Skin == nil {
-			out.RawString("null")
-		} else {
-			out.Uint(uint(*in.Skin))
-		}
+		out.Int(int(in.Cost))
 	}
 	{
-		const prefix string = ",\"record\":"
+		const prefix string = ",\"full_price\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int(int(in.Record))
+		out.Int(int(in.FullPrice))
 	}
 	{
-		const prefix string = ",\"win\":"
+		const prefix string = ",\"skins\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int(int(in.Win))
-	}
-	{
-		const prefix string = ",\"draws\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
+		if in.Skins == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
 		} else {
-			out.RawString(prefix)
+			out.RawByte('[')
+			for v68, v69 := range in.Skins {
+				if v68 > 0 {
+					out.RawByte(',')
+				}
+				out.Uint(uint(v69))
+			}
+			out.RawByte(']')
 		}
-		out.Int(int(in.Draws))
 	}
-	{
-		const prefix string = ",\"loss\":"
+	if in.AvailableFrom != nil {
+		const prefix string = ",\"available_from\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int(int(in.Loss))
+		out.Raw((*in.AvailableFrom).MarshalJSON())
 	}
-	{
-		const prefix string = ",\"id\":"
+	if in.AvailableUntil != nil {
+		const prefix string = ",\"available_until\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Uint(uint(in.UserID))
+		out.Raw((*in.AvailableUntil).MarshalJSON())
 	}
-	if in.Email != "" {
-		const prefix string = ",\"email\":"
+	{
+		const prefix string = ",\"hidden\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Email))
+		out.Bool(bool(in.Hidden))
 	}
-	if in.Password != "" {
-		const prefix string = ",\"password\":"
+	{
+		const prefix string = ",\"retired\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Password))
+		out.Bool(bool(in.Retired))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Profile) MarshalJSON() ([]byte, error) {
+func (v Bundle) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeApiModels10(&w, v)
+	easyjsonD2b7633eEncodeApiModels51(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Profile) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeApiModels10(w, v)
+func (v Bundle) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels51(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Profile) UnmarshalJSON(data []byte) error {
+func (v *Bundle) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeApiModels10(&r, v)
+	easyjsonD2b7633eDecodeApiModels51(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Profile) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeApiModels10(l, v)
+func (v *Bundle) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels51(l, v)
 }
-func easyjsonD2b7633eDecodeApiModels11(in *jlexer.Lexer, out *PositionList) {
+func easyjsonD2b7633eDecodeApiModels52(in *jlexer.Lexer, out *AllSkins) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1245,31 +6379,29 @@ func easyjsonD2b7633eDecodeApiModels11(in *jlexer.Lexer, out *PositionList) {
 			continue
 		}
 		switch key {
-		case "players":
+		case "skins":
 			if in.IsNull() {
 				in.Skip()
-				out.List = nil
+				out.Skins = nil
 			} else {
 				in.Delim('[')
-				if out.List == nil {
+				if out.Skins == nil {
 					if !in.IsDelim(']') {
-						out.List = make([]Position, 0, 2)
+						out.Skins = make([]Skin, 0, 1)
 					} else {
-						out.List = []Position{}
+						out.Skins = []Skin{}
 					}
 				} else {
-					out.List = (out.List)[:0]
+					out.Skins = (out.Skins)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v10 Position
-					(v10).UnmarshalEasyJSON(in)
-					out.List = append(out.List, v10)
+					var v70 Skin
+					(v70).UnmarshalEasyJSON(in)
+					out.Skins = append(out.Skins, v70)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "total":
-			out.Total = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -1280,68 +6412,58 @@ func easyjsonD2b7633eDecodeApiModels11(in *jlexer.Lexer, out *PositionList) {
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeApiModels11(out *jwriter.Writer, in PositionList) {
+func easyjsonD2b7633eEncodeApiModels52(out *jwriter.Writer, in AllSkins) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"players\":"
+		const prefix string = ",\"skins\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.List == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Skins == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v11, v12 := range in.List {
-				if v11 > 0 {
+			for v71, v72 := range in.Skins {
+				if v71 > 0 {
 					out.RawByte(',')
 				}
-				(v12).MarshalEasyJSON(out)
+				(v72).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
-	{
-		const prefix string = ",\"total\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Int(int(in.Total))
-	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v PositionList) MarshalJSON() ([]byte, error) {
+func (v AllSkins) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeApiModels11(&w, v)
+	easyjsonD2b7633eEncodeApiModels52(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v PositionList) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeApiModels11(w, v)
+func (v AllSkins) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels52(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *PositionList) UnmarshalJSON(data []byte) error {
+func (v *AllSkins) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeApiModels11(&r, v)
+	easyjsonD2b7633eDecodeApiModels52(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *PositionList) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeApiModels11(l, v)
+func (v *AllSkins) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels52(l, v)
 }
-func easyjsonD2b7633eDecodeApiModels12(in *jlexer.Lexer, out *Position) {
+func easyjsonD2b7633eDecodeApiModels53(in *jlexer.Lexer, out *AchievementList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1360,12 +6482,29 @@ func easyjsonD2b7633eDecodeApiModels12(in *jlexer.Lexer, out *Position) {
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = uint(in.Uint())
-		case "nickname":
-			out.Nickname = string(in.String())
-		case "record":
-			out.Points = int(in.Int())
+		case "achievements":
+			if in.IsNull() {
+				in.Skip()
+				out.Achievements = nil
+			} else {
+				in.Delim('[')
+				if out.Achievements == nil {
+					if !in.IsDelim(']') {
+						out.Achievements = make([]Achievement, 0, 1)
+					} else {
+						out.Achievements = []Achievement{}
+					}
+				} else {
+					out.Achievements = (out.Achievements)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v73 Achievement
+					(v73).UnmarshalEasyJSON(in)
+					out.Achievements = append(out.Achievements, v73)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1376,67 +6515,58 @@ func easyjsonD2b7633eDecodeApiModels12(in *jlexer.Lexer, out *Position) {
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeApiModels12(out *jwriter.Writer, in Position) {
+func easyjsonD2b7633eEncodeApiModels53(out *jwriter.Writer, in AchievementList) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Uint(uint(in.ID))
-	}
-	{
-		const prefix string = ",\"nickname\":"
+		const prefix string = ",\"achievements\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Nickname))
-	}
-	{
-		const prefix string = ",\"record\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
+		if in.Achievements == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
 		} else {
-			out.RawString(prefix)
+			out.RawByte('[')
+			for v74, v75 := range in.Achievements {
+				if v74 > 0 {
+					out.RawByte(',')
+				}
+				(v75).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
 		}
-		out.Int(int(in.Points))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Position) MarshalJSON() ([]byte, error) {
+func (v AchievementList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeApiModels12(&w, v)
+	easyjsonD2b7633eEncodeApiModels53(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Position) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeApiModels12(w, v)
+func (v AchievementList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels53(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Position) UnmarshalJSON(data []byte) error {
+func (v *AchievementList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeApiModels12(&r, v)
+	easyjsonD2b7633eDecodeApiModels53(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Position) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeApiModels12(l, v)
+func (v *AchievementList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels53(l, v)
 }
-func easyjsonD2b7633eDecodeApiModels13(in *jlexer.Lexer, out *Error) {
+func easyjsonD2b7633eDecodeApiModels54(in *jlexer.Lexer, out *Achievement) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1455,8 +6585,28 @@ func easyjsonD2b7633eDecodeApiModels13(in *jlexer.Lexer, out *Error) {
 			continue
 		}
 		switch key {
-		case "error":
-			out.What = string(in.String())
+		case "id":
+			out.ID = uint(in.Uint())
+		case "code":
+			out.Code = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "reward":
+			out.Reward = int(in.Int())
+		case "unlocked_at":
+			if in.IsNull() {
+				in.Skip()
+				out.UnlockedAt = nil
+			} else {
+				if out.UnlockedAt == nil {
+					out.UnlockedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.UnlockedAt).UnmarshalJSON(data))
+				}
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1467,146 +6617,93 @@ func easyjsonD2b7633eDecodeApiModels13(in *jlexer.Lexer, out *Error) {
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeApiModels13(out *jwriter.Writer, in Error) {
+func easyjsonD2b7633eEncodeApiModels54(out *jwriter.Writer, in Achievement) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"error\":"
+		const prefix string = ",\"id\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.What))
+		out.Uint(uint(in.ID))
 	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v Error) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeApiModels13(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Error) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeApiModels13(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *Error) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeApiModels13(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Error) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeApiModels13(l, v)
-}
-func easyjsonD2b7633eDecodeApiModels14(in *jlexer.Lexer, out *AllSkins) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
+	{
+		const prefix string = ",\"code\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
 		}
-		in.Skip()
-		return
+		out.String(string(in.Code))
 	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeString()
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "skins":
-			if in.IsNull() {
-				in.Skip()
-				out.Skins = nil
-			} else {
-				in.Delim('[')
-				if out.Skins == nil {
-					if !in.IsDelim(']') {
-						out.Skins = make([]Skin, 0, 2)
-					} else {
-						out.Skins = []Skin{}
-					}
-				} else {
-					out.Skins = (out.Skins)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v13 Skin
-					(v13).UnmarshalEasyJSON(in)
-					out.Skins = append(out.Skins, v13)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		default:
-			in.SkipRecursive()
+	{
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
 		}
-		in.WantComma()
+		out.String(string(in.Name))
 	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
+	{
+		const prefix string = ",\"description\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Description))
 	}
-}
-func easyjsonD2b7633eEncodeApiModels14(out *jwriter.Writer, in AllSkins) {
-	out.RawByte('{')
-	first := true
-	_ = first
 	{
-		const prefix string = ",\"skins\":"
+		const prefix string = ",\"reward\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.Skins == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
+		out.Int(int(in.Reward))
+	}
+	if in.UnlockedAt != nil {
+		const prefix string = ",\"unlocked_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
 		} else {
-			out.RawByte('[')
-			for v14, v15 := range in.Skins {
-				if v14 > 0 {
-					out.RawByte(',')
-				}
-				(v15).MarshalEasyJSON(out)
-			}
-			out.RawByte(']')
+			out.RawString(prefix)
 		}
+		out.Raw((*in.UnlockedAt).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v AllSkins) MarshalJSON() ([]byte, error) {
+func (v Achievement) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeApiModels14(&w, v)
+	easyjsonD2b7633eEncodeApiModels54(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v AllSkins) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeApiModels14(w, v)
+func (v Achievement) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeApiModels54(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *AllSkins) UnmarshalJSON(data []byte) error {
+func (v *Achievement) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeApiModels14(&r, v)
+	easyjsonD2b7633eDecodeApiModels54(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *AllSkins) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeApiModels14(l, v)
+func (v *Achievement) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeApiModels54(l, v)
 }
@@ -3,10 +3,13 @@ package models
 //easyjson:json
 type Profile struct {
 	User
-	Nickname string  `json:"nickname" example:"Nick"`
-	Avatar   *string `json:"avatar,omitempty"`
+	Nickname      string  `json:"nickname" example:"Nick"`
+	Avatar        *string `json:"avatar,omitempty"`
+	EmailVerified *bool   `json:"email_verified,omitempty" db:"email_verified"`
 	Stats
 	Store
+	LastMatches []MatchHistoryEntry `json:"last_matches,omitempty"`
+	Daily       *DailyStreak        `json:"daily,omitempty"`
 }
 
 //easyjson:json
@@ -15,6 +18,13 @@ type RegisterProfile struct {
 	UserPassword
 }
 
+//easyjson:json
+type ProfileUpdate struct {
+	RegisterProfile
+	// required to change email or password
+	CurrentPassword string `json:"current_password,omitempty" example:"password"`
+}
+
 //easyjson:json
 type User struct {
 	UserID uint `json:"id" db:"user_id"`
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+//easyjson:json
+type PromoCode struct {
+	ID             uint       `json:"id" db:"promo_id"`
+	Code           string     `json:"code" example:"KETNIPZ2026"`
+	Coins          int        `json:"coins,omitempty" example:"100"`
+	SkinID         *uint      `json:"skin,omitempty" example:"6" db:"skin_id"`
+	MaxRedemptions *int       `json:"max_redemptions,omitempty" example:"1000" db:"max_redemptions"`
+	Redeemed       int        `json:"redeemed" example:"10"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+}
+
+//easyjson:json
+type PromoCodeList struct {
+	Codes []PromoCode `json:"codes"`
+}
+
+// PromoBatch describes the codes to generate, a single code is created if Code is set
+//easyjson:json
+type PromoBatch struct {
+	PromoCode
+	Count  int    `json:"count,omitempty" example:"100"`
+	Prefix string `json:"prefix,omitempty" example:"NY"`
+}
+
+//easyjson:json
+type RedeemCode struct {
+	Code string `json:"code" example:"KETNIPZ2026"`
+}
+
+//easyjson:json
+type PromoReward struct {
+	Coins  int   `json:"coins,omitempty" example:"100"`
+	SkinID *uint `json:"skin,omitempty" example:"6"`
+}
@@ -2,18 +2,26 @@ package models
 
 //easyjson:json
 type Position struct {
-	ID       uint   `json:"id" example:"42" db:"user_id"`
-	Nickname string `json:"nickname" example:"Nick"`
-	Points   int    `json:"record" example:"100500" db:"record"`
+	ID        uint   `json:"id" example:"42" db:"user_id"`
+	Nickname  string `json:"nickname" example:"Nick"`
+	Points    int    `json:"record" example:"100500" db:"record"`
+	Rank      int    `json:"rank" example:"1" db:"rank"`
+	DenseRank int    `json:"dense_rank" example:"1" db:"dense_rank"`
 }
 
 //easyjson:json
 type PositionList struct {
 	List  []Position `json:"players"`
 	Total int        `json:"total" example:"1"`
+	Next  string     `json:"next,omitempty" example:"100500,42"`
 }
 
 type FetchScoreboardPage struct {
 	Limit uint
 	Page  uint
 }
+
+type ScoreboardCursor struct {
+	Record int
+	UserID uint
+}
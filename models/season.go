@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	PeriodAll    = "all"
+	PeriodDaily  = "daily"
+	PeriodWeekly = "weekly"
+	PeriodSeason = "season"
+)
+
+//easyjson:json
+type Season struct {
+	ID       uint      `json:"id" example:"1" db:"season_id"`
+	Name     string    `json:"name" example:"Winter 2019" db:"season_name"`
+	StartsAt time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt   time.Time `json:"ends_at" db:"ends_at"`
+	Archived bool      `json:"archived"`
+}
+
+//easyjson:json
+type SeasonList struct {
+	Seasons []Season `json:"seasons"`
+}
@@ -1,6 +1,28 @@
 package models
 
+import (
+	"time"
+)
+
 //easyjson:json
 type Session struct {
 	SessionID string `json:"session_id" example:"ef84d238-47ef-4452-9536-99380db79911"`
 }
+
+//easyjson:json
+type SessionInfo struct {
+	// the clients never get the IDs of the other sessions
+	SessionID  string    `json:"-" db:"session_id"`
+	UserID     uint      `json:"-" db:"user_id"`
+	Device     string    `json:"device" example:"Android" db:"device"`
+	UserAgent  string    `json:"user_agent" example:"Mozilla/5.0 (Linux; Android 9)" db:"user_agent"`
+	IP         string    `json:"ip" example:"192.0.2.1" db:"ip"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at" db:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
+
+//easyjson:json
+type SessionList struct {
+	Sessions []SessionInfo `json:"sessions"`
+}
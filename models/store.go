@@ -1,5 +1,16 @@
 package models
 
+import (
+	"time"
+)
+
+const (
+	RarityCommon    = "common"
+	RarityRare      = "rare"
+	RarityEpic      = "epic"
+	RarityLegendary = "legendary"
+)
+
 //easyjson:json
 type Store struct {
 	Coins          *int   `json:"coins,omitempty"`
@@ -9,9 +20,19 @@ type Store struct {
 
 //easyjson:json
 type Skin struct {
-	ID   uint   `json:"id" db:"skin_id"`
-	Name string `json:"name" db:"skin_name"`
-	Cost int    `json:"cost"`
+	ID             uint       `json:"id" db:"skin_id"`
+	Name           string     `json:"name" db:"skin_name"`
+	Cost           int        `json:"cost"`
+	Description    string     `json:"description" example:"Spooky"`
+	Rarity         string     `json:"rarity" example:"rare"`
+	AssetURL       *string    `json:"asset_url,omitempty" db:"asset_url"`
+	AvailableFrom  *time.Time `json:"available_from,omitempty" db:"available_from"`
+	AvailableUntil *time.Time `json:"available_until,omitempty" db:"available_until"`
+	Hidden         bool       `json:"hidden"`
+	Retired        bool       `json:"retired"`
+	// price with the best current sale, cost is the original price
+	Price      int        `json:"price" example:"40"`
+	SaleEndsAt *time.Time `json:"sale_ends_at,omitempty" db:"sale_ends_at"`
 }
 
 //easyjson:json
@@ -23,3 +44,43 @@ type AllSkins struct {
 type RequestSkin struct {
 	ID uint `json:"skin"`
 }
+
+//easyjson:json
+type Sale struct {
+	ID         uint      `json:"id" db:"sale_id"`
+	SkinID     uint      `json:"skin" db:"skin_id"`
+	PercentOff *int      `json:"percent_off,omitempty" example:"20" db:"percent_off"`
+	AmountOff  *int      `json:"amount_off,omitempty" example:"30" db:"amount_off"`
+	StartsAt   time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt     time.Time `json:"ends_at" db:"ends_at"`
+}
+
+//easyjson:json
+type SaleList struct {
+	Sales []Sale `json:"sales"`
+}
+
+//easyjson:json
+type Bundle struct {
+	ID          uint   `json:"id" db:"bundle_id"`
+	Name        string `json:"name" example:"Halloween" db:"bundle_name"`
+	Description string `json:"description"`
+	Cost        int    `json:"cost" example:"250"`
+	// sum of the current prices of the skins
+	FullPrice      int        `json:"full_price" example:"350" db:"full_price"`
+	Skins          []uint     `json:"skins"`
+	AvailableFrom  *time.Time `json:"available_from,omitempty" db:"available_from"`
+	AvailableUntil *time.Time `json:"available_until,omitempty" db:"available_until"`
+	Hidden         bool       `json:"hidden"`
+	Retired        bool       `json:"retired"`
+}
+
+//easyjson:json
+type BundleList struct {
+	Bundles []Bundle `json:"bundles"`
+}
+
+//easyjson:json
+type RequestBundle struct {
+	ID uint `json:"bundle"`
+}